@@ -14,6 +14,7 @@ import (
 	"github.com/c-bata/go-prompt"
 	"github.com/c-bata/go-prompt/completer"
 	"github.com/fatih/color"
+	"github.com/mroy31/gonetem/internal/importer"
 	"github.com/mroy31/gonetem/internal/options"
 	"github.com/mroy31/gonetem/internal/proto"
 	"github.com/mroy31/gonetem/internal/utils"
@@ -69,6 +70,16 @@ func CreateProject(prjPath string) error {
 	return utils.CreateOneFileArchive(prj, networkFilename, []byte(emptyNetwork))
 }
 
+// ImportedProjectPath returns the path of the project converted from
+// filename: lab.clab.yml or lab.gns3 gives lab.gnet
+func ImportedProjectPath(filename string) string {
+	name := filepath.Base(filename)
+	for _, ext := range []string{".clab.yml", ".clab.yaml", ".gns3"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+	return filepath.Join(filepath.Dir(filename), name+".gnet")
+}
+
 func OpenProject(prjPath string) (string, string, error) {
 	data, err := ioutil.ReadFile(prjPath)
 	if err != nil {
@@ -258,6 +269,46 @@ var createCmd = &cobra.Command{
 	},
 }
 
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import a containerlab or GNS3 topology",
+	Long:  `Convert a containerlab topology (.clab.yml) or a GNS3 project (.gns3) to a project, <name>.gnet by default`,
+	Args:  cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		prjPath := ImportedProjectPath(args[0])
+		if len(args) == 2 {
+			prjPath = args[1]
+		}
+		if filepath.Ext(prjPath) != ".gnet" {
+			Fatal("gonetem accepts only project with .gnet extension")
+		}
+		if _, err := os.Stat(prjPath); err == nil {
+			Fatal("Project %s already exist", prjPath)
+		}
+
+		project, err := importer.Import(args[0])
+		if err != nil {
+			Fatal("Unable to import %s: \n\t%v\n", args[0], err)
+		}
+		for _, issue := range project.Issues() {
+			fmt.Println(color.YellowString(issue))
+		}
+
+		prj, err := os.Create(prjPath)
+		if err != nil {
+			Fatal("Unable to create project %s: \n\t%v\n", prjPath, err)
+		}
+		defer prj.Close()
+		if err := project.Archive(prj); err != nil {
+			os.Remove(prjPath)
+			Fatal("Unable to create project %s: \n\t%v\n", prjPath, err)
+		}
+
+		fmt.Println(color.GreenString("Project " + prjPath + " has been created"))
+		fmt.Println(color.GreenString("You can now launch it with the command: gonetem-console open " + prjPath))
+	},
+}
+
 var openCmd = &cobra.Command{
 	Use:   "open",
 	Short: "Open a project",
@@ -355,6 +406,7 @@ func Init() {
 	rootCmd.AddCommand(listCmd)
 	rootCmd.AddCommand(createCmd)
 	rootCmd.AddCommand(connectCmd)
+	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(openCmd)
 	rootCmd.AddCommand(consoleCmd)
 	rootCmd.AddCommand(pullCmd)
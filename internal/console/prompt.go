@@ -63,10 +63,12 @@ type NetemNode struct {
 }
 
 type NetemCommand struct {
-	Desc  string
-	Usage string
-	Args  []string
-	Run   func(p *NetemPrompt, cmdArgs []string)
+	Desc    string
+	Usage   string
+	Args    []string
+	VarArgs bool // the last argument can be repeated
+	OptArgs int  // number of trailing arguments which are optional
+	Run     func(p *NetemPrompt, cmdArgs []string)
 }
 
 type NetemPrompt struct {
@@ -145,6 +147,31 @@ func (p *NetemPrompt) RegisterCommands() {
 			p.execWithClient(cmdArgs, p.Edit)
 		},
 	}
+	p.commands["expand"] = &NetemCommand{
+		Desc:  "Display the topology with variables and generators expanded",
+		Usage: "expand",
+		Args:  []string{},
+		Run: func(p *NetemPrompt, cmdArgs []string) {
+			p.execWithClient(cmdArgs, p.Expand)
+		},
+	}
+	p.commands["export"] = &NetemCommand{
+		Desc:    "Export the diagram of the topology, in a file if given",
+		Usage:   "export dot|svg|json [<file>]",
+		Args:    []string{`^(dot|svg|json)$`, `^\S+$`},
+		OptArgs: 1,
+		Run: func(p *NetemPrompt, cmdArgs []string) {
+			p.execWithClient(cmdArgs, p.Export)
+		},
+	}
+	p.commands["lint"] = &NetemCommand{
+		Desc:  "Check the topology with the saved configuration files of the nodes",
+		Usage: "lint",
+		Args:  []string{},
+		Run: func(p *NetemPrompt, cmdArgs []string) {
+			p.execWithClient(cmdArgs, p.Lint)
+		},
+	}
 	p.commands["ifState"] = &NetemCommand{
 		Desc:  "Enable/disable a node interface",
 		Usage: "ifState <node_name>.<if_number> up|down",
@@ -153,6 +180,50 @@ func (p *NetemPrompt) RegisterCommands() {
 			p.execWithClient(cmdArgs, p.IfState)
 		},
 	}
+	p.commands["link"] = &NetemCommand{
+		Desc:    "Modify parameters of a link without reloading the project",
+		Usage:   "link <node_name>.<if_number> <param>=<value> [<param>=<value> ...]",
+		Args:    []string{`^\w+\.\d+$`, `^[\w.]+=\S+$`},
+		VarArgs: true,
+		Run: func(p *NetemPrompt, cmdArgs []string) {
+			p.execWithClient(cmdArgs, p.LinkParams)
+		},
+	}
+	p.commands["linkState"] = &NetemCommand{
+		Desc:  "Enable/disable both interfaces of a link",
+		Usage: "linkState <node_name>.<if_number> <node_name>.<if_number> up|down",
+		Args:  []string{`^\w+\.\d+$`, `^\w+\.\d+$`, `^(up|down)$`},
+		Run: func(p *NetemPrompt, cmdArgs []string) {
+			p.execWithClient(cmdArgs, p.LinkState)
+		},
+	}
+	p.commands["linkFlap"] = &NetemCommand{
+		Desc:    "Set a link down/up periodically or randomly",
+		Usage:   "linkFlap <node_name>.<if_number> <node_name>.<if_number> periodic|random <up_ms> <down_ms> | stop",
+		Args:    []string{`^\w+\.\d+$`, `^\w+\.\d+$`, `^\w+$`},
+		VarArgs: true,
+		Run: func(p *NetemPrompt, cmdArgs []string) {
+			p.execWithClient(cmdArgs, p.LinkFlap)
+		},
+	}
+	p.commands["move"] = &NetemCommand{
+		Desc:    "Move a node of wireless segments, optionally in <duration_ms>",
+		Usage:   "move <node_name> <x> <y> [<duration_ms>]",
+		Args:    []string{`^\w+$`, `^-?\d*\.?\d+$`, `^-?\d*\.?\d+$`, `^\d+$`},
+		OptArgs: 1,
+		Run: func(p *NetemPrompt, cmdArgs []string) {
+			p.execWithClient(cmdArgs, p.MoveNode)
+		},
+	}
+	p.commands["profile"] = &NetemCommand{
+		Desc:    "Start, pause, resume or stop a profile on a link",
+		Usage:   "profile <node_name>.<if_number> start <profile> [loop] | pause | resume | stop",
+		Args:    []string{`^\w+\.\d+$`, `^\w+$`},
+		VarArgs: true,
+		Run: func(p *NetemPrompt, cmdArgs []string) {
+			p.execWithClient(cmdArgs, p.LinkProfile)
+		},
+	}
 	p.commands["reload"] = &NetemCommand{
 		Desc:  "Reload the project",
 		Usage: "reload",
@@ -227,6 +298,15 @@ func (p *NetemPrompt) RegisterCommands() {
 			p.execWithClient(cmdArgs, p.Stop)
 		},
 	}
+	p.commands["stats"] = &NetemCommand{
+		Desc:    "Display the rates and counters of links",
+		Usage:   "stats [<node_name>.<if_number>]",
+		Args:    []string{`^\w+\.\d+$`},
+		OptArgs: 1,
+		Run: func(p *NetemPrompt, cmdArgs []string) {
+			p.execWithClient(cmdArgs, p.LinkStats)
+		},
+	}
 	p.commands["status"] = &NetemCommand{
 		Desc:  "Display the state of the project",
 		Usage: "status",
@@ -279,13 +359,19 @@ func (p *NetemPrompt) Execute(s string) {
 	}
 
 	// check args
-	if len(args) != len(cmd.Args)+1 {
+	nbArgs, minArgs := len(args)-1, len(cmd.Args)-cmd.OptArgs
+	if (nbArgs < minArgs || nbArgs > len(cmd.Args)) && !(cmd.VarArgs && nbArgs >= minArgs) {
 		RedPrintf("Wrong number of arguments for '%s'\n\tusage: %s\n", args[0], cmd.Usage)
 		return
 	}
-	for idx, argRe := range cmd.Args {
+	for idx, arg := range args[1:] {
+		argRe := cmd.Args[len(cmd.Args)-1]
+		if idx < len(cmd.Args) {
+			argRe = cmd.Args[idx]
+		}
+
 		r, _ := regexp.Compile(argRe)
-		if !r.MatchString(args[idx+1]) {
+		if !r.MatchString(arg) {
 			RedPrintf("Wrong format for argument %d\n\tusage: %s\n", idx+1, cmd.Usage)
 			return
 		}
@@ -543,6 +629,192 @@ func (p *NetemPrompt) IfState(client proto.NetemClient, cmdArgs []string) {
 	}
 }
 
+func (p *NetemPrompt) LinkParams(client proto.NetemClient, cmdArgs []string) {
+	ifArgs := strings.Split(cmdArgs[0], ".")
+	ifIndex, _ := strconv.Atoi(ifArgs[1])
+
+	params := make(map[string]string)
+	for _, arg := range cmdArgs[1:] {
+		kv := strings.SplitN(arg, "=", 2)
+		params[kv[0]] = kv[1]
+	}
+
+	ack, err := client.SetLinkParams(
+		context.Background(),
+		&proto.LinkParamsRequest{
+			PrjId:   p.prjID,
+			Node:    ifArgs[0],
+			IfIndex: int32(ifIndex),
+			Params:  params,
+		})
+	if err != nil {
+		RedPrintf("Unable to modify link parameters: %v\n", err)
+	} else if ack.GetStatus().GetCode() == proto.StatusCode_ERROR {
+		RedPrintf(ack.GetStatus().GetError() + "\n")
+	}
+}
+
+func (p *NetemPrompt) LinkState(client proto.NetemClient, cmdArgs []string) {
+	state := proto.IfState_UP
+	if cmdArgs[2] == "down" {
+		state = proto.IfState_DOWN
+	}
+
+	ack, err := client.SetLinkState(
+		context.Background(),
+		&proto.LinkStateRequest{
+			PrjId: p.prjID,
+			Peer1: cmdArgs[0],
+			Peer2: cmdArgs[1],
+			State: state,
+		})
+	if err != nil {
+		RedPrintf("Unable to change link state: %v\n", err)
+	} else if ack.GetStatus().GetCode() == proto.StatusCode_ERROR {
+		RedPrintf(ack.GetStatus().GetError() + "\n")
+	}
+}
+
+func (p *NetemPrompt) LinkFlap(client proto.NetemClient, cmdArgs []string) {
+	request := &proto.LinkFlapRequest{
+		PrjId: p.prjID,
+		Peer1: cmdArgs[0],
+		Peer2: cmdArgs[1],
+	}
+
+	switch cmdArgs[2] {
+	case "stop":
+		if len(cmdArgs) != 3 {
+			RedPrintf("Wrong arguments for 'linkFlap stop'\n\tusage: %s\n", p.commands["linkFlap"].Usage)
+			return
+		}
+		request.Mode = proto.LinkFlapRequest_STOP
+	case "periodic", "random":
+		if len(cmdArgs) != 5 {
+			RedPrintf("Wrong arguments for 'linkFlap %s'\n\tusage: %s\n", cmdArgs[2], p.commands["linkFlap"].Usage)
+			return
+		}
+		up, errUp := strconv.Atoi(cmdArgs[3])
+		down, errDown := strconv.Atoi(cmdArgs[4])
+		if errUp != nil || errDown != nil {
+			RedPrintf("Up and down durations must be integers in ms\n")
+			return
+		}
+
+		request.Mode = proto.LinkFlapRequest_PERIODIC
+		if cmdArgs[2] == "random" {
+			request.Mode = proto.LinkFlapRequest_RANDOM
+		}
+		request.Up = int32(up)
+		request.Down = int32(down)
+	default:
+		RedPrintf("Unknown flap mode '%s'\n\tusage: %s\n", cmdArgs[2], p.commands["linkFlap"].Usage)
+		return
+	}
+
+	ack, err := client.SetLinkFlap(context.Background(), request)
+	if err != nil {
+		RedPrintf("Unable to set link flapping: %v\n", err)
+	} else if ack.GetStatus().GetCode() == proto.StatusCode_ERROR {
+		RedPrintf(ack.GetStatus().GetError() + "\n")
+	}
+}
+
+func (p *NetemPrompt) Expand(client proto.NetemClient, cmdArgs []string) {
+	response, err := client.ReadExpandedNetworkFile(context.Background(), &proto.ProjectRequest{Id: p.prjID})
+	if err != nil {
+		RedPrintf("Unable to get network file: %v\n", err)
+		return
+	} else if response.GetStatus().GetCode() == proto.StatusCode_ERROR {
+		RedPrintf(response.GetStatus().GetError() + "\n")
+		return
+	}
+
+	fmt.Print(string(response.GetData()))
+}
+
+func (p *NetemPrompt) Export(client proto.NetemClient, cmdArgs []string) {
+	response, err := client.Export(context.Background(), &proto.ExportRequest{PrjId: p.prjID, Format: cmdArgs[0]})
+	if err != nil {
+		RedPrintf("Unable to export topology: %v\n", err)
+		return
+	} else if response.GetStatus().GetCode() == proto.StatusCode_ERROR {
+		RedPrintf(response.GetStatus().GetError() + "\n")
+		return
+	}
+
+	if len(cmdArgs) == 1 {
+		fmt.Print(string(response.GetData()))
+		return
+	}
+	if err := ioutil.WriteFile(cmdArgs[1], response.GetData(), 0644); err != nil {
+		RedPrintf("Unable to write diagram to %s: %v\n", cmdArgs[1], err)
+	}
+}
+
+func (p *NetemPrompt) MoveNode(client proto.NetemClient, cmdArgs []string) {
+	x, _ := strconv.ParseFloat(cmdArgs[1], 64)
+	y, _ := strconv.ParseFloat(cmdArgs[2], 64)
+	request := &proto.MoveNodeRequest{
+		PrjId: p.prjID,
+		Node:  cmdArgs[0],
+		X:     x,
+		Y:     y,
+	}
+	if len(cmdArgs) == 4 {
+		duration, _ := strconv.Atoi(cmdArgs[3])
+		request.Duration = int32(duration)
+	}
+
+	ack, err := client.MoveNode(context.Background(), request)
+	if err != nil {
+		RedPrintf("Unable to move node: %v\n", err)
+	} else if ack.GetStatus().GetCode() == proto.StatusCode_ERROR {
+		RedPrintf(ack.GetStatus().GetError() + "\n")
+	}
+}
+
+func (p *NetemPrompt) LinkProfile(client proto.NetemClient, cmdArgs []string) {
+	ifArgs := strings.Split(cmdArgs[0], ".")
+	ifIndex, _ := strconv.Atoi(ifArgs[1])
+
+	request := &proto.LinkProfileRequest{
+		PrjId:   p.prjID,
+		Node:    ifArgs[0],
+		IfIndex: int32(ifIndex),
+	}
+	switch cmdArgs[1] {
+	case "start":
+		if len(cmdArgs) < 3 || len(cmdArgs) > 4 || (len(cmdArgs) == 4 && cmdArgs[3] != "loop") {
+			RedPrintf("Wrong arguments for 'profile start'\n\tusage: %s\n", p.commands["profile"].Usage)
+			return
+		}
+		request.Action = proto.LinkProfileRequest_START
+		request.Profile = cmdArgs[2]
+		request.Loop = len(cmdArgs) == 4
+	case "pause", "resume", "stop":
+		if len(cmdArgs) != 2 {
+			RedPrintf("Wrong arguments for 'profile %s'\n\tusage: %s\n", cmdArgs[1], p.commands["profile"].Usage)
+			return
+		}
+		request.Action = map[string]proto.LinkProfileRequest_Action{
+			"pause":  proto.LinkProfileRequest_PAUSE,
+			"resume": proto.LinkProfileRequest_RESUME,
+			"stop":   proto.LinkProfileRequest_STOP,
+		}[cmdArgs[1]]
+	default:
+		RedPrintf("Unknown profile action '%s'\n\tusage: %s\n", cmdArgs[1], p.commands["profile"].Usage)
+		return
+	}
+
+	ack, err := client.LinkProfile(context.Background(), request)
+	if err != nil {
+		RedPrintf("Unable to %s link profile: %v\n", cmdArgs[1], err)
+	} else if ack.GetStatus().GetCode() == proto.StatusCode_ERROR {
+		RedPrintf(ack.GetStatus().GetError() + "\n")
+	}
+}
+
 func (p *NetemPrompt) Check(client proto.NetemClient, cmdArgs []string) {
 	ack, err := client.Check(context.Background(), &proto.ProjectRequest{Id: p.prjID})
 	if err != nil {
@@ -556,6 +828,25 @@ func (p *NetemPrompt) Check(client proto.NetemClient, cmdArgs []string) {
 	}
 }
 
+func (p *NetemPrompt) Lint(client proto.NetemClient, cmdArgs []string) {
+	response, err := client.Lint(context.Background(), &proto.ProjectRequest{Id: p.prjID})
+	if err != nil {
+		RedPrintf(err.Error() + "\n")
+		return
+	} else if response.GetStatus().GetCode() == proto.StatusCode_ERROR {
+		RedPrintf(response.GetStatus().GetError() + "\n")
+		return
+	}
+
+	if len(response.GetIssues()) == 0 {
+		fmt.Println(color.GreenString("No issue found"))
+		return
+	}
+	for _, issue := range response.GetIssues() {
+		fmt.Println(color.YellowString(issue))
+	}
+}
+
 func (p *NetemPrompt) startConsole(client proto.NetemClient, nodeName string, shell bool) {
 	// first check that we can run console for this node
 	ack, err := client.CanRunConsole(context.Background(), &proto.NodeRequest{
@@ -797,6 +1088,13 @@ func (p *NetemPrompt) Reload(client proto.NetemClient, cmdArgs []string) {
 		return
 	}
 
+	if len(answer.Changes) == 0 {
+		fmt.Println("No change in the topology")
+	}
+	for _, change := range answer.Changes {
+		fmt.Printf("%s %s %s\n", change.Action, change.Kind, change.Name)
+	}
+
 	// Display warning messages from run command
 	for _, nMessages := range answer.NodeMessages {
 		if len(nMessages.Messages) > 0 {
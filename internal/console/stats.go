@@ -0,0 +1,121 @@
+package console
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/mroy31/gonetem/internal/proto"
+)
+
+const (
+	// time between the two polls used to compute rates
+	statsInterval = time.Second
+)
+
+func formatRate(bytes uint64, seconds float64) string {
+	bps := float64(bytes*8) / seconds
+	switch {
+	case bps >= 1e9:
+		return fmt.Sprintf("%.2f Gbps", bps/1e9)
+	case bps >= 1e6:
+		return fmt.Sprintf("%.2f Mbps", bps/1e6)
+	case bps >= 1e3:
+		return fmt.Sprintf("%.2f kbps", bps/1e3)
+	}
+	return fmt.Sprintf("%.0f bps", bps)
+}
+
+func (p *NetemPrompt) pollLinkStats(client proto.NetemClient, node string, ifIndex int) (*proto.LinkStatsResponse, error) {
+	response, err := client.GetLinkStats(
+		context.Background(),
+		&proto.NodeInterfaceRequest{
+			PrjId:   p.prjID,
+			Node:    node,
+			IfIndex: int32(ifIndex),
+		})
+	if err != nil {
+		return nil, err
+	} else if response.GetStatus().GetCode() == proto.StatusCode_ERROR {
+		return nil, errors.New(response.GetStatus().GetError())
+	}
+	return response, nil
+}
+
+func (p *NetemPrompt) LinkStats(client proto.NetemClient, cmdArgs []string) {
+	node, ifIndex := "", 0
+	if len(cmdArgs) > 0 {
+		ifArgs := strings.Split(cmdArgs[0], ".")
+		node = ifArgs[0]
+		ifIndex, _ = strconv.Atoi(ifArgs[1])
+	}
+
+	first, err := p.pollLinkStats(client, node, ifIndex)
+	if err != nil {
+		RedPrintf("Unable to get link stats: %v\n", err)
+		return
+	}
+	time.Sleep(statsInterval)
+	second, err := p.pollLinkStats(client, node, ifIndex)
+	if err != nil {
+		RedPrintf("Unable to get link stats: %v\n", err)
+		return
+	}
+
+	fmt.Print(formatLinkStats(first, second))
+}
+
+// formatLinkStats returns the rates and counters of each interface
+// computed between the polls first and second
+func formatLinkStats(first, second *proto.LinkStatsResponse) string {
+	seconds := float64(second.GetTimestamp()-first.GetTimestamp()) / 1e9
+	if seconds <= 0 {
+		// the clock of the server has been changed between polls
+		seconds = statsInterval.Seconds()
+	}
+	previous := make(map[string]*proto.LinkStatsResponse_IfStats)
+	for _, ifStats := range first.GetInterfaces() {
+		previous[fmt.Sprintf("%s.%d", ifStats.GetNode(), ifStats.GetIfIndex())] = ifStats
+	}
+
+	var out strings.Builder
+	for _, cur := range second.GetInterfaces() {
+		name := fmt.Sprintf("%s.%d", cur.GetNode(), cur.GetIfIndex())
+		prev, found := previous[name]
+		if !found {
+			continue
+		}
+
+		out.WriteString(color.BlueString(name) + " -> " + cur.GetRemote() + "\n")
+		fmt.Fprintf(&out, "  rx: %s, %.0f pps, errors %d, dropped %d\n",
+			formatRate(cur.GetRxBytes()-prev.GetRxBytes(), seconds),
+			float64(cur.GetRxPackets()-prev.GetRxPackets())/seconds,
+			cur.GetRxErrors(), cur.GetRxDropped())
+		fmt.Fprintf(&out, "  tx: %s, %.0f pps, errors %d, dropped %d\n",
+			formatRate(cur.GetTxBytes()-prev.GetTxBytes(), seconds),
+			float64(cur.GetTxPackets()-prev.GetTxPackets())/seconds,
+			cur.GetTxErrors(), cur.GetTxDropped())
+
+		prevQdiscs := make(map[string]*proto.LinkStatsResponse_QdiscStats)
+		for _, qdisc := range prev.GetQdiscs() {
+			prevQdiscs[qdisc.GetHandle()] = qdisc
+		}
+		for _, qdisc := range cur.GetQdiscs() {
+			prevQdisc, found := prevQdiscs[qdisc.GetHandle()]
+			if !found || prevQdisc.GetKind() != qdisc.GetKind() {
+				// qdisc has been replaced between polls
+				prevQdisc = &proto.LinkStatsResponse_QdiscStats{}
+			}
+			fmt.Fprintf(&out, "  qdisc %s %s: backlog %db %dp, drops %d (%+d), overlimits %d (%+d)\n",
+				qdisc.GetKind(), qdisc.GetHandle(),
+				qdisc.GetBacklog(), qdisc.GetQlen(),
+				qdisc.GetDrops(), int64(qdisc.GetDrops())-int64(prevQdisc.GetDrops()),
+				qdisc.GetOverlimits(), int64(qdisc.GetOverlimits())-int64(prevQdisc.GetOverlimits()))
+		}
+	}
+	return out.String()
+}
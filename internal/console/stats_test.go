@@ -0,0 +1,125 @@
+package console
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/mroy31/gonetem/internal/proto"
+)
+
+func TestStats_FormatRate(t *testing.T) {
+	tests := []struct {
+		desc     string
+		bytes    uint64
+		seconds  float64
+		expected string
+	}{
+		{desc: "Stats: bps", bytes: 100, seconds: 1, expected: "800 bps"},
+		{desc: "Stats: kbps", bytes: 125000, seconds: 2, expected: "500.00 kbps"},
+		{desc: "Stats: Mbps", bytes: 1250000, seconds: 1, expected: "10.00 Mbps"},
+		{desc: "Stats: Gbps", bytes: 1250000000, seconds: 0.5, expected: "20.00 Gbps"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if rate := formatRate(tt.bytes, tt.seconds); rate != tt.expected {
+				t.Errorf("Wrong rate %s != %s", rate, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStats_FormatLinkStats(t *testing.T) {
+	color.NoColor = true
+	first := &proto.LinkStatsResponse{
+		Timestamp: 0,
+		Interfaces: []*proto.LinkStatsResponse_IfStats{
+			{
+				Node: "R1", IfIndex: 0, Remote: "R2.0",
+				RxBytes: 1000, RxPackets: 10, TxBytes: 2000, TxPackets: 20,
+				Qdiscs: []*proto.LinkStatsResponse_QdiscStats{
+					{Kind: "netem", Handle: "1:0", Drops: 5, Overlimits: 1},
+					{Kind: "tbf", Handle: "2:0", Drops: 7},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		desc     string
+		second   *proto.LinkStatsResponse
+		expected string
+	}{
+		{
+			desc: "Stats: rates and qdisc deltas",
+			second: &proto.LinkStatsResponse{
+				Timestamp: 2e9,
+				Interfaces: []*proto.LinkStatsResponse_IfStats{
+					{
+						Node: "R1", IfIndex: 0, Remote: "R2.0",
+						RxBytes: 251000, RxPackets: 210, RxErrors: 1, TxBytes: 2000, TxPackets: 20,
+						Qdiscs: []*proto.LinkStatsResponse_QdiscStats{
+							{Kind: "netem", Handle: "1:0", Backlog: 1500, Qlen: 1, Drops: 8, Overlimits: 1},
+						},
+					},
+				},
+			},
+			expected: "R1.0 -> R2.0\n" +
+				"  rx: 1.00 Mbps, 100 pps, errors 1, dropped 0\n" +
+				"  tx: 0 bps, 0 pps, errors 0, dropped 0\n" +
+				"  qdisc netem 1:0: backlog 1500b 1p, drops 8 (+3), overlimits 1 (+0)\n",
+		},
+		{
+			desc: "Stats: replaced qdisc",
+			second: &proto.LinkStatsResponse{
+				Timestamp: 1e9,
+				Interfaces: []*proto.LinkStatsResponse_IfStats{
+					{
+						Node: "R1", IfIndex: 0, Remote: "R2.0",
+						RxBytes: 1000, RxPackets: 10, TxBytes: 2000, TxPackets: 20,
+						Qdiscs: []*proto.LinkStatsResponse_QdiscStats{
+							{Kind: "htb", Handle: "2:0", Drops: 2},
+						},
+					},
+				},
+			},
+			expected: "R1.0 -> R2.0\n" +
+				"  rx: 0 bps, 0 pps, errors 0, dropped 0\n" +
+				"  tx: 0 bps, 0 pps, errors 0, dropped 0\n" +
+				"  qdisc htb 2:0: backlog 0b 0p, drops 2 (+2), overlimits 0 (+0)\n",
+		},
+		{
+			desc: "Stats: same timestamp in both polls",
+			second: &proto.LinkStatsResponse{
+				Timestamp: 0,
+				Interfaces: []*proto.LinkStatsResponse_IfStats{
+					{
+						Node: "R1", IfIndex: 0, Remote: "R2.0",
+						RxBytes: 126000, RxPackets: 110, TxBytes: 2000, TxPackets: 20,
+					},
+				},
+			},
+			expected: "R1.0 -> R2.0\n" +
+				"  rx: 1.00 Mbps, 100 pps, errors 0, dropped 0\n" +
+				"  tx: 0 bps, 0 pps, errors 0, dropped 0\n",
+		},
+		{
+			desc: "Stats: interface missing in first poll",
+			second: &proto.LinkStatsResponse{
+				Timestamp: 1e9,
+				Interfaces: []*proto.LinkStatsResponse_IfStats{
+					{Node: "R2", IfIndex: 0, Remote: "R1.0"},
+				},
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if out := formatLinkStats(first, tt.second); out != tt.expected {
+				t.Errorf("Wrong output:\n%s\n!=\n%s", out, tt.expected)
+			}
+		})
+	}
+}
@@ -113,13 +113,39 @@ func (c *DockerClient) GetState(containerId string) (string, error) {
 	return container.State, nil
 }
 
-func (c *DockerClient) Create(imgName, containerName, hostName string, volumes []string, ipv6, mpls bool) (string, error) {
+// ContainerResources are the limits of a container, a zero value means
+// no limit
+type ContainerResources struct {
+	NanoCPUs   int64
+	Memory     int64 // bytes
+	PidsLimit  int64
+	CpusetCpus string
+}
+
+// ContainerOptions are the sysctls and the environment of a container,
+// Cmd and Entrypoint replace the ones of the image if they are set
+type ContainerOptions struct {
+	Sysctls    map[string]string
+	Env        []string // KEY=value
+	Cmd        []string
+	Entrypoint []string
+}
+
+func (c *DockerClient) Create(imgName, containerName, hostName string, volumes []string, ipv6, mpls bool, resources ContainerResources, opts ContainerOptions) (string, error) {
 	hostConfig := container.HostConfig{
 		NetworkMode: "none",
 		Privileged:  true,
 		CapAdd:      []string{"ALL"},
 		Sysctls:     make(map[string]string),
 		Binds:       volumes,
+		Resources: container.Resources{
+			NanoCPUs:   resources.NanoCPUs,
+			Memory:     resources.Memory,
+			CpusetCpus: resources.CpusetCpus,
+		},
+	}
+	if resources.PidsLimit > 0 {
+		hostConfig.Resources.PidsLimit = &resources.PidsLimit
 	}
 	if ipv6 {
 		hostConfig.Sysctls["net.ipv6.conf.all.disable_ipv6"] = "0"
@@ -128,11 +154,17 @@ func (c *DockerClient) Create(imgName, containerName, hostName string, volumes [
 		hostConfig.Sysctls["net.mpls.platform_labels"] = "100000"
 		hostConfig.Sysctls["net.mpls.conf.lo.input"] = "1"
 	}
+	for key, value := range opts.Sysctls {
+		hostConfig.Sysctls[key] = value
+	}
 
 	resp, err := c.cli.ContainerCreate(context.Background(), &container.Config{
-		Image:    imgName,
-		Hostname: hostName,
-		Tty:      false,
+		Image:      imgName,
+		Hostname:   hostName,
+		Tty:        false,
+		Env:        opts.Env,
+		Cmd:        opts.Cmd,
+		Entrypoint: opts.Entrypoint,
 	}, &hostConfig, nil, nil, containerName)
 	if err != nil {
 		return "", err
@@ -6,7 +6,9 @@ import (
 	"io"
 	"os"
 	"path"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/moby/term"
 	"github.com/mroy31/gonetem/internal/link"
@@ -26,15 +28,23 @@ type VrrpOptions struct {
 }
 
 type DockerNodeOptions struct {
-	Name      string
-	ShortName string
-	Type      string
-	ImgName   string
-	Ipv6      bool
-	Mpls      bool
-	Vrfs      []string
-	Vrrps     []VrrpOptions
-	Volumes   []string
+	Name       string
+	ShortName  string
+	Type       string
+	ImgName    string
+	Ipv6       bool
+	Mpls       bool
+	Vrfs       []string
+	Vrrps      []VrrpOptions
+	Volumes    []string
+	Resources  options.NodeResources
+	Cpuset     string
+	Sysctls    map[string]string
+	Env        map[string]string
+	Command    []string
+	Entrypoint []string
+	// kinds declared in the network file
+	Kinds map[string]options.NodeKind
 }
 
 type DockerNodeStatus struct {
@@ -47,6 +57,7 @@ type DockerNode struct {
 	Name           string
 	ShortName      string
 	Type           string
+	Kind           options.NodeKind
 	Interfaces     map[string]link.IfState
 	LocalNetnsName string
 	Running        bool
@@ -55,6 +66,8 @@ type DockerNode struct {
 	Vrfs           []string
 	Vrrps          []VrrpOptions
 	Volumes        []string
+	Resources      ContainerResources
+	Options        ContainerOptions
 	Logger         *logrus.Entry
 }
 
@@ -120,7 +133,7 @@ func (n *DockerNode) Create(imgName string, ipv6 bool) error {
 	}
 
 	containerName := fmt.Sprintf("%s%s.%s", options.NETEM_ID, n.PrjID, n.Name)
-	if n.ID, err = client.Create(imgName, containerName, n.Name, n.Volumes, ipv6, n.Mpls); err != nil {
+	if n.ID, err = client.Create(imgName, containerName, n.Name, n.Volumes, ipv6, n.Mpls, n.Resources, n.Options); err != nil {
 		return err
 	}
 
@@ -161,6 +174,37 @@ func (n *DockerNode) AddInterface(ifName string, ifIndex int, ns netns.NsHandle)
 	return nil
 }
 
+// RemoveInterface deletes the interface ifIndex, and so the veth pair it
+// belongs to. When the node is stopped, the interface is in its local netns
+func (n *DockerNode) RemoveInterface(ifIndex int) error {
+	ifName := n.GetInterfaceName(ifIndex)
+	if _, found := n.Interfaces[ifName]; !found {
+		return fmt.Errorf("Interface %s.%d not found", n.GetName(), ifIndex)
+	}
+
+	var ns netns.NsHandle
+	var err error
+	if n.Running {
+		ns, err = n.GetNetns()
+	} else {
+		ns, err = netns.GetFromName(n.LocalNetnsName)
+	}
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+
+	// the interface is already deleted if it was the peer of a removed one
+	if link.IsLinkExist(ifName, ns) {
+		if err := link.DeleteLink(ifName, ns); err != nil {
+			return err
+		}
+	}
+	delete(n.Interfaces, ifName)
+
+	return nil
+}
+
 func (n *DockerNode) PrepareInterface(ifName string) {
 	client, err := NewDockerClient()
 	if err != nil {
@@ -218,11 +262,8 @@ func (n *DockerNode) Console(shell bool, in io.ReadCloser, out io.Writer, resize
 	defer client.Close()
 
 	cmd := []string{"/bin/bash"}
-	if !shell {
-		switch n.Type {
-		case "router":
-			cmd = []string{"/usr/bin/vtysh"}
-		}
+	if !shell && n.Kind.Console != "" {
+		cmd = strings.Fields(n.Kind.Console)
 	}
 
 	return client.ExecTty(n.ID, cmd, in, out, resizeCh)
@@ -307,6 +348,29 @@ func (n *DockerNode) Stop() error {
 	return nil
 }
 
+// waitReady runs the readiness probe of the kind until it succeeds
+func (n *DockerNode) waitReady(client *DockerClient) error {
+	if n.Kind.Ready == nil {
+		return nil
+	}
+
+	timeout := n.Kind.Ready.Timeout
+	if timeout <= 0 {
+		timeout = options.DEFAULT_PROBE_TIMEOUT
+	}
+	deadline := time.Now().Add(time.Duration(timeout) * time.Second)
+	for {
+		_, err := client.Exec(n.ID, []string{"sh", "-c", n.Kind.Ready.Command})
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Node %s is not ready after %ds: %v", n.Name, timeout, err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
 func (n *DockerNode) LoadConfig(confPath string) ([]string, error) {
 	var messages []string
 
@@ -355,19 +419,14 @@ func (n *DockerNode) LoadConfig(confPath string) ([]string, error) {
 			}
 		}
 
+		if err := n.waitReady(client); err != nil {
+			return messages, err
+		}
+
 		if _, err := os.Stat(confPath); err == nil {
 			configFiles := make(map[string]string)
-			switch n.Type {
-			case "router":
-				configFiles[n.Name+".frr.conf"] = "/etc/frr/frr.conf"
-			case "host":
-				configFiles[n.Name+".net.conf"] = "/tmp/custom.net.conf"
-				configFiles[n.Name+".ntp.conf"] = "/etc/ntp.conf"
-			case "server":
-				configFiles[n.Name+".net.conf"] = "/tmp/custom.net.conf"
-				configFiles[n.Name+".ntp.conf"] = "/etc/ntp.conf"
-				configFiles[n.Name+".dhcpd.conf"] = "/etc/dhcp/dhcpd.conf"
-				configFiles[n.Name+".tftpd-hpa.default"] = "/etc/default/tftpd-hpa"
+			for _, file := range n.Kind.Files {
+				configFiles[n.Name+"."+file.Name] = file.Path
 			}
 
 			configFiles[n.Name+".init.conf"] = initScript
@@ -385,20 +444,12 @@ func (n *DockerNode) LoadConfig(confPath string) ([]string, error) {
 		}
 
 		// Start process when necessary
-		if n.Type == "router" {
-			// start FRR daemon
-			if _, err := client.Exec(n.ID, []string{"/usr/lib/frr/frrinit.sh", "start"}); err != nil {
+		if n.Kind.Start != "" {
+			output, err := client.Exec(n.ID, []string{"sh", "-c", n.Kind.Start})
+			if err != nil {
 				return messages, err
-			}
-		} else {
-			netconf := path.Join(confPath, n.Name+".net.conf")
-			if _, err := os.Stat(netconf); err == nil {
-				output, err := client.Exec(n.ID, []string{"network-config.py", "-l", "/tmp/custom.net.conf"})
-				if err != nil {
-					return messages, err
-				} else if output != "" {
-					messages = strings.Split(output, "\n")
-				}
+			} else if output != "" {
+				messages = strings.Split(output, "\n")
 			}
 		}
 
@@ -408,7 +459,7 @@ func (n *DockerNode) LoadConfig(confPath string) ([]string, error) {
 			if err != nil {
 				return messages, err
 			} else if output != "" {
-				messages = strings.Split(output, "\n")
+				messages = append(messages, strings.Split(output, "\n")...)
 			}
 		}
 
@@ -430,35 +481,15 @@ func (n *DockerNode) Save(dstPath string) error {
 	}
 	defer client.Close()
 
-	configFiles := make(map[string]string)
-	switch n.Type {
-	case "host":
-		confFile := "/tmp/custom.net.conf"
-		if _, err := client.Exec(n.ID, []string{"network-config.py", "-s", confFile}); err != nil {
+	if n.Kind.Save != "" {
+		if _, err := client.Exec(n.ID, []string{"sh", "-c", n.Kind.Save}); err != nil {
 			return err
 		}
-		configFiles[confFile] = fmt.Sprintf("%s.net.conf", n.Name)
-		configFiles["/etc/ntp.conf"] = fmt.Sprintf("%s.ntp.conf", n.Name)
+	}
 
-	case "server":
-		confFile := "/tmp/custom.net.conf"
-		if _, err := client.Exec(n.ID, []string{"network-config.py", "-s", confFile}); err != nil {
-			return err
-		}
-		configFiles[confFile] = fmt.Sprintf("%s.net.conf", n.Name)
-		configFiles["/etc/ntp.conf"] = fmt.Sprintf("%s.ntp.conf", n.Name)
-		configFiles["/etc/dhcp/dhcpd.conf"] = fmt.Sprintf("%s.dhcpd.conf", n.Name)
-		configFiles["/etc/default/tftpd-hpa"] = fmt.Sprintf("%s.tftpd-hpa.default", n.Name)
-
-	case "router":
-		confFile := "/etc/frr/frr.conf"
-		if _, err := client.Exec(n.ID, []string{"vtysh", "-w"}); err != nil {
-			return err
-		}
-		if _, err := client.Exec(n.ID, []string{"chmod", "+r", confFile}); err != nil {
-			return err
-		}
-		configFiles[confFile] = fmt.Sprintf("%s.frr.conf", n.Name)
+	configFiles := make(map[string]string)
+	for _, file := range n.Kind.Files {
+		configFiles[file.Path] = fmt.Sprintf("%s.%s", n.Name, file.Name)
 	}
 
 	// Save init script if it exists
@@ -571,19 +602,37 @@ func NewDockerNode(prjID string, dockerOpts DockerNodeOptions) (*DockerNode, err
 		}),
 	}
 
+	kind, found := options.GetNodeKind(dockerOpts.Type, dockerOpts.Kinds)
+	if !found {
+		return node, errors.New(fmt.Sprintf("Docker type %s is not known", dockerOpts.Type))
+	}
+	node.Kind = kind
+
+	resources, err := options.GetNodeResources(dockerOpts.Resources)
+	if err != nil {
+		return node, fmt.Errorf("Node %s: %w", dockerOpts.Name, err)
+	}
+	memory, _ := options.ParseMemory(resources.Memory)
+	node.Resources = ContainerResources{
+		NanoCPUs:   int64(resources.Cpus * 1e9),
+		Memory:     memory,
+		PidsLimit:  resources.PidsLimit,
+		CpusetCpus: dockerOpts.Cpuset,
+	}
+	node.Options = ContainerOptions{
+		Sysctls:    dockerOpts.Sysctls,
+		Cmd:        dockerOpts.Command,
+		Entrypoint: dockerOpts.Entrypoint,
+	}
+	for key, value := range dockerOpts.Env {
+		node.Options.Env = append(node.Options.Env, key+"="+value)
+	}
+	sort.Strings(node.Options.Env)
+
 	imgName := dockerOpts.ImgName
 	if imgName == "" {
-		// use default image
-		switch dockerOpts.Type {
-		case "host":
-			imgName = options.GetDockerImageId(options.IMG_HOST)
-		case "server":
-			imgName = options.GetDockerImageId(options.IMG_SERVER)
-		case "router":
-			imgName = options.GetDockerImageId(options.IMG_ROUTER)
-		default:
-			return node, errors.New(fmt.Sprintf("Docker type %s is not known", dockerOpts.Type))
-		}
+		// use the image of the kind
+		imgName = kind.Image
 	}
 
 	if err := node.Create(imgName, dockerOpts.Ipv6); err != nil {
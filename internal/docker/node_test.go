@@ -263,6 +263,7 @@ func TestDockerNode_AttachLink(t *testing.T) {
 	_, err = link.CreateVethLink(
 		node1.GetInterfaceName(0), node1Netns,
 		node2.GetInterfaceName(0), node2Netns,
+		link.DEFAULT_MTU, link.DEFAULT_TXQLEN,
 	)
 	if err != nil {
 		t.Fatalf("Unable to create veth: %v", err)
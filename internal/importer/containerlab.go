@@ -0,0 +1,192 @@
+package importer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const frrConfigPath = "/etc/frr/frr.conf"
+
+type clabNode struct {
+	Kind          string
+	Image         string
+	StartupConfig string `yaml:"startup-config"`
+	Binds         []string
+	Exec          []string
+}
+
+type clabLink struct {
+	Endpoints []string
+}
+
+type clabTopology struct {
+	Name     string
+	Topology struct {
+		Defaults clabNode
+		Kinds    map[string]clabNode
+		Nodes    map[string]clabNode
+		Links    []clabLink
+	}
+}
+
+// merge returns the node with the values of the kind and the defaults
+// for the options which are not set
+func (c *clabTopology) merge(node clabNode) clabNode {
+	defaults := c.Topology.Defaults
+	if node.Kind == "" {
+		node.Kind = defaults.Kind
+	}
+	kind := c.Topology.Kinds[node.Kind]
+	if node.Image == "" {
+		node.Image = kind.Image
+	}
+	if node.Image == "" {
+		node.Image = defaults.Image
+	}
+	if node.StartupConfig == "" {
+		node.StartupConfig = kind.StartupConfig
+	}
+	node.Binds = append(append(node.Binds, kind.Binds...), defaults.Binds...)
+	node.Exec = append(append(node.Exec, kind.Exec...), defaults.Exec...)
+	return node
+}
+
+// clabNodeType returns the gonetem type of a containerlab node, an empty
+// string if the kind is not supported
+func clabNodeType(node clabNode) string {
+	switch node.Kind {
+	case "linux":
+		return nodeType(node.Image)
+	case "bridge", "ovs-bridge":
+		return "ovs"
+	}
+	return ""
+}
+
+// bindTarget returns the path of a bind in the container
+func bindTarget(bind string) string {
+	split := strings.Split(bind, ":")
+	if len(split) < 2 {
+		return ""
+	}
+	return split[1]
+}
+
+// loadFrrConfig adds the FRR configuration of a router, given by the
+// startup-config or by a bind on /etc/frr/frr.conf
+func (p *Project) loadFrrConfig(name string, node clabNode, dir string) {
+	filename := node.StartupConfig
+	for _, bind := range node.Binds {
+		if bindTarget(bind) == frrConfigPath {
+			filename = strings.Split(bind, ":")[0]
+		}
+	}
+	if filename == "" {
+		return
+	}
+
+	if !path.IsAbs(filename) {
+		filename = path.Join(dir, filename)
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		p.report("Node %s: unable to read FRR configuration: %v", name, err)
+		return
+	}
+	p.addConfig(name, "frr.conf", data)
+}
+
+// ImportContainerlab converts a containerlab topology, dir is the folder
+// of the topology file where the configuration files are searched
+func ImportContainerlab(data []byte, dir string) (*Project, error) {
+	var topology clabTopology
+	if err := yaml.Unmarshal(data, &topology); err != nil {
+		return nil, fmt.Errorf("Unable to parse containerlab topology: %w", err)
+	}
+
+	p := newProject()
+	names := make(map[string]string)
+	clabNames := make([]string, 0, len(topology.Topology.Nodes))
+	for name := range topology.Topology.Nodes {
+		clabNames = append(clabNames, name)
+	}
+	sort.Strings(clabNames)
+
+	for _, clabName := range clabNames {
+		node := topology.merge(topology.Topology.Nodes[clabName])
+		nType := clabNodeType(node)
+		if nType == "" {
+			p.report("Node %s: kind '%s' is not supported", clabName, node.Kind)
+			continue
+		}
+		name := p.addNode(clabName, nType)
+		names[clabName] = name
+
+		switch nType {
+		case "docker.router":
+			p.loadFrrConfig(name, node, dir)
+			for _, cmd := range node.Exec {
+				p.report("Node %s: command '%s' is not converted", name, cmd)
+			}
+		case "docker.host":
+			if node.Image != "" {
+				p.report("Node %s: image %s is replaced by the image of docker.host", name, node.Image)
+			}
+			p.addCommands(name, node.Exec)
+		}
+		if nType != "docker.router" && node.StartupConfig != "" {
+			p.report("Node %s: startup-config %s is not converted", name, node.StartupConfig)
+		}
+		for _, bind := range node.Binds {
+			if nType != "docker.router" || bindTarget(bind) != frrConfigPath {
+				p.report("Node %s: bind %s is not converted", name, bind)
+			}
+		}
+	}
+
+	for idx, l := range topology.Topology.Links {
+		if len(l.Endpoints) != 2 {
+			p.report("Link %d: 2 endpoints are expected", idx)
+			continue
+		}
+
+		// endpoints are <node>:<interface>, or macvlan:<host interface>
+		type endpoint struct{ node, ifName string }
+		endpoints := make([]endpoint, 0, 2)
+		hostIf := ""
+		for _, e := range l.Endpoints {
+			split := strings.SplitN(e, ":", 2)
+			if len(split) != 2 {
+				p.report("Link %d: endpoint '%s' is not valid", idx, e)
+				break
+			}
+			if split[0] == "macvlan" {
+				hostIf = split[1]
+				continue
+			}
+			name, found := names[split[0]]
+			if !found {
+				p.report("Link %s: node %s is not converted", strings.Join(l.Endpoints, " - "), split[0])
+				break
+			}
+			endpoints = append(endpoints, endpoint{name, split[1]})
+		}
+
+		switch {
+		case len(endpoints) == 2:
+			p.Links = append(p.Links, Link{
+				Peer1: p.addInterface(endpoints[0].node, endpoints[0].ifName),
+				Peer2: p.addInterface(endpoints[1].node, endpoints[1].ifName),
+			})
+		case len(endpoints) == 1 && hostIf != "":
+			p.addBridge(hostIf, p.addInterface(endpoints[0].node, endpoints[0].ifName))
+		}
+	}
+
+	return p, nil
+}
@@ -0,0 +1,289 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type gns3Port struct {
+	Interface  string
+	PortNumber int `json:"port_number"`
+}
+
+type gns3Node struct {
+	NodeID     string `json:"node_id"`
+	Name       string
+	NodeType   string `json:"node_type"`
+	Properties struct {
+		Image        string
+		PortsMapping []gns3Port `json:"ports_mapping"`
+	}
+}
+
+type gns3LinkNode struct {
+	NodeID        string `json:"node_id"`
+	AdapterNumber int    `json:"adapter_number"`
+	PortNumber    int    `json:"port_number"`
+}
+
+type gns3Link struct {
+	Nodes   []gns3LinkNode
+	Filters map[string][]interface{}
+	Suspend bool
+}
+
+type gns3Project struct {
+	Name     string
+	Topology struct {
+		Nodes []gns3Node
+		Links []gns3Link
+	}
+}
+
+// gns3NodeType returns the gonetem type of a GNS3 node, an empty string
+// if the type is not supported
+func gns3NodeType(node gns3Node) string {
+	switch node.NodeType {
+	case "docker":
+		return nodeType(node.Properties.Image)
+	case "vpcs":
+		return "docker.host"
+	case "ethernet_switch", "ethernet_hub":
+		return "ovs"
+	}
+	return ""
+}
+
+// parseMask returns the prefix length of value, a length or a netmask,
+// -1 if value is not a mask
+func parseMask(value string) int {
+	if length, err := strconv.Atoi(strings.TrimPrefix(value, "/")); err == nil {
+		return length
+	}
+	if ip := net.ParseIP(value).To4(); ip != nil && ip[0] == 255 {
+		if ones, bits := net.IPMask(ip).Size(); bits != 0 {
+			return ones
+		}
+	}
+	return -1
+}
+
+// loadVpcsConfig converts the ip commands of a VPCS startup script, like
+// "ip 192.168.1.1 192.168.1.254 24"
+func (p *Project) loadVpcsConfig(node string, data []byte) {
+	config := NetConfig{Interfaces: make(map[string][]string), Routes: make([]NetRoute, 0)}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || (fields[0] == "set" && len(fields) > 1 && fields[1] == "pcname") {
+			continue
+		}
+		if fields[0] != "ip" || len(fields) < 2 || fields[1] == "dhcp" {
+			p.report("Node %s: command '%s' is not converted", node, strings.TrimSpace(line))
+			continue
+		}
+
+		address, length, gateway := fields[1], 24, ""
+		if split := strings.SplitN(address, "/", 2); len(split) == 2 {
+			address, length = split[0], parseMask(split[1])
+		}
+		for _, field := range fields[2:] {
+			if mask := parseMask(field); mask >= 0 {
+				length = mask
+			} else {
+				gateway = field
+			}
+		}
+		config.Interfaces["eth0"] = append(config.Interfaces["eth0"], fmt.Sprintf("%s/%d", address, length))
+		if gateway != "" {
+			config.Routes = append(config.Routes, NetRoute{Dst: "default", Gateway: gateway, Family: routeFamily(gateway)})
+		}
+	}
+	p.addNetConfig(node, config)
+}
+
+// loadInterfacesConfig converts the static addresses of a debian file
+// /etc/network/interfaces
+func (p *Project) loadInterfacesConfig(node string, data []byte) {
+	config := NetConfig{Interfaces: make(map[string][]string), Routes: make([]NetRoute, 0)}
+	ifName, address, length := "", "", -1
+	flush := func() {
+		if ifName != "" && address != "" {
+			if length < 0 {
+				length = 24
+			}
+			config.Interfaces[ifName] = append(config.Interfaces[ifName], fmt.Sprintf("%s/%d", address, length))
+		}
+		address, length = "", -1
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		switch fields[0] {
+		case "iface":
+			flush()
+			ifName = ""
+			if len(fields) >= 4 && fields[3] == "static" {
+				ifName = fields[1]
+			} else if len(fields) >= 4 && fields[1] != "lo" {
+				p.report("Node %s: interface %s of method %s is not converted", node, fields[1], fields[3])
+			}
+		case "address":
+			if split := strings.SplitN(fields[1], "/", 2); len(split) == 2 {
+				address, length = split[0], parseMask(split[1])
+			} else {
+				address = fields[1]
+			}
+		case "netmask":
+			length = parseMask(fields[1])
+		case "gateway":
+			if ifName != "" {
+				config.Routes = append(config.Routes, NetRoute{Dst: "default", Gateway: fields[1], Family: routeFamily(fields[1])})
+			}
+		}
+	}
+	flush()
+	p.addNetConfig(node, config)
+}
+
+// loadGns3Config adds the configuration files of a node, stored in the
+// folder project-files of the GNS3 project
+func (p *Project) loadGns3Config(name, nType string, node gns3Node, dir string) {
+	filesDir := path.Join(dir, "project-files", node.NodeType, node.NodeID)
+	read := func(filename string) []byte {
+		data, err := ioutil.ReadFile(path.Join(filesDir, filename))
+		if err != nil {
+			return nil
+		}
+		return data
+	}
+
+	switch {
+	case node.NodeType == "vpcs":
+		if data := read("startup.vpc"); data != nil {
+			p.loadVpcsConfig(name, data)
+		}
+	case nType == "docker.router":
+		if data := read("etc/frr/frr.conf"); data != nil {
+			p.addConfig(name, "frr.conf", data)
+		}
+	case nType == "docker.host":
+		if data := read("etc/network/interfaces"); data != nil {
+			p.loadInterfacesConfig(name, data)
+		}
+	}
+}
+
+// filterValue returns the first value of a GNS3 link filter
+func filterValue(values []interface{}, idx int) float64 {
+	if idx < len(values) {
+		if value, ok := values[idx].(float64); ok {
+			return value
+		}
+	}
+	return 0
+}
+
+// ImportGns3 converts a GNS3 project, dir is the folder of the project
+// where the configuration files are searched
+func ImportGns3(data []byte, dir string) (*Project, error) {
+	var project gns3Project
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("Unable to parse GNS3 project: %w", err)
+	}
+
+	p := newProject()
+	nodes := make(map[string]gns3Node)
+	names := make(map[string]string)
+	for _, node := range project.Topology.Nodes {
+		nodes[node.NodeID] = node
+		if node.NodeType == "cloud" {
+			continue
+		}
+
+		nType := gns3NodeType(node)
+		if nType == "" {
+			p.report("Node %s: type '%s' is not supported", node.Name, node.NodeType)
+			continue
+		}
+		name := p.addNode(node.Name, nType)
+		names[node.NodeID] = name
+		if nType == "docker.host" && node.Properties.Image != "" {
+			p.report("Node %s: image %s is replaced by the image of docker.host", name, node.Properties.Image)
+		}
+		p.loadGns3Config(name, nType, node, dir)
+	}
+
+	for idx, l := range project.Topology.Links {
+		if len(l.Nodes) != 2 {
+			p.report("Link %d: 2 nodes are expected", idx)
+			continue
+		}
+
+		peers := make([]gns3LinkNode, 0, 2)
+		hostIf := ""
+		for _, ln := range l.Nodes {
+			node := nodes[ln.NodeID]
+			if node.NodeType == "cloud" {
+				for _, port := range node.Properties.PortsMapping {
+					if port.PortNumber == ln.PortNumber {
+						hostIf = port.Interface
+					}
+				}
+				continue
+			}
+			if _, found := names[ln.NodeID]; !found {
+				p.report("Link %d: node %s is not converted", idx, node.Name)
+				break
+			}
+			peers = append(peers, ln)
+		}
+		// the index of the interface is the adapter, or the port for switches
+		peer := func(ln gns3LinkNode) string {
+			ifIndex := ln.AdapterNumber
+			if nodes[ln.NodeID].NodeType == "ethernet_switch" || nodes[ln.NodeID].NodeType == "ethernet_hub" {
+				ifIndex = ln.PortNumber
+			}
+			return p.addInterface(names[ln.NodeID], fmt.Sprintf("eth%d", ifIndex))
+		}
+
+		switch {
+		case len(peers) == 2:
+			link := Link{Peer1: peer(peers[0]), Peer2: peer(peers[1])}
+			filters := make([]string, 0, len(l.Filters))
+			for filter := range l.Filters {
+				filters = append(filters, filter)
+			}
+			sort.Strings(filters)
+			for _, filter := range filters {
+				values := l.Filters[filter]
+				switch filter {
+				case "delay":
+					link.Delay, link.Jitter = filterValue(values, 0), filterValue(values, 1)
+				case "packet_loss":
+					link.Loss = filterValue(values, 0)
+				case "corrupt":
+					link.Corrupt = filterValue(values, 0)
+				default:
+					p.report("Link %s - %s: filter %s is not converted", link.Peer1, link.Peer2, filter)
+				}
+			}
+			if l.Suspend {
+				p.report("Link %s - %s: the link is suspended in GNS3", link.Peer1, link.Peer2)
+			}
+			p.Links = append(p.Links, link)
+		case len(peers) == 1 && hostIf != "":
+			p.addBridge(hostIf, peer(peers[0]))
+		}
+	}
+
+	return p, nil
+}
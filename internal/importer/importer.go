@@ -0,0 +1,257 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mroy31/gonetem/internal/utils"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	networkFilename = "network.yml"
+	configDir       = "configs"
+	// maximum length of the name of an ovs switch
+	switchNameLength = 10
+)
+
+var (
+	invalidNameRE = regexp.MustCompile(`\W`)
+	ifNumberRE    = regexp.MustCompile(`(\d+)$`)
+	ipAddrRE      = regexp.MustCompile(`^ip\s+(?:-[46]\s+)?a(?:ddr|ddress)?\s+add\s+(\S+)\s+dev\s+(\S+)`)
+	ipRouteRE     = regexp.MustCompile(`^ip\s+(?:-[46]\s+)?r(?:oute)?\s+add\s+(\S+)\s+via\s+(\S+)`)
+)
+
+type Node struct {
+	Type string
+}
+
+type Link struct {
+	Peer1   string
+	Peer2   string
+	Delay   float64 `yaml:",omitempty"` // ms
+	Jitter  float64 `yaml:",omitempty"` // ms
+	Loss    float64 `yaml:",omitempty"` // percent
+	Corrupt float64 `yaml:",omitempty"` // percent
+}
+
+type Bridge struct {
+	Host       string
+	Interfaces []string
+}
+
+type NetConfig struct {
+	Interfaces map[string][]string `json:"interfaces"`
+	Routes     []NetRoute          `json:"routes"`
+}
+
+type NetRoute struct {
+	Dst     string `json:"dst"`
+	Gateway string `json:"gateway"`
+	Family  int    `json:"family"`
+}
+
+// Project is a gonetem project converted from another tool
+type Project struct {
+	Nodes   map[string]Node
+	Links   []Link            `yaml:",omitempty"`
+	Bridges map[string]Bridge `yaml:",omitempty"`
+
+	// configuration files of the nodes, by name in the configs folder
+	configs map[string][]byte
+	// interfaces of the nodes, to find a free index
+	interfaces map[string]map[int]bool
+	// elements which can not be converted
+	issues []string
+}
+
+func newProject() *Project {
+	return &Project{
+		Nodes:      make(map[string]Node),
+		Links:      make([]Link, 0),
+		Bridges:    make(map[string]Bridge),
+		configs:    make(map[string][]byte),
+		interfaces: make(map[string]map[int]bool),
+		issues:     make([]string, 0),
+	}
+}
+
+func (p *Project) report(format string, a ...interface{}) {
+	p.issues = append(p.issues, fmt.Sprintf(format, a...))
+}
+
+// Issues returns the elements which have not been converted
+func (p *Project) Issues() []string {
+	return p.issues
+}
+
+// Configs returns the names of the configuration files of the nodes
+func (p *Project) Configs() []string {
+	names := make([]string, 0, len(p.configs))
+	for name := range p.configs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// addNode adds a node with a valid and unique name, based on name, and
+// returns this name
+func (p *Project) addNode(name, nType string) string {
+	nodeName := invalidNameRE.ReplaceAllString(name, "_")
+	if nType == "ovs" && len(nodeName) > switchNameLength {
+		nodeName = nodeName[:switchNameLength]
+	}
+	for idx := 1; ; idx++ {
+		if _, found := p.Nodes[nodeName]; !found {
+			break
+		}
+		suffix := strconv.Itoa(idx)
+		base := strings.TrimRight(nodeName, "0123456789")
+		if nType == "ovs" && len(base)+len(suffix) > switchNameLength {
+			base = base[:switchNameLength-len(suffix)]
+		}
+		nodeName = base + suffix
+	}
+	if nodeName != name {
+		p.report("Node %s has been renamed %s", name, nodeName)
+	}
+
+	p.Nodes[nodeName] = Node{Type: nType}
+	p.interfaces[nodeName] = make(map[int]bool)
+	return nodeName
+}
+
+// addInterface returns the peer <node>.<ifIndex> of the interface ifName,
+// the index is the number at the end of ifName if it is free
+func (p *Project) addInterface(node, ifName string) string {
+	ifIndex := -1
+	if match := ifNumberRE.FindStringSubmatch(ifName); match != nil {
+		ifIndex, _ = strconv.Atoi(match[1])
+	}
+	if ifIndex < 0 || p.interfaces[node][ifIndex] {
+		for ifIndex = 0; p.interfaces[node][ifIndex]; ifIndex++ {
+		}
+		p.report("Interface %s of node %s has been mapped to eth%d", ifName, node, ifIndex)
+	}
+
+	p.interfaces[node][ifIndex] = true
+	return fmt.Sprintf("%s.%d", node, ifIndex)
+}
+
+// addBridge connects peer to the host interface hostIf
+func (p *Project) addBridge(hostIf, peer string) {
+	name := invalidNameRE.ReplaceAllString(hostIf, "_")
+	bridge, found := p.Bridges[name]
+	if !found {
+		bridge = Bridge{Host: hostIf, Interfaces: make([]string, 0)}
+	}
+	bridge.Interfaces = append(bridge.Interfaces, peer)
+	p.Bridges[name] = bridge
+}
+
+// addConfig adds the configuration file <node>.<suffix>
+func (p *Project) addConfig(node, suffix string, data []byte) {
+	p.configs[node+"."+suffix] = data
+}
+
+// addCommands converts ip commands which configure the addresses and the
+// routes of a host to the file <node>.net.conf, other commands are reported
+func (p *Project) addCommands(node string, commands []string) {
+	config := NetConfig{Interfaces: make(map[string][]string), Routes: make([]NetRoute, 0)}
+	for _, cmd := range commands {
+		cmd = strings.TrimSpace(cmd)
+		if match := ipAddrRE.FindStringSubmatch(cmd); match != nil {
+			config.Interfaces[match[2]] = append(config.Interfaces[match[2]], match[1])
+		} else if match := ipRouteRE.FindStringSubmatch(cmd); match != nil {
+			config.Routes = append(config.Routes, NetRoute{Dst: match[1], Gateway: match[2], Family: routeFamily(match[2])})
+		} else if cmd != "" {
+			p.report("Node %s: command '%s' is not converted", node, cmd)
+		}
+	}
+	p.addNetConfig(node, config)
+}
+
+// addNetConfig adds the file <node>.net.conf used by the hosts and the
+// servers to configure their addresses and routes
+func (p *Project) addNetConfig(node string, config NetConfig) {
+	if len(config.Interfaces) > 0 || len(config.Routes) > 0 {
+		data, _ := json.MarshalIndent(config, "", "    ")
+		p.addConfig(node, "net.conf", data)
+	}
+}
+
+// routeFamily returns the family of a route via gateway
+func routeFamily(gateway string) int {
+	if strings.Contains(gateway, ":") {
+		return 10 // AF_INET6
+	}
+	return 2 // AF_INET
+}
+
+// nodeType returns the type of a docker node according to its image
+func nodeType(image string) string {
+	if strings.Contains(strings.ToLower(image), "frr") {
+		return "docker.router"
+	}
+	return "docker.host"
+}
+
+// NetworkFile returns the topology in the format of network.yml
+func (p *Project) NetworkFile() ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// Archive writes the project in w, with the format of .gnet files
+func (p *Project) Archive(w io.Writer) error {
+	dir, err := ioutil.TempDir("", "gonetem-import")
+	if err != nil {
+		return fmt.Errorf("Unable to create temp folder: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	data, err := p.NetworkFile()
+	if err != nil {
+		return fmt.Errorf("Unable to create network file: %w", err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, networkFilename), data, 0644); err != nil {
+		return err
+	}
+	if len(p.configs) > 0 {
+		if err := os.Mkdir(path.Join(dir, configDir), 0755); err != nil {
+			return err
+		}
+		for name, data := range p.configs {
+			if err := ioutil.WriteFile(path.Join(dir, configDir, name), data, 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	return utils.CreateArchive(dir, w)
+}
+
+// Import converts a containerlab topology (.clab.yml) or a GNS3 project
+// (.gns3) to a gonetem project
+func Import(filename string) (*Project, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasSuffix(filename, ".clab.yml") || strings.HasSuffix(filename, ".clab.yaml"):
+		return ImportContainerlab(data, path.Dir(filename))
+	case strings.HasSuffix(filename, ".gns3"):
+		return ImportGns3(data, path.Dir(filename))
+	}
+	return nil, fmt.Errorf("Format of file %s is unknown (.clab.yml or .gns3 expected)", filename)
+}
@@ -0,0 +1,208 @@
+package importer
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const clabTestTopology = `
+name: lab
+topology:
+  kinds:
+    linux:
+      image: frrouting/frr:latest
+  nodes:
+    r1:
+      kind: linux
+      binds:
+      - r1/frr.conf:/etc/frr/frr.conf
+    pc-1:
+      kind: linux
+      image: alpine:latest
+      exec:
+      - ip addr add 192.168.1.1/24 dev eth1
+      - ip route add default via 192.168.1.254
+      - sleep 1
+    srl:
+      kind: nokia_srlinux
+  links:
+  - endpoints: ["r1:eth1", "pc-1:eth1"]
+  - endpoints: ["r1:eth2", "srl:e1-1"]
+  - endpoints: ["r1:eth3", "macvlan:enp0s3"]`
+
+const gns3TestProject = `{
+  "name": "lab",
+  "topology": {
+    "nodes": [
+      {"node_id": "n1", "name": "PC1", "node_type": "vpcs", "properties": {}},
+      {"node_id": "n2", "name": "Switch1", "node_type": "ethernet_switch", "properties": {}},
+      {"node_id": "n3", "name": "R1", "node_type": "dynamips", "properties": {}},
+      {"node_id": "n4", "name": "Cloud1", "node_type": "cloud", "properties": {
+        "ports_mapping": [{"interface": "eth0", "port_number": 0}]
+      }}
+    ],
+    "links": [
+      {"nodes": [
+        {"node_id": "n1", "adapter_number": 0, "port_number": 0},
+        {"node_id": "n2", "adapter_number": 0, "port_number": 3}
+      ], "filters": {"delay": [10, 2], "frequency_drop": [5]}},
+      {"nodes": [
+        {"node_id": "n2", "adapter_number": 0, "port_number": 1},
+        {"node_id": "n3", "adapter_number": 0, "port_number": 0}
+      ]},
+      {"nodes": [
+        {"node_id": "n2", "adapter_number": 0, "port_number": 2},
+        {"node_id": "n4", "adapter_number": 0, "port_number": 0}
+      ]}
+    ]
+  }
+}`
+
+func TestImport_Project(t *testing.T) {
+	tests := []struct {
+		desc            string
+		filename        string
+		files           map[string]string
+		expectedNetwork string
+		expectedConfigs map[string]string
+		expectedIssues  []string
+	}{
+		{
+			desc:     "Import: containerlab",
+			filename: "lab.clab.yml",
+			files: map[string]string{
+				"lab.clab.yml": clabTestTopology,
+				"r1/frr.conf":  "hostname r1\n",
+			},
+			expectedNetwork: `nodes:
+  pc_1:
+    type: docker.host
+  r1:
+    type: docker.router
+links:
+- peer1: r1.1
+  peer2: pc_1.1
+bridges:
+  enp0s3:
+    host: enp0s3
+    interfaces:
+    - r1.3
+`,
+			expectedConfigs: map[string]string{
+				"pc_1.net.conf": `{
+    "interfaces": {
+        "eth1": [
+            "192.168.1.1/24"
+        ]
+    },
+    "routes": [
+        {
+            "dst": "default",
+            "gateway": "192.168.1.254",
+            "family": 2
+        }
+    ]
+}`,
+				"r1.frr.conf": "hostname r1\n",
+			},
+			expectedIssues: []string{
+				"Node pc-1 has been renamed pc_1",
+				"Node pc_1: image alpine:latest is replaced by the image of docker.host",
+				"Node pc_1: command 'sleep 1' is not converted",
+				"Node srl: kind 'nokia_srlinux' is not supported",
+				"Link r1:eth2 - srl:e1-1: node srl is not converted",
+			},
+		},
+		{
+			desc:     "Import: GNS3",
+			filename: "lab.gns3",
+			files: map[string]string{
+				"lab.gns3":                          gns3TestProject,
+				"project-files/vpcs/n1/startup.vpc": "set pcname PC1\nip 10.0.0.1 10.0.0.254 24\n",
+			},
+			expectedNetwork: `nodes:
+  PC1:
+    type: docker.host
+  Switch1:
+    type: ovs
+links:
+- peer1: PC1.0
+  peer2: Switch1.3
+  delay: 10
+  jitter: 2
+bridges:
+  eth0:
+    host: eth0
+    interfaces:
+    - Switch1.2
+`,
+			expectedConfigs: map[string]string{
+				"PC1.net.conf": `{
+    "interfaces": {
+        "eth0": [
+            "10.0.0.1/24"
+        ]
+    },
+    "routes": [
+        {
+            "dst": "default",
+            "gateway": "10.0.0.254",
+            "family": 2
+        }
+    ]
+}`,
+			},
+			expectedIssues: []string{
+				"Node R1: type 'dynamips' is not supported",
+				"Link PC1.0 - Switch1.3: filter frequency_drop is not converted",
+				"Link 1: node R1 is not converted",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("/tmp", "ntmtst")
+			if err != nil {
+				t.Fatalf("Unable to create temp folder: %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			for name, content := range tt.files {
+				if err := os.MkdirAll(path.Dir(path.Join(dir, name)), 0755); err != nil {
+					t.Fatalf("Unable to create folder of %s: %v", name, err)
+				}
+				if err := ioutil.WriteFile(path.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Unable to create file %s: %v", name, err)
+				}
+			}
+
+			project, err := Import(path.Join(dir, tt.filename))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			data, err := project.NetworkFile()
+			if err != nil {
+				t.Fatalf("Unable to create network file: %v", err)
+			}
+			if string(data) != tt.expectedNetwork {
+				t.Errorf("Wrong network file:\n%s\n!=\n%s", string(data), tt.expectedNetwork)
+			}
+			configs := make(map[string]string)
+			for _, name := range project.Configs() {
+				configs[name] = string(project.configs[name])
+			}
+			if !reflect.DeepEqual(configs, tt.expectedConfigs) {
+				t.Errorf("Wrong configs: %v != %v", configs, tt.expectedConfigs)
+			}
+			if strings.Join(project.Issues(), "\n") != strings.Join(tt.expectedIssues, "\n") {
+				t.Errorf("Wrong issues:\n%s\n!=\n%s", strings.Join(project.Issues(), "\n"), strings.Join(tt.expectedIssues, "\n"))
+			}
+		})
+	}
+}
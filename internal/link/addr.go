@@ -0,0 +1,171 @@
+package link
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"path"
+	"runtime"
+	"strings"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// Route is a route via a gateway, Dst is default or a prefix
+type Route struct {
+	Dst     string
+	Gateway string
+}
+
+// AddAddress adds address, in CIDR notation, to the interface name
+func AddAddress(name, address string, namespace netns.NsHandle) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("Unable get link %s: %v", name, err)
+	}
+	addr, err := netlink.ParseAddr(address)
+	if err != nil {
+		return fmt.Errorf("Address %s is not valid: %v", address, err)
+	}
+	if err := netlink.AddrAdd(link, addr); err != nil {
+		return fmt.Errorf("Error when adding address %s to %s: %v", address, name, err)
+	}
+
+	return nil
+}
+
+// GetAddresses returns the global addresses, in CIDR notation, of the
+// interface name
+func GetAddresses(name string, namespace netns.NsHandle) ([]string, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return nil, fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("Unable get link %s: %v", name, err)
+	}
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list addresses of %s: %v", name, err)
+	}
+
+	addresses := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		if addr.Scope == int(netlink.SCOPE_UNIVERSE) {
+			addresses = append(addresses, addr.IPNet.String())
+		}
+	}
+	return addresses, nil
+}
+
+// FlushAddresses removes all the addresses of the interface name
+func FlushAddresses(name string, namespace netns.NsHandle) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("Unable get link %s: %v", name, err)
+	}
+	addrs, err := netlink.AddrList(link, netlink.FAMILY_ALL)
+	if err != nil {
+		return fmt.Errorf("Unable to list addresses of %s: %v", name, err)
+	}
+	for _, addr := range addrs {
+		addr := addr
+		if err := netlink.AddrDel(link, &addr); err != nil {
+			return fmt.Errorf("Error when deleting address %s of %s: %v", addr.IPNet, name, err)
+		}
+	}
+
+	return nil
+}
+
+// AddRoute adds a route in the namespace
+func AddRoute(route Route, namespace netns.NsHandle) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	gateway := net.ParseIP(route.Gateway)
+	if gateway == nil {
+		return fmt.Errorf("Gateway %s is not valid", route.Gateway)
+	}
+	nlRoute := &netlink.Route{Gw: gateway}
+	if route.Dst != "default" {
+		_, dst, err := net.ParseCIDR(route.Dst)
+		if err != nil {
+			return fmt.Errorf("Destination %s is not valid: %v", route.Dst, err)
+		}
+		nlRoute.Dst = dst
+	}
+	if err := netlink.RouteAdd(nlRoute); err != nil {
+		return fmt.Errorf("Error when adding route %s via %s: %v", route.Dst, route.Gateway, err)
+	}
+
+	return nil
+}
+
+// GetRoutes returns the routes via a gateway of the namespace
+func GetRoutes(namespace netns.NsHandle) ([]Route, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return nil, fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	nlRoutes, err := netlink.RouteList(nil, netlink.FAMILY_ALL)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to list routes: %v", err)
+	}
+
+	routes := make([]Route, 0)
+	for _, r := range nlRoutes {
+		if r.Gw == nil {
+			continue
+		}
+		dst := "default"
+		if r.Dst != nil {
+			dst = r.Dst.String()
+		}
+		routes = append(routes, Route{Dst: dst, Gateway: r.Gw.String()})
+	}
+	return routes, nil
+}
+
+// SetSysctl writes the value of a sysctl of the namespace, like
+// net.ipv6.conf.all.disable_ipv6
+func SetSysctl(key, value string, namespace netns.NsHandle) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	filename := path.Join("/proc/sys", strings.ReplaceAll(key, ".", "/"))
+	if err := ioutil.WriteFile(filename, []byte(value), 0644); err != nil {
+		return fmt.Errorf("Unable to set sysctl %s: %v", key, err)
+	}
+	return nil
+}
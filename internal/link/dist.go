@@ -0,0 +1,74 @@
+package link
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// maximum number of entries in a netem distribution table
+	distTableMaxSize = 16384
+)
+
+var (
+	// directories where iproute2 installs its distribution tables
+	distTableDirs = []string{"/usr/lib/tc", "/usr/lib64/tc", "/usr/lib/*/tc"}
+)
+
+// FindSystemDistTable returns the path of the iproute2
+// distribution table name (normal, pareto, paretonormal...)
+func FindSystemDistTable(name string) (string, error) {
+	for _, dir := range distTableDirs {
+		matches, _ := filepath.Glob(path.Join(dir, name+".dist"))
+		if len(matches) > 0 {
+			return matches[0], nil
+		}
+	}
+	return "", fmt.Errorf("Distribution table '%s' not found", name)
+}
+
+// ReadDistTable parses a distribution table file in the iproute2
+// format: values between -32768 and 32767 separated by spaces,
+// lines starting with # are ignored
+func ReadDistTable(filepath string) ([]int16, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open distribution table: %w", err)
+	}
+	defer file.Close()
+
+	table := make([]int16, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		for _, field := range strings.Fields(line) {
+			value, err := strconv.ParseInt(field, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("Distribution table %s: wrong value '%s'", path.Base(filepath), field)
+			}
+			table = append(table, int16(value))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Unable to read distribution table: %w", err)
+	}
+
+	if len(table) == 0 {
+		return nil, fmt.Errorf("Distribution table %s is empty", path.Base(filepath))
+	} else if len(table) > distTableMaxSize {
+		return nil, fmt.Errorf(
+			"Distribution table %s is too large (%d > %d values)",
+			path.Base(filepath), len(table), distTableMaxSize)
+	}
+
+	return table, nil
+}
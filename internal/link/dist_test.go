@@ -0,0 +1,64 @@
+package link
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+func TestLink_ReadDistTable(t *testing.T) {
+	tests := []struct {
+		desc          string
+		content       string
+		expected      []int16
+		expectedError bool
+	}{
+		{
+			desc:     "ReadDistTable: valid table",
+			content:  "# This is a comment\n-100 0 100\n32767 -32768\n",
+			expected: []int16{-100, 0, 100, 32767, -32768},
+		},
+		{
+			desc:          "ReadDistTable: out of range value",
+			content:       "1 2 40000\n",
+			expectedError: true,
+		},
+		{
+			desc:          "ReadDistTable: empty table",
+			content:       "# empty\n",
+			expectedError: true,
+		},
+	}
+
+	dir, err := ioutil.TempDir("/tmp", "ntmtst")
+	if err != nil {
+		t.Fatalf("Unable to create temp folder: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			filepath := path.Join(dir, "test.dist")
+			if err := ioutil.WriteFile(filepath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Unable to create table file: %v", err)
+			}
+
+			table, err := ReadDistTable(filepath)
+			if err != nil {
+				if !tt.expectedError {
+					t.Errorf("ReadDistTable returns an unexpected error: %v", err)
+				}
+				return
+			} else if tt.expectedError {
+				t.Errorf("ReadDistTable does not return an error")
+				return
+			}
+
+			if !reflect.DeepEqual(table, tt.expected) {
+				t.Errorf("Wrong table %v != %v", table, tt.expected)
+			}
+		})
+	}
+}
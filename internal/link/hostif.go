@@ -0,0 +1,77 @@
+package link
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// MoveLink moves the interface name of namespace in target, where it is
+// named tmpName. The interface is set down first: a physical interface
+// can not be renamed while it is up. The temporary name avoids conflicts
+// with the interfaces of target
+func MoveLink(name, tmpName string, namespace, target netns.NsHandle) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	link, err := netlink.LinkByName(name)
+	if err != nil {
+		return fmt.Errorf("Unable get link %s: %v", name, err)
+	}
+	if err := netlink.LinkSetDown(link); err != nil {
+		return fmt.Errorf("Error when set %s down: %v", name, err)
+	}
+	if name != tmpName {
+		if err := netlink.LinkSetName(link, tmpName); err != nil {
+			return fmt.Errorf("Error when renaming link %s->%s: %v", name, tmpName, err)
+		}
+	}
+	if err := netlink.LinkSetNsFd(link, int(target)); err != nil {
+		return fmt.Errorf("Error when update netns for %s: %v", name, err)
+	}
+
+	return nil
+}
+
+// CreateChildLink creates on the interface parent of namespace a child
+// of type kind, a macvlan in bridge mode or an ipvlan in L2 mode. The
+// child is named name in target
+func CreateChildLink(kind, name, parent string, namespace, target netns.NsHandle) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	parentLink, err := netlink.LinkByName(parent)
+	if err != nil {
+		return fmt.Errorf("Unable to find %s parent %s: %v", kind, parent, err)
+	}
+
+	la := netlink.NewLinkAttrs()
+	la.Name = name
+	la.Namespace = netlink.NsFd(target)
+	la.ParentIndex = parentLink.Attrs().Index
+
+	var child netlink.Link
+	switch kind {
+	case "macvlan":
+		child = &netlink.Macvlan{LinkAttrs: la, Mode: netlink.MACVLAN_MODE_BRIDGE}
+	case "ipvlan":
+		child = &netlink.IPVlan{LinkAttrs: la, Mode: netlink.IPVLAN_MODE_L2}
+	default:
+		return fmt.Errorf("Unknown child interface type %s", kind)
+	}
+
+	if err := netlink.LinkAdd(child); err != nil {
+		return fmt.Errorf("Error when creating %s %s: %v", kind, name, err)
+	}
+	return nil
+}
@@ -18,6 +18,15 @@ const (
 	IFSTATE_DOWN
 )
 
+const (
+	DEFAULT_MTU    = 1500
+	DEFAULT_TXQLEN = 1000
+	MIN_MTU        = 68
+	MAX_MTU        = 65535
+	// size of the ethernet header, not included in the MTU
+	ethHeaderLen = 14
+)
+
 var (
 	mutex = &sync.Mutex{}
 )
@@ -37,7 +46,9 @@ func IsLinkExist(name string, namespace netns.NsHandle) bool {
 	return err == nil
 }
 
-func CreateVethLink(name string, namespace netns.NsHandle, peerName string, peerNamespace netns.NsHandle) (*netlink.Veth, error) {
+// CreateVethLink creates a veth pair between namespace and peerNamespace,
+// mtu and txQLen are applied to both interfaces
+func CreateVethLink(name string, namespace netns.NsHandle, peerName string, peerNamespace netns.NsHandle, mtu, txQLen int) (*netlink.Veth, error) {
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
@@ -46,8 +57,8 @@ func CreateVethLink(name string, namespace netns.NsHandle, peerName string, peer
 	veth := &netlink.Veth{
 		LinkAttrs: netlink.LinkAttrs{
 			Name:      name,
-			MTU:       1500,
-			TxQLen:    1000,
+			MTU:       mtu,
+			TxQLen:    txQLen,
 			Namespace: netlink.NsFd(namespace),
 		},
 		PeerName:      peerName,
@@ -84,6 +95,66 @@ func CreateBridge(name string, namespace netns.NsHandle) (*netlink.Bridge, error
 	return br, nil
 }
 
+// CreateHub creates a bridge which does not learn MAC addresses,
+// so each frame is sent to all ports like a hub
+func CreateHub(name string, namespace netns.NsHandle) (*netlink.Bridge, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	netns.Set(namespace)
+
+	ageingTime := uint32(0)
+	mcastSnooping := false
+	la := netlink.NewLinkAttrs()
+	la.Name = name
+	br := &netlink.Bridge{
+		LinkAttrs:         la,
+		AgeingTime:        &ageingTime,
+		MulticastSnooping: &mcastSnooping,
+	}
+
+	if err := netlink.LinkAdd(br); err != nil {
+		return br, fmt.Errorf("Error when creating hub %s: %v", name, err)
+	}
+	if err := netlink.LinkSetUp(br); err != nil {
+		return br, fmt.Errorf("Error when set %s up: %v", name, err)
+	}
+
+	return br, nil
+}
+
+// CreateNetns creates the named namespace name,
+// the namespace of the calling thread is kept
+func CreateNetns(name string) (netns.NsHandle, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	origin, err := netns.Get()
+	if err != nil {
+		return netns.None(), fmt.Errorf("Unable to get current netns: %v", err)
+	}
+	defer origin.Close()
+
+	ns, err := netns.NewNamed(name)
+	if err != nil {
+		return netns.None(), fmt.Errorf("Unable to create netns %s: %v", name, err)
+	}
+	if err := netns.Set(origin); err != nil {
+		ns.Close()
+		return netns.None(), fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	return ns, nil
+}
+
+// DeleteNetns removes the named namespace name, and so its interfaces
+func DeleteNetns(name string) error {
+	if err := netns.DeleteNamed(name); err != nil {
+		return fmt.Errorf("Unable to delete netns %s: %v", name, err)
+	}
+	return nil
+}
+
 func CreateMacVlan(name string, parent string, group int, namespace netns.NsHandle) (*netlink.Macvlan, error) {
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
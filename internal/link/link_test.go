@@ -47,7 +47,7 @@ func TestLink_CreateVeth(t *testing.T) {
 	ns, teardown := setUpNetlinkTest(t)
 	defer teardown()
 
-	veth, err := CreateVethLink(utils.RandString(6), ns, utils.RandString(6), ns)
+	veth, err := CreateVethLink(utils.RandString(6), ns, utils.RandString(6), ns, DEFAULT_MTU, DEFAULT_TXQLEN)
 	if err != nil {
 		t.Fatalf("Unable to create veth: %v", err)
 	}
@@ -105,7 +105,7 @@ func TestLink_InterfaceState(t *testing.T) {
 	ns, teardown := setUpNetlinkTest(t)
 	defer teardown()
 
-	veth, err := CreateVethLink(utils.RandString(6), ns, utils.RandString(6), ns)
+	veth, err := CreateVethLink(utils.RandString(6), ns, utils.RandString(6), ns, DEFAULT_MTU, DEFAULT_TXQLEN)
 	if err != nil {
 		t.Fatalf("Unable to create veth: %v", err)
 	}
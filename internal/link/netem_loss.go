@@ -0,0 +1,75 @@
+package link
+
+import (
+	"github.com/florianl/go-tc"
+	"github.com/vishvananda/netlink/nl"
+)
+
+// loss model attributes nested in TCA_NETEM_LOSS (see linux/pkt_sched.h)
+const (
+	netemLossGI = 1 // 4-state Markov model
+	netemLossGE = 2 // Gilbert-Elliott model
+)
+
+// netemRequest sends the netem qdisc with a raw rtnetlink request since
+// go-tc does not support the TCA_NETEM_LOSS and TCA_NETEM_DELAY_DIST
+// attributes. It must be called from the namespace of the interface
+func netemRequest(ifIndex uint32, params NetemParams, flags int) error {
+	qopt := params.qopt()
+	options := nl.NewRtAttr(nl.TCA_OPTIONS, (&nl.TcNetemQopt{
+		Latency:   qopt.Latency,
+		Limit:     qopt.Limit,
+		Loss:      qopt.Loss,
+		Gap:       qopt.Gap,
+		Duplicate: qopt.Duplicate,
+		Jitter:    qopt.Jitter,
+	}).Serialize())
+
+	// attributes are sent even with zero values, a replace
+	// keeps the values of the missing ones
+	options.AddRtAttr(nl.TCA_NETEM_CORR, (&nl.TcNetemCorr{
+		DelayCorr: formatPercent(params.DelayCorr),
+		LossCorr:  formatPercent(params.LossCorr),
+		DupCorr:   formatPercent(params.DuplicateCorr),
+	}).Serialize())
+	options.AddRtAttr(nl.TCA_NETEM_REORDER, (&nl.TcNetemReorder{
+		Probability: formatPercent(params.Reorder),
+		Correlation: formatPercent(params.ReorderCorr),
+	}).Serialize())
+	options.AddRtAttr(nl.TCA_NETEM_CORRUPT, (&nl.TcNetemCorrupt{
+		Probability: formatPercent(params.Corrupt),
+		Correlation: formatPercent(params.CorruptCorr),
+	}).Serialize())
+
+	if len(params.DelayDist) > 0 {
+		dist := make([]byte, 2*len(params.DelayDist))
+		for idx, v := range params.DelayDist {
+			nl.NativeEndian().PutUint16(dist[2*idx:], uint16(v))
+		}
+		options.AddRtAttr(nl.TCA_NETEM_DELAY_DIST, dist)
+	}
+
+	// without TCA_NETEM_LOSS, the kernel goes back to the random loss
+	if ge := params.LossGE; ge != nil {
+		// struct tc_netem_gemodel: p, r, h, k1
+		lossAttr := options.AddRtAttr(nl.TCA_NETEM_LOSS, nil)
+		lossAttr.AddRtAttr(netemLossGE, serializeUint32s(
+			formatPercent(ge.P),
+			formatPercent(ge.R),
+			formatPercent(ge.BadLoss),
+			formatPercent(ge.GoodLoss),
+		))
+	} else if st := params.LossState; st != nil {
+		// struct tc_netem_gimodel: p13, p31, p32, p14, p23
+		lossAttr := options.AddRtAttr(nl.TCA_NETEM_LOSS, nil)
+		lossAttr.AddRtAttr(netemLossGI, serializeUint32s(
+			formatPercent(st.P13),
+			formatPercent(st.P31),
+			formatPercent(st.P32),
+			formatPercent(st.P14),
+			formatPercent(st.P23),
+		))
+	}
+
+	return qdiscRequest(flags, ifIndex, netemHandle, tc.HandleRoot, "netem", options)
+}
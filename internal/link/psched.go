@@ -0,0 +1,86 @@
+package link
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/florianl/go-tc"
+)
+
+const (
+	pschedFile = "/proc/net/psched"
+	// kernel default, a tick is 64ns (PSCHED_SHIFT = 6)
+	defaultTickInUsec = 15.625
+	linklayerEthernet = uint8(1)
+)
+
+var (
+	tickInUsec = defaultTickInUsec
+	pschedOnce = &sync.Once{}
+)
+
+// readPsched returns the number of ticks per us computed from the
+// psched parameters of the kernel, like iproute2 does (see tc/tc_core.c)
+func readPsched(filepath string) (float64, error) {
+	data, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return 0, err
+	}
+
+	var t2us, us2t, clockRes uint32
+	if _, err := fmt.Sscanf(string(data), "%x %x %x", &t2us, &us2t, &clockRes); err != nil {
+		return 0, fmt.Errorf("Unable to parse %s: %w", filepath, err)
+	}
+	if us2t == 0 || clockRes == 0 {
+		return 0, fmt.Errorf("Wrong psched parameters in %s", filepath)
+	}
+
+	// compatibility with old kernels, see iproute2
+	if clockRes == 1000000000 {
+		t2us = us2t
+	}
+	clockFactor := float64(clockRes) / float64(time.Second/time.Microsecond)
+	return float64(t2us) / float64(us2t) * clockFactor, nil
+}
+
+func getTickInUsec() float64 {
+	pschedOnce.Do(func() {
+		tick, err := readPsched(pschedFile)
+		if err != nil {
+			logger.Warnf("Unable to read psched parameters, use default: %v", err)
+			return
+		}
+		tickInUsec = tick
+	})
+	return tickInUsec
+}
+
+// time2Tick converts a duration in psched ticks
+func time2Tick(d time.Duration) uint32 {
+	ticks := float64(d) / float64(time.Microsecond) * getTickInUsec()
+	if ticks > float64(uint32Max) {
+		return uint32Max
+	}
+	return uint32(ticks)
+}
+
+// xmitTime returns the time, in ticks, to send size bytes at rate bits/s
+func xmitTime(rate uint64, size uint32) uint32 {
+	return time2Tick(time.Duration(float64(size) * 8 * float64(time.Second) / float64(rate)))
+}
+
+// rateSpec returns the rate specification of rate, in bits/s, and
+// the 64-bit rate, in bytes/s, to send if it does not fit in the
+// 32-bit field of the specification
+func rateSpec(rate uint64) (tc.RateSpec, *uint64) {
+	spec := tc.RateSpec{Linklayer: linklayerEthernet}
+	bytes := rate / 8
+	if bytes >= uint64(uint32Max) {
+		spec.Rate = uint32Max
+		return spec, &bytes
+	}
+	spec.Rate = uint32(bytes)
+	return spec, nil
+}
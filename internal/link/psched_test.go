@@ -0,0 +1,62 @@
+package link
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestLink_ReadPsched(t *testing.T) {
+	tests := []struct {
+		desc          string
+		content       string
+		expected      float64
+		expectedError bool
+	}{
+		{
+			desc:     "ReadPsched: high resolution timer",
+			content:  "000003e8 00000040 000f4240 3b9aca00\n",
+			expected: 15.625,
+		},
+		{
+			desc:     "ReadPsched: old kernel with clock_res of 1GHz",
+			content:  "00000001 00000001 3b9aca00 000003e8\n",
+			expected: 1000,
+		},
+		{
+			desc:          "ReadPsched: wrong content",
+			content:       "wrong\n",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("/tmp", "ntmtst")
+			if err != nil {
+				t.Fatalf("Unable to create temp folder: %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			filepath := path.Join(dir, "psched")
+			if err := ioutil.WriteFile(filepath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Unable to write psched file: %v", err)
+			}
+
+			tick, err := readPsched(filepath)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("readPsched succeeds but an error is expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("readPsched returns an error: %v", err)
+			}
+			if tick != tt.expected {
+				t.Errorf("Wrong tick in us: %f != %f", tick, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,439 @@
+package link
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	QUEUE_PFIFO    = "pfifo"
+	QUEUE_FQ_CODEL = "fq_codel"
+	QUEUE_RED      = "red"
+	QUEUE_HTB      = "htb"
+
+	// attributes of the red qdisc (see linux/pkt_sched.h)
+	tcaRedParms = 1
+	tcaRedStab  = 2
+	tcaRedMaxP  = 3
+	tcRedEcn    = 1
+
+	// minor of the first htb class, the root class is 1
+	htbClassMinor = 0x10
+)
+
+var (
+	queueHandle = core.BuildHandle(0x20, 0x0)
+	queueParent = core.BuildHandle(0x10, 0x1)
+)
+
+// HtbClass defines a class of the htb qdisc, rates are in bits/s.
+// When Dscp is set, packets with this DSCP value are sent in this class
+type HtbClass struct {
+	Rate uint64
+	Ceil uint64
+	Prio int
+	Dscp *int
+}
+
+// QueueParams contains the parameters of the queue of a link
+type QueueParams struct {
+	Type  string
+	Limit int // packets for pfifo and fq_codel, bytes for red
+	// fq_codel parameters
+	Target   time.Duration
+	Interval time.Duration
+	Flows    int
+	Ecn      bool // fq_codel and red
+	// red parameters
+	Min         int // bytes
+	Max         int // bytes
+	Avpkt       int // bytes
+	Burst       int // packets
+	Probability float64
+	// htb parameters
+	Classes      []HtbClass
+	DefaultClass int
+}
+
+func htons(v uint16) uint16 {
+	buf := make([]byte, 2)
+	binary.BigEndian.PutUint16(buf, v)
+	return nl.NativeEndian().Uint16(buf)
+}
+
+func htonl(v uint32) uint32 {
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, v)
+	return nl.NativeEndian().Uint32(buf)
+}
+
+// deleteQdiscIfKind deletes the qdisc with the given handle
+// if its kind is different from kind (always if kind is empty)
+func deleteQdiscIfKind(rtnl *tc.Tc, ifIndex uint32, handle uint32, kind string) error {
+	qdiscs, err := rtnl.Qdisc().Get()
+	if err != nil {
+		return err
+	}
+
+	for _, qdisc := range qdiscs {
+		if qdisc.Ifindex == ifIndex && qdisc.Handle == handle && qdisc.Kind != kind {
+			return rtnl.Qdisc().Delete(&tc.Object{Msg: qdisc.Msg})
+		}
+	}
+	return nil
+}
+
+func fqCodelQdisc(ifIndex uint32, params QueueParams) *tc.Object {
+	fqCodel := &tc.FqCodel{}
+	if params.Limit > 0 {
+		limit := uint32(params.Limit)
+		fqCodel.Limit = &limit
+	}
+	if params.Target > 0 {
+		target := uint32(params.Target / time.Microsecond)
+		fqCodel.Target = &target
+	}
+	if params.Interval > 0 {
+		interval := uint32(params.Interval / time.Microsecond)
+		fqCodel.Interval = &interval
+	}
+	if params.Flows > 0 {
+		flows := uint32(params.Flows)
+		fqCodel.Flows = &flows
+	}
+	ecn := uint32(0)
+	if params.Ecn {
+		ecn = 1
+	}
+	fqCodel.ECN = &ecn
+
+	return &tc.Object{
+		Msg: tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: ifIndex,
+			Handle:  queueHandle,
+			Parent:  queueParent,
+		},
+		Attribute: tc.Attribute{
+			Kind:    QUEUE_FQ_CODEL,
+			FqCodel: fqCodel,
+		},
+	}
+}
+
+func pfifoQdisc(ifIndex uint32, params QueueParams) *tc.Object {
+	return &tc.Object{
+		Msg: tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: ifIndex,
+			Handle:  queueHandle,
+			Parent:  queueParent,
+		},
+		Attribute: tc.Attribute{
+			Kind:  QUEUE_PFIFO,
+			Pfifo: &tc.FifoOpt{Limit: uint32(params.Limit)},
+		},
+	}
+}
+
+// redRequest sends the red qdisc with a raw rtnetlink request since
+// go-tc does not support the TCA_RED_STAB attribute. Parameters are
+// computed like iproute2 does (see tc/tc_red.c)
+func redRequest(ifIndex uint32, rate uint64, params QueueParams, flags int) error {
+	limit := uint32(params.Limit)
+	qmax := uint32(params.Max)
+	if qmax == 0 {
+		qmax = limit / 4
+	}
+	qmin := uint32(params.Min)
+	if qmin == 0 {
+		qmin = qmax / 3
+	}
+	avpkt := uint32(params.Avpkt)
+	if avpkt == 0 {
+		avpkt = 1000
+	}
+	burst := uint32(params.Burst)
+	if burst == 0 {
+		burst = (2*qmin + qmax) / (3 * avpkt)
+	}
+	probability := params.Probability / 100
+	if probability == 0 {
+		probability = 0.02
+	}
+
+	// Wlog: ewma weight of the average queue
+	wlog := -1
+	a := float64(burst) + 1 - float64(qmin)/float64(avpkt)
+	w := 0.5
+	for l := 1; l < 32 && a >= 1.0; l, w = l+1, w/2 {
+		if a <= (1-math.Pow(1-w, float64(burst)))/w {
+			wlog = l
+			break
+		}
+	}
+	if wlog < 0 {
+		return fmt.Errorf("Failed to calculate EWMA constant of red")
+	}
+
+	// Plog: drop probability at qmax
+	plog := -1
+	prob := probability / float64(qmax-qmin)
+	for l := 0; l < 32; l++ {
+		if prob > 1.0 {
+			plog = l
+			break
+		}
+		prob *= 2
+	}
+	if plog < 0 {
+		return fmt.Errorf("Failed to calculate probability of red")
+	}
+
+	// Scell_log and stab: idle damping
+	stab := make([]byte, 256)
+	xmit := float64(xmitTime(rate, avpkt))
+	lW := -math.Log(1.0-1.0/float64(uint(1)<<uint(wlog))) / xmit
+	maxTime := 31 / lW
+	scellLog := 0
+	for ; scellLog < 32; scellLog++ {
+		if maxTime/float64(uint(1)<<uint(scellLog)) < 512 {
+			break
+		}
+	}
+	if scellLog >= 32 {
+		return fmt.Errorf("Failed to calculate idle damping of red")
+	}
+	for i := 1; i < 255; i++ {
+		stab[i] = uint8(math.Min(float64(uint(i)<<uint(scellLog))*lW, 31))
+	}
+	stab[255] = 31
+
+	redFlags := uint8(0)
+	if params.Ecn {
+		redFlags |= tcRedEcn
+	}
+
+	// struct tc_red_qopt: limit, qth_min, qth_max, Wlog, Plog, Scell_log, flags
+	qopt := append(
+		serializeUint32s(limit, qmin, qmax),
+		uint8(wlog), uint8(plog), uint8(scellLog), redFlags)
+
+	options := nl.NewRtAttr(nl.TCA_OPTIONS, nil)
+	options.AddRtAttr(tcaRedParms, qopt)
+	options.AddRtAttr(tcaRedStab, stab)
+	options.AddRtAttr(tcaRedMaxP, serializeUint32s(uint32(probability*float64(uint32Max))))
+
+	return qdiscRequest(flags, ifIndex, queueHandle, queueParent, QUEUE_RED, options)
+}
+
+func htbClass(ifIndex uint32, minor uint32, rate, ceil uint64, prio, mtu int) *tc.Object {
+	if ceil == 0 {
+		ceil = rate
+	}
+	// buffers contain 1ms of traffic and a frame
+	buffer := uint32(rate/8/1000) + uint32(mtu+ethHeaderLen)
+	cbuffer := uint32(ceil/8/1000) + uint32(mtu+ethHeaderLen)
+	rSpec, rate64 := rateSpec(rate)
+	cSpec, ceil64 := rateSpec(ceil)
+
+	return &tc.Object{
+		Msg: tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: ifIndex,
+			Handle:  core.BuildHandle(0x10, minor),
+			Parent:  queueParent,
+		},
+		Attribute: tc.Attribute{
+			Kind: QUEUE_HTB,
+			Htb: &tc.Htb{
+				Parms: &tc.HtbOpt{
+					Rate:    rSpec,
+					Ceil:    cSpec,
+					Buffer:  xmitTime(rate, buffer),
+					Cbuffer: xmitTime(ceil, cbuffer),
+					Prio:    uint32(prio),
+				},
+				Rate64: rate64,
+				Ceil64: ceil64,
+			},
+		},
+	}
+}
+
+// htbDscpFilter sends IPv4 packets with the given DSCP to the class minor
+func htbDscpFilter(ifIndex uint32, minor uint32, dscp int, prio uint32) *tc.Object {
+	classID := core.BuildHandle(0x10, minor)
+
+	return &tc.Object{
+		Msg: tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: ifIndex,
+			Handle:  0,
+			Parent:  tbfHandle,
+			Info:    prio<<16 | uint32(htons(unix.ETH_P_IP)),
+		},
+		Attribute: tc.Attribute{
+			Kind: "u32",
+			U32: &tc.U32{
+				ClassID: &classID,
+				Sel: &tc.U32Sel{
+					Flags: 0x1, // TC_U32_TERMINAL
+					NKeys: 1,
+					Keys: []tc.U32Key{
+						{
+							// TOS byte of the IPv4 header
+							Mask: htonl(0x00fc0000),
+							Val:  htonl(uint32(dscp) << 18),
+							Off:  0,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// createHtb creates the htb qdisc, which replaces the tbf qdisc, with a root
+// class at the link rate and its children classes. The queue of each class
+// is the default one (pfifo)
+func createHtb(rtnl *tc.Tc, ifIndex uint32, rate uint64, mtu int, params QueueParams) error {
+	qdisc := &tc.Object{
+		Msg: tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: ifIndex,
+			Handle:  tbfHandle,
+			Parent:  tbfParent,
+		},
+		Attribute: tc.Attribute{
+			Kind: QUEUE_HTB,
+			Htb: &tc.Htb{
+				Init: &tc.HtbGlob{
+					Version:      3,
+					Rate2Quantum: 10,
+					Defcls:       uint32(htbClassMinor + params.DefaultClass),
+				},
+			},
+		},
+	}
+	if err := rtnl.Qdisc().Add(qdisc); err != nil {
+		return err
+	}
+
+	// root class, classes share the rate of the link
+	root := htbClass(ifIndex, 0x1, rate, rate, 0, mtu)
+	root.Parent = tbfHandle
+	if err := rtnl.Class().Add(root); err != nil {
+		return err
+	}
+
+	for idx, class := range params.Classes {
+		minor := uint32(htbClassMinor + idx)
+		if err := rtnl.Class().Add(htbClass(ifIndex, minor, class.Rate, class.Ceil, class.Prio, mtu)); err != nil {
+			return err
+		}
+		if class.Dscp != nil {
+			if err := rtnl.Filter().Add(htbDscpFilter(ifIndex, minor, *class.Dscp, uint32(idx+1))); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// CreateHtb creates, instead of a tbf qdisc, a htb qdisc with
+// the given classes under the netem qdisc
+func CreateHtb(ifname string, namespace netns.NsHandle, rate uint64, mtu int, params QueueParams) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		if err := createHtb(rtnl, ifIndex, rate, mtu, params); err != nil {
+			return fmt.Errorf("Could not assign qdisc htb to %s: %v\n", ifname, err)
+		}
+		return nil
+	})
+}
+
+// ReplaceHtb removes the current tbf/htb qdisc and creates
+// a new htb qdisc with the given classes
+func ReplaceHtb(ifname string, namespace netns.NsHandle, rate uint64, mtu int, params QueueParams) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		if err := deleteQdiscIfKind(rtnl, ifIndex, tbfHandle, ""); err != nil {
+			return fmt.Errorf("Could not delete qdisc on %s: %v\n", ifname, err)
+		}
+
+		if err := createHtb(rtnl, ifIndex, rate, mtu, params); err != nil {
+			return fmt.Errorf("Could not assign qdisc htb to %s: %v\n", ifname, err)
+		}
+		return nil
+	})
+}
+
+func setQueue(rtnl *tc.Tc, ifIndex uint32, rate uint64, params QueueParams, replace bool) error {
+	flags := unix.NLM_F_CREATE | unix.NLM_F_EXCL
+	if replace {
+		flags = unix.NLM_F_CREATE | unix.NLM_F_REPLACE
+		if err := deleteQdiscIfKind(rtnl, ifIndex, queueHandle, params.Type); err != nil {
+			return err
+		}
+	}
+
+	var qdisc *tc.Object
+	switch params.Type {
+	case QUEUE_PFIFO:
+		qdisc = pfifoQdisc(ifIndex, params)
+	case QUEUE_FQ_CODEL:
+		qdisc = fqCodelQdisc(ifIndex, params)
+	case QUEUE_RED:
+		return redRequest(ifIndex, rate, params, flags)
+	default:
+		return fmt.Errorf("Unknown queue type '%s'", params.Type)
+	}
+
+	if replace {
+		return rtnl.Qdisc().Replace(qdisc)
+	}
+	return rtnl.Qdisc().Add(qdisc)
+}
+
+// CreateQueue creates the queue (pfifo, fq_codel or red)
+// attached to the tbf qdisc of the interface
+func CreateQueue(ifname string, namespace netns.NsHandle, rate uint64, params QueueParams) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		// tc qdisc add dev ifname parent 10:1 ...
+		if err := setQueue(rtnl, ifIndex, rate, params, false); err != nil {
+			return fmt.Errorf("Could not assign qdisc %s to %s: %v\n", params.Type, ifname, err)
+		}
+		return nil
+	})
+}
+
+// ReplaceQueue creates the queue attached to the tbf qdisc
+// or modifies it if it already exists
+func ReplaceQueue(ifname string, namespace netns.NsHandle, rate uint64, params QueueParams) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		// tc qdisc replace dev ifname parent 10:1 ...
+		if err := setQueue(rtnl, ifIndex, rate, params, true); err != nil {
+			return fmt.Errorf("Could not replace qdisc %s on %s: %v\n", params.Type, ifname, err)
+		}
+		return nil
+	})
+}
+
+// DeleteQueue removes the queue attached to the tbf qdisc.
+// Nothing is done if no queue exists
+func DeleteQueue(ifname string, namespace netns.NsHandle) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		if err := deleteQdiscIfKind(rtnl, ifIndex, queueHandle, ""); err != nil {
+			return fmt.Errorf("Could not delete queue on %s: %v\n", ifname, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,32 @@
+package link
+
+import (
+	"github.com/vishvananda/netlink/nl"
+	"golang.org/x/sys/unix"
+)
+
+func serializeUint32s(values ...uint32) []byte {
+	buf := make([]byte, 4*len(values))
+	for idx, v := range values {
+		nl.NativeEndian().PutUint32(buf[4*idx:], v)
+	}
+	return buf
+}
+
+// qdiscRequest sends a qdisc built with a raw rtnetlink request, for
+// attributes not supported by go-tc.
+// It must be called from the namespace of the interface
+func qdiscRequest(flags int, ifIndex, handle, parent uint32, kind string, options *nl.RtAttr) error {
+	req := nl.NewNetlinkRequest(unix.RTM_NEWQDISC, flags|unix.NLM_F_ACK)
+	req.AddData(&nl.TcMsg{
+		Family:  nl.FAMILY_ALL,
+		Ifindex: int32(ifIndex),
+		Handle:  handle,
+		Parent:  parent,
+	})
+	req.AddData(nl.NewRtAttr(nl.TCA_KIND, nl.ZeroTerminated(kind)))
+	req.AddData(options)
+
+	_, err := req.Execute(unix.NETLINK_ROUTE, 0)
+	return err
+}
@@ -0,0 +1,110 @@
+package link
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/florianl/go-tc"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+// IfStats contains the counters of an interface
+type IfStats struct {
+	RxBytes   uint64
+	RxPackets uint64
+	RxErrors  uint64
+	RxDropped uint64
+	TxBytes   uint64
+	TxPackets uint64
+	TxErrors  uint64
+	TxDropped uint64
+}
+
+// QdiscStats contains the counters of a qdisc
+type QdiscStats struct {
+	Kind       string
+	Handle     uint32
+	Parent     uint32
+	Bytes      uint64
+	Packets    uint32
+	Backlog    uint32 // bytes
+	Qlen       uint32 // packets
+	Drops      uint32
+	Overlimits uint32
+	Requeues   uint32
+}
+
+// GetIfStats returns the counters of the interface ifname
+func GetIfStats(ifname string, namespace netns.NsHandle) (*IfStats, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return nil, fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	link, err := netlink.LinkByName(ifname)
+	if err != nil {
+		return nil, fmt.Errorf("Unable get link %s: %v", ifname, err)
+	}
+
+	stats := link.Attrs().Statistics
+	if stats == nil {
+		return nil, fmt.Errorf("No statistics available for link %s", ifname)
+	}
+	return &IfStats{
+		RxBytes:   stats.RxBytes,
+		RxPackets: stats.RxPackets,
+		RxErrors:  stats.RxErrors,
+		RxDropped: stats.RxDropped,
+		TxBytes:   stats.TxBytes,
+		TxPackets: stats.TxPackets,
+		TxErrors:  stats.TxErrors,
+		TxDropped: stats.TxDropped,
+	}, nil
+}
+
+// GetQdiscStats returns the counters of the qdiscs attached
+// to the interface ifname
+func GetQdiscStats(ifname string, namespace netns.NsHandle) ([]QdiscStats, error) {
+	result := make([]QdiscStats, 0)
+	err := execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		qdiscs, err := rtnl.Qdisc().Get()
+		if err != nil {
+			return fmt.Errorf("Could not get qdiscs of %s: %v\n", ifname, err)
+		}
+
+		for _, qdisc := range qdiscs {
+			if qdisc.Ifindex != ifIndex {
+				continue
+			}
+
+			stats := QdiscStats{
+				Kind:   qdisc.Kind,
+				Handle: qdisc.Handle,
+				Parent: qdisc.Parent,
+			}
+			if s := qdisc.Stats2; s != nil {
+				stats.Bytes = s.Bytes
+				stats.Packets = s.Packets
+				stats.Backlog = s.Backlog
+				stats.Qlen = s.Qlen
+				stats.Drops = s.Drops
+				stats.Overlimits = s.Overlimits
+				stats.Requeues = s.Requeues
+			} else if s := qdisc.Stats; s != nil {
+				stats.Bytes = s.Bytes
+				stats.Packets = s.Packets
+				stats.Backlog = s.Backlog
+				stats.Qlen = s.Qlen
+				stats.Drops = s.Drops
+				stats.Overlimits = s.Overlimits
+			}
+			result = append(result, stats)
+		}
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,114 @@
+package link
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	MIN_VLAN = 1
+	MAX_VLAN = 4094
+	// VLAN of the ports of a bridge created with VLAN filtering
+	defaultVlan = 1
+)
+
+// setBridgeStp enables or disables STP on a bridge with a raw rtnetlink
+// request, as IFLA_BR_STP_STATE is not supported by netlink.Bridge.
+// It must be called from the namespace of the bridge
+func setBridgeStp(ifIndex int, stp bool) error {
+	state := uint32(0)
+	if stp {
+		state = 1
+	}
+
+	req := nl.NewNetlinkRequest(unix.RTM_NEWLINK, unix.NLM_F_ACK)
+	msg := nl.NewIfInfomsg(unix.AF_UNSPEC)
+	msg.Index = int32(ifIndex)
+	req.AddData(msg)
+	linkInfo := nl.NewRtAttr(unix.IFLA_LINKINFO, nil)
+	linkInfo.AddRtAttr(nl.IFLA_INFO_KIND, nl.NonZeroTerminated("bridge"))
+	data := linkInfo.AddRtAttr(nl.IFLA_INFO_DATA, nil)
+	data.AddRtAttr(nl.IFLA_BR_STP_STATE, nl.Uint32Attr(state))
+	req.AddData(linkInfo)
+
+	_, err := req.Execute(unix.NETLINK_ROUTE, 0)
+	return err
+}
+
+// CreateSwitch creates the bridge of a switch node, with STP and VLAN
+// filtering if they are requested
+func CreateSwitch(name string, namespace netns.NsHandle, stp, vlanFiltering bool) (*netlink.Bridge, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return nil, fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	la := netlink.NewLinkAttrs()
+	la.Name = name
+	br := &netlink.Bridge{LinkAttrs: la}
+	// the attribute is rejected by kernels built without VLAN filtering
+	if vlanFiltering {
+		br.VlanFiltering = &vlanFiltering
+	}
+	if err := netlink.LinkAdd(br); err != nil {
+		return br, fmt.Errorf("Error when creating switch %s: %v", name, err)
+	}
+
+	if stp {
+		if err := setBridgeStp(br.Attrs().Index, stp); err != nil {
+			return br, fmt.Errorf("Error when enabling STP on %s: %v", name, err)
+		}
+	}
+	if err := netlink.LinkSetUp(br); err != nil {
+		return br, fmt.Errorf("Error when set %s up: %v", name, err)
+	}
+
+	return br, nil
+}
+
+// AddSwitchPort attaches ifName to the switch brName. With VLAN filtering,
+// pvid is the untagged VLAN of the port, 1 if it is 0, and vlans are the
+// tagged ones
+func AddSwitchPort(brName, ifName string, pvid int, vlans []int, namespace netns.NsHandle) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	br, err := netlink.LinkByName(brName)
+	if err != nil {
+		return fmt.Errorf("Unable to get switch %s: %v", brName, err)
+	}
+	port, err := netlink.LinkByName(ifName)
+	if err != nil {
+		return fmt.Errorf("Unable to get %s: %v", ifName, err)
+	}
+	if err := netlink.LinkSetMaster(port, br); err != nil {
+		return fmt.Errorf("Unable to attach %s to %s: %v", ifName, brName, err)
+	}
+
+	if pvid != 0 && pvid != defaultVlan {
+		if err := netlink.BridgeVlanDel(port, defaultVlan, true, true, false, true); err != nil {
+			return fmt.Errorf("Unable to remove VLAN %d of %s: %v", defaultVlan, ifName, err)
+		}
+		if err := netlink.BridgeVlanAdd(port, uint16(pvid), true, true, false, true); err != nil {
+			return fmt.Errorf("Unable to set VLAN %d on %s: %v", pvid, ifName, err)
+		}
+	}
+	for _, vlan := range vlans {
+		if err := netlink.BridgeVlanAdd(port, uint16(vlan), false, false, false, true); err != nil {
+			return fmt.Errorf("Unable to add VLAN %d on %s: %v", vlan, ifName, err)
+		}
+	}
+
+	return nil
+}
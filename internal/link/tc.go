@@ -3,11 +3,13 @@ package link
 import (
 	"fmt"
 	"runtime"
+	"time"
 
 	"github.com/florianl/go-tc"
 	"github.com/florianl/go-tc/core"
 	"github.com/sirupsen/logrus"
 	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
 	"github.com/vishvananda/netns"
 	"golang.org/x/sys/unix"
 )
@@ -18,6 +20,10 @@ const (
 
 var (
 	logger = logrus.WithField("module", "tc")
+
+	netemHandle = core.BuildHandle(0x1, 0x0)
+	tbfHandle   = core.BuildHandle(0x10, 0x0)
+	tbfParent   = core.BuildHandle(0x1, 0x1)
 )
 
 func formatPercent(per float64) uint32 {
@@ -27,12 +33,9 @@ func formatPercent(per float64) uint32 {
 	return result
 }
 
-func formatTime(t int) uint32 {
-	// TODO: understand why we need 15.625 factor
-	return uint32(float64(t) * 1000 * 15.625)
-}
-
-func CreateNetem(ifname string, namespace netns.NsHandle, delay int, jitter int, loss float64) error {
+// execTc opens a rtnetlink socket in the given namespace and runs
+// the action with the index of the interface ifname
+func execTc(ifname string, namespace netns.NsHandle, action func(rtnl *tc.Tc, ifIndex uint32) error) error {
 	runtime.LockOSThread()
 	defer runtime.UnlockOSThread()
 
@@ -55,91 +58,291 @@ func CreateNetem(ifname string, namespace netns.NsHandle, delay int, jitter int,
 		}
 	}()
 
-	qdisc := tc.Object{
-		Msg: tc.Msg{
-			Family:  unix.AF_UNSPEC,
-			Ifindex: uint32(devID.Attrs().Index),
-			Handle:  core.BuildHandle(0x1, 0x0),
-			Parent:  tc.HandleRoot,
-			Info:    0,
-		},
-		Attribute: tc.Attribute{
-			Kind: "netem",
-			Netem: &tc.Netem{
-				Qopt: tc.NetemQopt{
-					Latency: formatTime(delay),
-					Jitter:  formatTime(jitter),
-					Limit:   1000,
-					Loss:    formatPercent(loss),
-				},
-			},
-		},
+	return action(rtnl, uint32(devID.Attrs().Index))
+}
+
+func hasQdisc(rtnl *tc.Tc, ifIndex uint32, handle uint32) (bool, error) {
+	qdiscs, err := rtnl.Qdisc().Get()
+	if err != nil {
+		return false, err
 	}
 
-	// tc qdisc add dev ifname root netem ...
-	if err := rtnl.Qdisc().Add(&qdisc); err != nil {
-		return fmt.Errorf("Could not assign qdisc netem to %s: %v\n", ifname, err)
+	for _, qdisc := range qdiscs {
+		if qdisc.Ifindex == ifIndex && qdisc.Handle == handle {
+			return true, nil
+		}
 	}
+	return false, nil
+}
 
-	return nil
+// NetemGEModel is the Gilbert-Elliott loss model, values are in percent
+type NetemGEModel struct {
+	P        float64 // probability to go from good to bad state
+	R        float64 // probability to go from bad to good state
+	BadLoss  float64 // loss probability in bad state (1-h)
+	GoodLoss float64 // loss probability in good state (1-k)
 }
 
-func CreateTbf(ifname string, namespace netns.NsHandle, delay, rate int) error {
-	runtime.LockOSThread()
-	defer runtime.UnlockOSThread()
+// NetemStateModel is the 4-state Markov loss model, values are in percent
+type NetemStateModel struct {
+	P13 float64
+	P31 float64
+	P32 float64
+	P23 float64
+	P14 float64
+}
 
-	netns.Set(namespace)
+// NetemParams contains the parameters of a netem qdisc.
+// Probabilities/correlations are in percent
+type NetemParams struct {
+	Delay         time.Duration
+	Jitter        time.Duration
+	DelayCorr     float64
+	Loss          float64
+	LossCorr      float64
+	Duplicate     float64
+	DuplicateCorr float64
+	Corrupt       float64
+	CorruptCorr   float64
+	Reorder       float64
+	ReorderCorr   float64
+	Gap           int
+	LossGE        *NetemGEModel
+	LossState     *NetemStateModel
+	DelayDist     []int16 // distribution table of the jitter
+}
 
-	// get interface ID
-	devID, err := netlink.LinkByName(ifname)
-	if err != nil {
-		return fmt.Errorf("Could not get interface ID for %s: %v\n", ifname, err)
+// needsRawRequest returns true if the qdisc has attributes not supported
+// by go-tc, the loss models and the delay distribution
+func (p NetemParams) needsRawRequest() bool {
+	return p.LossGE != nil || p.LossState != nil || len(p.DelayDist) > 0
+}
+
+func (p NetemParams) qopt() tc.NetemQopt {
+	gap := uint32(p.Gap)
+	// like iproute2, reordering without gap means gap 1
+	if p.Reorder > 0 && gap == 0 {
+		gap = 1
 	}
 
-	// open a rtnetlink socket
-	rtnl, err := tc.Open(&tc.Config{})
-	if err != nil {
-		return fmt.Errorf("Could not open rtnetlink socket: %v", err)
+	return tc.NetemQopt{
+		Latency:   time2Tick(p.Delay),
+		Jitter:    time2Tick(p.Jitter),
+		Limit:     1000,
+		Loss:      formatPercent(p.Loss),
+		Gap:       gap,
+		Duplicate: formatPercent(p.Duplicate),
 	}
-	defer func() {
-		if err := rtnl.Close(); err != nil {
-			logger.Errorf("Could not close rtnetlink socket: %v", err)
-		}
-	}()
+}
+
+func netemQdisc(ifIndex uint32, params NetemParams) *tc.Object {
+	return netemQdiscAt(ifIndex, netemHandle, tc.HandleRoot, params)
+}
 
-	linklayerEthernet := uint8(1)
-	tbfBurst := uint32(rate * 4)  // rate (in bps) / 250 HZ
-	limit := uint32(rate * delay) // rate * latency
+func netemQdiscAt(ifIndex uint32, handle, parent uint32, params NetemParams) *tc.Object {
+	// attributes are sent even with zero values, a replace
+	// keeps the values of the missing ones
+	netem := &tc.Netem{
+		Qopt: params.qopt(),
+		Corr: &tc.NetemCorr{
+			Delay: formatPercent(params.DelayCorr),
+			Loss:  formatPercent(params.LossCorr),
+			Dup:   formatPercent(params.DuplicateCorr),
+		},
+		Reorder: &tc.NetemReorder{
+			Probability: formatPercent(params.Reorder),
+			Correlation: formatPercent(params.ReorderCorr),
+		},
+		Corrupt: &tc.NetemCorrupt{
+			Probability: formatPercent(params.Corrupt),
+			Correlation: formatPercent(params.CorruptCorr),
+		},
+	}
 
-	qdisc := tc.Object{
+	return &tc.Object{
 		Msg: tc.Msg{
 			Family:  unix.AF_UNSPEC,
-			Ifindex: uint32(devID.Attrs().Index),
-			Handle:  core.BuildHandle(0x10, 0x0),
-			Parent:  core.BuildHandle(0x1, 0x1),
+			Ifindex: ifIndex,
+			Handle:  handle,
+			Parent:  parent,
+			Info:    0,
+		},
+		Attribute: tc.Attribute{
+			Kind:  "netem",
+			Netem: netem,
+		},
+	}
+}
+
+// tbfParams returns the parameters of the tbf qdisc limiting the rate, in
+// bits/s, with a queue sized according to the latency. rate64 is set when
+// the rate in bytes/s does not fit in 32 bits
+func tbfParams(latency time.Duration, rate uint64, mtu int) (qopt tc.TbfQopt, burst uint32, rate64 *uint64) {
+	frameSize := uint32(mtu + ethHeaderLen)
+	burst = uint32(rate / 250) // rate / 250 HZ
+	limit := uint32Max         // rate * latency
+	if l := float64(rate) * latency.Seconds(); l < float64(uint32Max) {
+		limit = uint32(l)
+	}
+	// burst and limit must contain at least one frame
+	if burst < frameSize {
+		burst = frameSize
+	}
+	if limit < frameSize {
+		limit = frameSize
+	}
+
+	spec, rate64 := rateSpec(rate)
+	spec.CellLog = 0x3
+
+	return tc.TbfQopt{Mtu: frameSize, Limit: limit, Rate: spec}, burst, rate64
+}
+
+func tbfQdisc(ifIndex uint32, qopt tc.TbfQopt, burst uint32) *tc.Object {
+	return &tc.Object{
+		Msg: tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: ifIndex,
+			Handle:  tbfHandle,
+			Parent:  tbfParent,
 			Info:    0,
 		},
 		Attribute: tc.Attribute{
 			Kind: "tbf",
 			Tbf: &tc.Tbf{
-				Parms: &tc.TbfQopt{
-					Mtu:   1514,
-					Limit: limit,
-					Rate: tc.RateSpec{
-						Rate:      uint32(rate * 125),
-						Linklayer: linklayerEthernet,
-						CellLog:   0x3,
-					},
-				},
-				Burst: &tbfBurst,
+				Parms: &qopt,
+				Burst: &burst,
 			},
 		},
 	}
+}
+
+// tbfRequest sends the tbf qdisc with a raw rtnetlink request since go-tc
+// does not support the TCA_TBF_RATE64 attribute, required for rates above
+// 32 bits. It must be called from the namespace of the interface
+func tbfRequest(ifIndex uint32, qopt tc.TbfQopt, burst uint32, rate64 uint64, flags int) error {
+	rate := nl.TcRateSpec{
+		CellLog:   qopt.Rate.CellLog,
+		Linklayer: qopt.Rate.Linklayer,
+		Overhead:  qopt.Rate.Overhead,
+		CellAlign: int16(qopt.Rate.CellAlign),
+		Mpu:       qopt.Rate.Mpu,
+		Rate:      qopt.Rate.Rate,
+	}
+	options := nl.NewRtAttr(nl.TCA_OPTIONS, nil)
+	options.AddRtAttr(nl.TCA_TBF_PARMS, (&nl.TcTbfQopt{
+		Rate:  rate,
+		Limit: qopt.Limit,
+		Mtu:   qopt.Mtu,
+	}).Serialize())
+	options.AddRtAttr(nl.TCA_TBF_RATE64, nl.Uint64Attr(rate64))
+	options.AddRtAttr(nl.TCA_TBF_BURST, nl.Uint32Attr(burst))
 
-	// tc qdisc add dev ifname root netem ...
-	if err := rtnl.Qdisc().Add(&qdisc); err != nil {
-		return fmt.Errorf("Could not assign qdisc tbf to %s: %v\n", ifname, err)
+	return qdiscRequest(flags, ifIndex, tbfHandle, tbfParent, "tbf", options)
+}
+
+// setTbf adds or replaces the tbf qdisc, through go-tc unless the rate
+// requires TCA_TBF_RATE64
+func setTbf(rtnl *tc.Tc, ifIndex uint32, latency time.Duration, rate uint64, mtu int, replace bool) error {
+	qopt, burst, rate64 := tbfParams(latency, rate, mtu)
+	switch {
+	case rate64 != nil && replace:
+		return tbfRequest(ifIndex, qopt, burst, *rate64, unix.NLM_F_CREATE|unix.NLM_F_REPLACE)
+	case rate64 != nil:
+		return tbfRequest(ifIndex, qopt, burst, *rate64, unix.NLM_F_CREATE|unix.NLM_F_EXCL)
+	case replace:
+		return rtnl.Qdisc().Replace(tbfQdisc(ifIndex, qopt, burst))
+	default:
+		return rtnl.Qdisc().Add(tbfQdisc(ifIndex, qopt, burst))
 	}
+}
 
-	return nil
+func CreateNetem(ifname string, namespace netns.NsHandle, params NetemParams) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		// tc qdisc add dev ifname root netem ...
+		var err error
+		if params.needsRawRequest() {
+			err = netemRequest(ifIndex, params, unix.NLM_F_CREATE|unix.NLM_F_EXCL)
+		} else {
+			err = rtnl.Qdisc().Add(netemQdisc(ifIndex, params))
+		}
+		if err != nil {
+			return fmt.Errorf("Could not assign qdisc netem to %s: %v\n", ifname, err)
+		}
+		return nil
+	})
+}
+
+func CreateTbf(ifname string, namespace netns.NsHandle, latency time.Duration, rate uint64, mtu int) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		// tc qdisc add dev ifname parent 1:1 tbf ...
+		if err := setTbf(rtnl, ifIndex, latency, rate, mtu, false); err != nil {
+			return fmt.Errorf("Could not assign qdisc tbf to %s: %v\n", ifname, err)
+		}
+		return nil
+	})
+}
+
+// ReplaceNetem creates the root netem qdisc of the interface or
+// modifies its parameters if it already exists
+func ReplaceNetem(ifname string, namespace netns.NsHandle, params NetemParams) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		// tc qdisc replace dev ifname root netem ...
+		var err error
+		if params.needsRawRequest() {
+			err = netemRequest(ifIndex, params, unix.NLM_F_CREATE|unix.NLM_F_REPLACE)
+		} else {
+			err = rtnl.Qdisc().Replace(netemQdisc(ifIndex, params))
+		}
+		if err != nil {
+			return fmt.Errorf("Could not replace qdisc netem on %s: %v\n", ifname, err)
+		}
+		return nil
+	})
+}
+
+// ReplaceTbf creates the tbf qdisc attached to the netem qdisc
+// or modifies its parameters if it already exists
+func ReplaceTbf(ifname string, namespace netns.NsHandle, latency time.Duration, rate uint64, mtu int) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		// a htb qdisc may be attached instead of tbf
+		if err := deleteQdiscIfKind(rtnl, ifIndex, tbfHandle, "tbf"); err != nil {
+			return fmt.Errorf("Could not delete qdisc on %s: %v\n", ifname, err)
+		}
+		// tc qdisc replace dev ifname parent 1:1 tbf ...
+		if err := setTbf(rtnl, ifIndex, latency, rate, mtu, true); err != nil {
+			return fmt.Errorf("Could not replace qdisc tbf on %s: %v\n", ifname, err)
+		}
+		return nil
+	})
+}
+
+// DeleteNetem removes the root netem qdisc, and so the attached tbf
+// qdisc, from the interface. Nothing is done if no netem qdisc exists
+func DeleteNetem(ifname string, namespace netns.NsHandle) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		found, err := hasQdisc(rtnl, ifIndex, netemHandle)
+		if err != nil {
+			return fmt.Errorf("Could not get qdiscs of %s: %v\n", ifname, err)
+		} else if !found {
+			return nil
+		}
+
+		if err := rtnl.Qdisc().Delete(netemQdisc(ifIndex, NetemParams{})); err != nil {
+			return fmt.Errorf("Could not delete qdisc netem on %s: %v\n", ifname, err)
+		}
+		return nil
+	})
+}
+
+// DeleteTbf removes the tbf qdisc from the interface.
+// Nothing is done if no tbf qdisc exists
+func DeleteTbf(ifname string, namespace netns.NsHandle) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		// the qdisc can be tbf or htb
+		if err := deleteQdiscIfKind(rtnl, ifIndex, tbfHandle, ""); err != nil {
+			return fmt.Errorf("Could not delete qdisc tbf on %s: %v\n", ifname, err)
+		}
+		return nil
+	})
 }
@@ -0,0 +1,110 @@
+package link
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/florianl/go-tc"
+	"github.com/mroy31/gonetem/internal/utils"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+func skipUnlessNetem(t *testing.T, err error) {
+	// the kernel returns ENOENT when the netem module is missing
+	if err != nil && strings.Contains(err.Error(), "no such file or directory") {
+		t.Skip("Test requires the netem qdisc.")
+	}
+}
+
+func getNetem(t *testing.T, ifname string, ns netns.NsHandle) *tc.Netem {
+	var netem *tc.Netem
+	err := execTc(ifname, ns, func(rtnl *tc.Tc, ifIndex uint32) error {
+		qdiscs, err := rtnl.Qdisc().Get()
+		if err != nil {
+			return err
+		}
+		for _, qdisc := range qdiscs {
+			if qdisc.Ifindex == ifIndex && qdisc.Handle == netemHandle {
+				netem = qdisc.Netem
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unable to get qdiscs of %s: %v", ifname, err)
+	} else if netem == nil {
+		t.Fatalf("No netem qdisc found on %s", ifname)
+	}
+	return netem
+}
+
+func TestTc_ReplaceNetemResetsAttributes(t *testing.T) {
+	tests := []struct {
+		desc    string
+		initial NetemParams
+		updated NetemParams
+	}{
+		{
+			desc: "go-tc request",
+			initial: NetemParams{
+				Delay: 10 * time.Millisecond, DelayCorr: 20,
+				Reorder: 25, ReorderCorr: 50, Corrupt: 5, CorruptCorr: 10,
+			},
+			updated: NetemParams{Delay: 10 * time.Millisecond},
+		},
+		{
+			desc: "raw request",
+			initial: NetemParams{
+				Delay: 10 * time.Millisecond, DelayCorr: 20,
+				Reorder: 25, ReorderCorr: 50, Corrupt: 5, CorruptCorr: 10,
+				LossGE: &NetemGEModel{P: 1, R: 10, BadLoss: 100},
+			},
+			updated: NetemParams{
+				Delay:  10 * time.Millisecond,
+				LossGE: &NetemGEModel{P: 1, R: 10, BadLoss: 100},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			ns, teardown := setUpNetlinkTest(t)
+			defer teardown()
+
+			veth, err := CreateVethLink(utils.RandString(6), ns, utils.RandString(6), ns, DEFAULT_MTU, DEFAULT_TXQLEN)
+			if err != nil {
+				t.Fatalf("Unable to create veth: %v", err)
+			}
+			defer netlink.LinkDel(veth)
+
+			err = CreateNetem(veth.Name, ns, tt.initial)
+			skipUnlessNetem(t, err)
+			if err != nil {
+				t.Fatalf("CreateNetem returns an error: %v", err)
+			}
+			if err := ReplaceNetem(veth.Name, ns, tt.updated); err != nil {
+				t.Fatalf("ReplaceNetem returns an error: %v", err)
+			}
+
+			netem := getNetem(t, veth.Name, ns)
+			if netem.Corr != nil && netem.Corr.Delay != 0 {
+				t.Errorf("Delay correlation has not been reset: %d", netem.Corr.Delay)
+			}
+			if netem.Reorder != nil && netem.Reorder.Probability != 0 {
+				t.Errorf("Reorder has not been reset: %d", netem.Reorder.Probability)
+			}
+			if netem.Corrupt != nil && netem.Corrupt.Probability != 0 {
+				t.Errorf("Corrupt has not been reset: %d", netem.Corrupt.Probability)
+			}
+		})
+	}
+}
+
+func TestTc_NetemQdiscAttributes(t *testing.T) {
+	netem := netemQdisc(1, NetemParams{Delay: 10 * time.Millisecond}).Netem
+	if netem.Corr == nil || netem.Reorder == nil || netem.Corrupt == nil {
+		t.Errorf("Unset attributes are not sent: %+v", netem)
+	}
+}
@@ -0,0 +1,161 @@
+package link
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+
+	"github.com/florianl/go-tc"
+	"github.com/florianl/go-tc/core"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	"github.com/vishvananda/netns"
+	"golang.org/x/sys/unix"
+)
+
+// On the port of a hub connected to a station, a drr qdisc sends the
+// frames in a class depending on the sender. Each class has a netem
+// qdisc with the impairments between the sender and the station
+var (
+	wirelessHandle = core.BuildHandle(0x1, 0x0)
+)
+
+// WirelessPeer is a station which sends frames to the port
+type WirelessPeer struct {
+	Index  int // unique index of the station
+	Mac    net.HardwareAddr
+	Params NetemParams
+}
+
+func wirelessClassID(index int) uint32 {
+	return core.BuildHandle(0x1, uint32(index+1))
+}
+
+func wirelessNetemHandle(index int) uint32 {
+	return core.BuildHandle(uint32(index+2), 0x0)
+}
+
+// u32 offsets are relative to the network header, the source MAC is 8
+// bytes before it in the ethernet header. Keys store the negative offsets
+// as uint32, the kernel adds them with a wrap around
+const (
+	srcMacOff   = uint32(0xfffffff8) // -8, bytes 0-3 of the source MAC
+	srcMacOffHi = uint32(0xfffffffc) // -4, bytes 4-5 and the ethertype
+)
+
+// wirelessFilter sends frames with the source MAC of the peer in its
+// class. Offsets are relative to the network header
+func wirelessFilter(ifIndex uint32, peer WirelessPeer) *tc.Object {
+	classID := wirelessClassID(peer.Index)
+	mac := peer.Mac
+
+	return &tc.Object{
+		Msg: tc.Msg{
+			Family:  unix.AF_UNSPEC,
+			Ifindex: ifIndex,
+			Handle:  0,
+			Parent:  wirelessHandle,
+			Info:    uint32(peer.Index+1)<<16 | uint32(htons(unix.ETH_P_ALL)),
+		},
+		Attribute: tc.Attribute{
+			Kind: "u32",
+			U32: &tc.U32{
+				ClassID: &classID,
+				Sel: &tc.U32Sel{
+					Flags: 0x1, // TC_U32_TERMINAL
+					NKeys: 2,
+					Keys: []tc.U32Key{
+						{
+							Mask: 0xffffffff,
+							Val:  nl.NativeEndian().Uint32(mac[0:4]),
+							Off:  srcMacOff,
+						},
+						{
+							Mask: htonl(0xffff0000),
+							Val:  nl.NativeEndian().Uint32([]byte{mac[4], mac[5], 0, 0}),
+							Off:  srcMacOffHi,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// CreateWirelessPort adds the qdiscs and filters which apply
+// the impairments of each peer to the frames they send
+func CreateWirelessPort(ifname string, namespace netns.NsHandle, peers []WirelessPeer) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		qdisc := &tc.Object{
+			Msg: tc.Msg{
+				Family:  unix.AF_UNSPEC,
+				Ifindex: ifIndex,
+				Handle:  wirelessHandle,
+				Parent:  tc.HandleRoot,
+			},
+			Attribute: tc.Attribute{
+				Kind: "drr",
+				Drr:  &tc.Drr{},
+			},
+		}
+		if err := rtnl.Qdisc().Add(qdisc); err != nil {
+			return fmt.Errorf("Could not assign qdisc drr to %s: %v\n", ifname, err)
+		}
+
+		for _, peer := range peers {
+			class := &tc.Object{
+				Msg: tc.Msg{
+					Family:  unix.AF_UNSPEC,
+					Ifindex: ifIndex,
+					Handle:  wirelessClassID(peer.Index),
+					Parent:  wirelessHandle,
+				},
+				Attribute: tc.Attribute{
+					Kind: "drr",
+					Drr:  &tc.Drr{},
+				},
+			}
+			if err := rtnl.Class().Add(class); err != nil {
+				return fmt.Errorf("Could not add class to %s: %v\n", ifname, err)
+			}
+
+			netem := netemQdiscAt(ifIndex, wirelessNetemHandle(peer.Index), wirelessClassID(peer.Index), peer.Params)
+			if err := rtnl.Qdisc().Add(netem); err != nil {
+				return fmt.Errorf("Could not assign qdisc netem to %s: %v\n", ifname, err)
+			}
+
+			if err := rtnl.Filter().Add(wirelessFilter(ifIndex, peer)); err != nil {
+				return fmt.Errorf("Could not add filter to %s: %v\n", ifname, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateWirelessPeer modifies the impairments of the frames sent by peer
+func UpdateWirelessPeer(ifname string, namespace netns.NsHandle, peer WirelessPeer) error {
+	return execTc(ifname, namespace, func(rtnl *tc.Tc, ifIndex uint32) error {
+		netem := netemQdiscAt(ifIndex, wirelessNetemHandle(peer.Index), wirelessClassID(peer.Index), peer.Params)
+		if err := rtnl.Qdisc().Replace(netem); err != nil {
+			return fmt.Errorf("Could not replace qdisc netem on %s: %v\n", ifname, err)
+		}
+		return nil
+	})
+}
+
+// GetInterfaceMac returns the MAC address of the interface ifname
+func GetInterfaceMac(ifname string, namespace netns.NsHandle) (net.HardwareAddr, error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	if err := netns.Set(namespace); err != nil {
+		return nil, fmt.Errorf("Error when switching netns: %v", err)
+	}
+
+	ifObj, err := netlink.LinkByName(ifname)
+	if err != nil {
+		return nil, fmt.Errorf("Unable get link %s: %v", ifname, err)
+	}
+	return ifObj.Attrs().HardwareAddr, nil
+}
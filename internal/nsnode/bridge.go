@@ -0,0 +1,263 @@
+package nsnode
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/moby/term"
+	"github.com/mroy31/gonetem/internal/link"
+	"github.com/mroy31/gonetem/internal/options"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netns"
+)
+
+// name of the bridge in the namespace of a bridge node
+const bridgeName = "br0"
+
+// BridgePort is the VLAN configuration of a port of a bridge node, Pvid
+// is the untagged VLAN and Vlans are the tagged ones
+type BridgePort struct {
+	Pvid  int
+	Vlans []int
+}
+
+// BridgeNode is a switch made of a linux bridge in its own namespace.
+// Its configuration is given by the network file, so it saves no file
+type BridgeNode struct {
+	PrjID      string
+	Name       string
+	ShortName  string
+	NetnsName  string
+	Stp        bool
+	Ports      map[int]BridgePort
+	Interfaces map[string]link.IfState
+	Running    bool
+	Logger     *logrus.Entry
+}
+
+func (b *BridgeNode) GetName() string {
+	return b.Name
+}
+
+func (b *BridgeNode) GetShortName() string {
+	if b.ShortName == "" {
+		return b.Name
+	}
+	return b.ShortName
+}
+
+func (b *BridgeNode) GetType() string {
+	return "bridge"
+}
+
+func (b *BridgeNode) IsRunning() bool {
+	return b.Running
+}
+
+func (b *BridgeNode) GetNetns() (netns.NsHandle, error) {
+	return netns.GetFromName(b.NetnsName)
+}
+
+func (b *BridgeNode) GetInterfaceName(ifIndex int) string {
+	return fmt.Sprintf("eth%d", ifIndex)
+}
+
+func (b *BridgeNode) Start() error {
+	if !b.Running {
+		b.Logger.Debug("Start Node")
+
+		ns, err := b.GetNetns()
+		if err != nil {
+			return err
+		}
+		defer ns.Close()
+
+		if err := link.SetInterfaceState(bridgeName, ns, link.IFSTATE_UP); err != nil {
+			return err
+		}
+		for ifName, state := range b.Interfaces {
+			if err := link.SetInterfaceState(ifName, ns, state); err != nil {
+				return err
+			}
+		}
+		b.Running = true
+	}
+
+	return nil
+}
+
+// Stop sets the ports and the bridge down, the peers lose their carrier
+func (b *BridgeNode) Stop() error {
+	if b.Running {
+		b.Logger.Debug("Stop Node")
+
+		ns, err := b.GetNetns()
+		if err != nil {
+			return err
+		}
+		defer ns.Close()
+
+		for ifName := range b.Interfaces {
+			if err := link.SetInterfaceState(ifName, ns, link.IFSTATE_DOWN); err != nil {
+				return err
+			}
+		}
+		if err := link.SetInterfaceState(bridgeName, ns, link.IFSTATE_DOWN); err != nil {
+			return err
+		}
+		b.Running = false
+	}
+
+	return nil
+}
+
+func (b *BridgeNode) AddInterface(ifName string, ifIndex int, ns netns.NsHandle) error {
+	targetIfName := b.GetInterfaceName(ifIndex)
+	if err := link.RenameLink(ifName, targetIfName, ns); err != nil {
+		return err
+	}
+
+	port := b.Ports[ifIndex]
+	if err := link.AddSwitchPort(bridgeName, targetIfName, port.Pvid, port.Vlans, ns); err != nil {
+		return err
+	}
+
+	b.Interfaces[targetIfName] = link.IFSTATE_UP
+	return nil
+}
+
+// RemoveInterface deletes the port ifIndex of the bridge, and so the veth
+// pair it belongs to
+func (b *BridgeNode) RemoveInterface(ifIndex int) error {
+	ifName := b.GetInterfaceName(ifIndex)
+	if _, found := b.Interfaces[ifName]; !found {
+		return fmt.Errorf("Interface %s.%d not found", b.GetName(), ifIndex)
+	}
+
+	ns, err := b.GetNetns()
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+
+	// the interface is already deleted if it was the peer of a removed one
+	if link.IsLinkExist(ifName, ns) {
+		if err := link.DeleteLink(ifName, ns); err != nil {
+			return err
+		}
+	}
+	delete(b.Interfaces, ifName)
+
+	return nil
+}
+
+func (b *BridgeNode) LoadConfig(confPath string) ([]string, error) {
+	return []string{}, nil
+}
+
+func (b *BridgeNode) Save(dstPath string) error {
+	return nil
+}
+
+func (b *BridgeNode) CanRunConsole() error {
+	if !b.Running {
+		return errors.New("Not running")
+	}
+	return nil
+}
+
+// Console runs a shell in the namespace of the bridge, where the command
+// bridge of iproute2 shows the ports, their STP state and their VLANs
+func (b *BridgeNode) Console(shell bool, in io.ReadCloser, out io.Writer, resizeCh chan term.Winsize) error {
+	if !b.Running {
+		return errors.New("Not running")
+	}
+
+	return runConsole(b.NetnsName, b.Name, in, out, resizeCh)
+}
+
+func (b *BridgeNode) Capture(ifIndex int, out io.Writer) error {
+	if !b.Running {
+		return errors.New("Not running")
+	}
+
+	return runCapture(b.NetnsName, b.GetInterfaceName(ifIndex), out)
+}
+
+func (b *BridgeNode) CopyFrom(srcPath, destPath string) error {
+	return fmt.Errorf("CopyFrom action not supported for bridge node")
+}
+
+func (b *BridgeNode) CopyTo(srcPath, destPath string) error {
+	return fmt.Errorf("CopyTo action not supported for bridge node")
+}
+
+func (b *BridgeNode) GetInterfacesState() map[string]link.IfState {
+	return b.Interfaces
+}
+
+// SetInterfaceState changes the state of a port, it is applied when the
+// node starts if it is stopped
+func (b *BridgeNode) SetInterfaceState(ifIndex int, state link.IfState) error {
+	ifName := b.GetInterfaceName(ifIndex)
+	st, found := b.Interfaces[ifName]
+	if !found {
+		return fmt.Errorf("Interface %s.%d not found", b.GetName(), ifIndex)
+	}
+
+	if b.Running && state != st {
+		ns, err := b.GetNetns()
+		if err != nil {
+			return err
+		}
+		defer ns.Close()
+
+		if err := link.SetInterfaceState(ifName, ns, state); err != nil {
+			return err
+		}
+	}
+	b.Interfaces[ifName] = state
+	return nil
+}
+
+func (b *BridgeNode) Close() error {
+	b.Running = false
+	b.Interfaces = make(map[string]link.IfState)
+	return link.DeleteNetns(b.NetnsName)
+}
+
+// NewBridgeNode creates the namespace of the node and its bridge, VLAN
+// filtering is enabled if a port has a VLAN configuration
+func NewBridgeNode(prjID, name, shortName string, stp bool, ports map[int]BridgePort) (*BridgeNode, error) {
+	node := &BridgeNode{
+		PrjID:      prjID,
+		Name:       name,
+		ShortName:  shortName,
+		NetnsName:  fmt.Sprintf("%s%s.%s", options.NETEM_ID, prjID, name),
+		Stp:        stp,
+		Ports:      ports,
+		Interfaces: make(map[string]link.IfState),
+		Logger: logrus.WithFields(logrus.Fields{
+			"project": prjID,
+			"node":    "bridge-" + name,
+		}),
+	}
+
+	ns, err := link.CreateNetns(node.NetnsName)
+	if err != nil {
+		return node, err
+	}
+	defer ns.Close()
+
+	// the ports do not send IPv6 frames of their own
+	for _, key := range []string{"net.ipv6.conf.all.disable_ipv6", "net.ipv6.conf.default.disable_ipv6"} {
+		if err := link.SetSysctl(key, "1", ns); err != nil {
+			return node, err
+		}
+	}
+	if _, err := link.CreateSwitch(bridgeName, ns, stp, len(ports) > 0); err != nil {
+		return node, err
+	}
+	return node, nil
+}
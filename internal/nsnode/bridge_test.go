@@ -0,0 +1,61 @@
+package nsnode
+
+import (
+	"testing"
+
+	"github.com/mroy31/gonetem/internal/link"
+	"github.com/mroy31/gonetem/internal/utils"
+)
+
+func TestBridgeNode_AttachPorts(t *testing.T) {
+	tests := []struct {
+		desc  string
+		stp   bool
+		ports map[int]BridgePort
+	}{
+		{desc: "BridgeNode: default", ports: map[int]BridgePort{}},
+		{desc: "BridgeNode: stp", stp: true, ports: map[int]BridgePort{}},
+		{desc: "BridgeNode: vlans", ports: map[int]BridgePort{0: {Pvid: 10}, 1: {Pvid: 10, Vlans: []int{20, 30}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			prjID := utils.RandString(3)
+			name := utils.RandString(4)
+
+			node, err := NewBridgeNode(prjID, name, name, tt.stp, tt.ports)
+			if err != nil {
+				t.Fatalf("Unable to create bridge node: %v", err)
+			}
+			defer node.Close()
+
+			if err := node.Start(); err != nil {
+				t.Fatalf("Unable to start bridge node: %v", err)
+			}
+
+			ns, err := node.GetNetns()
+			if err != nil {
+				t.Fatalf("Unable to get netns of the node: %v", err)
+			}
+			defer ns.Close()
+
+			// connect the ports 0 and 1 of the bridge
+			_, err = link.CreateVethLink(prjID+".0", ns, prjID+".1", ns, link.DEFAULT_MTU, link.DEFAULT_TXQLEN)
+			if err != nil {
+				t.Fatalf("Unable to create veth: %v", err)
+			}
+			for ifIndex, ifName := range []string{prjID + ".0", prjID + ".1"} {
+				if err := node.AddInterface(ifName, ifIndex, ns); err != nil {
+					t.Fatalf("Unable to add port %s: %v", ifName, err)
+				}
+			}
+
+			if err := node.SetInterfaceState(1, link.IFSTATE_DOWN); err != nil {
+				t.Errorf("Unable to set port down: %v", err)
+			}
+			if err := node.Stop(); err != nil {
+				t.Errorf("Unable to stop bridge node: %v", err)
+			}
+		})
+	}
+}
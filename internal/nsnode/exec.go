@@ -0,0 +1,66 @@
+package nsnode
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"syscall"
+
+	"github.com/moby/term"
+	"github.com/sirupsen/logrus"
+)
+
+// folder of the named namespaces
+const netnsDir = "/run/netns"
+
+// nsCommand returns the command name run with nsenter in the namespace
+// nsName
+func nsCommand(nsName, name string, args ...string) *exec.Cmd {
+	nsArgs := []string{"--net=" + path.Join(netnsDir, nsName), name}
+	return exec.Command("nsenter", append(nsArgs, args...)...)
+}
+
+// runConsole runs a shell in the namespace nsName with a pseudo terminal.
+// The node shares the hostname of the server, so the prompt gives its name
+func runConsole(nsName, nodeName string, in io.ReadCloser, out io.Writer, resizeCh chan term.Winsize) error {
+	master, slave, err := openPty()
+	if err != nil {
+		return err
+	}
+	defer master.Close()
+
+	cmd := nsCommand(nsName, "/bin/bash", "--norc")
+	cmd.Env = append(os.Environ(), fmt.Sprintf("PS1=%s:\\w# ", nodeName))
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = slave, slave, slave
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+	err = cmd.Start()
+	slave.Close()
+	if err != nil {
+		return fmt.Errorf("Unable to run console: %w", err)
+	}
+
+	// resize TTY goroutine
+	go func() {
+		for ws := range resizeCh {
+			ws := ws
+			if err := term.SetWinsize(master.Fd(), &ws); err != nil {
+				logrus.WithField("node", nodeName).Errorf("unable to resize TTY: %s", err)
+			}
+		}
+	}()
+	go io.Copy(master, in)
+
+	// reading the master fails once the shell has exited
+	io.Copy(out, master)
+	return cmd.Wait()
+}
+
+// runCapture writes the frames of the interface ifName of the namespace
+// nsName in out, with the format pcap
+func runCapture(nsName, ifName string, out io.Writer) error {
+	cmd := nsCommand(nsName, "tcpdump", "-w", "-", "-s", "0", "-U", "-i", ifName)
+	cmd.Stdout = out
+	return cmd.Run()
+}
@@ -0,0 +1,362 @@
+package nsnode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/moby/term"
+	"github.com/mroy31/gonetem/internal/link"
+	"github.com/mroy31/gonetem/internal/options"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	// AF_INET and AF_INET6, as saved by network-config.py
+	familyV4 = 2
+	familyV6 = 10
+)
+
+// NetConfig is the file <node>.net.conf, with the format used by the
+// script network-config.py of the host and server images
+type NetConfig struct {
+	Interfaces map[string][]string `json:"interfaces"`
+	Routes     []NetRoute          `json:"routes"`
+}
+
+type NetRoute struct {
+	Dst     string `json:"dst"`
+	Gateway string `json:"gateway"`
+	Family  int    `json:"family"`
+}
+
+// NetnsNode is a host made of a named network namespace only, without
+// container. Its addresses and routes are configured by the server
+type NetnsNode struct {
+	PrjID        string
+	Name         string
+	ShortName    string
+	NetnsName    string
+	Interfaces   map[string]link.IfState
+	Running      bool
+	ConfigLoaded bool
+	Logger       *logrus.Entry
+}
+
+func (n *NetnsNode) GetName() string {
+	return n.Name
+}
+
+func (n *NetnsNode) GetShortName() string {
+	if n.ShortName == "" {
+		return n.Name
+	}
+	return n.ShortName
+}
+
+func (n *NetnsNode) GetType() string {
+	return "netns"
+}
+
+func (n *NetnsNode) IsRunning() bool {
+	return n.Running
+}
+
+// GetNetns returns the namespace of the node, which exists even when the
+// node is stopped
+func (n *NetnsNode) GetNetns() (netns.NsHandle, error) {
+	return netns.GetFromName(n.NetnsName)
+}
+
+func (n *NetnsNode) GetInterfaceName(ifIndex int) string {
+	return fmt.Sprintf("eth%d", ifIndex)
+}
+
+func (n *NetnsNode) Start() error {
+	if !n.Running {
+		n.Logger.Debug("Start Node")
+
+		ns, err := n.GetNetns()
+		if err != nil {
+			return err
+		}
+		defer ns.Close()
+
+		if err := link.SetInterfaceState("lo", ns, link.IFSTATE_UP); err != nil {
+			return err
+		}
+		for ifName, state := range n.Interfaces {
+			if err := link.SetInterfaceState(ifName, ns, state); err != nil {
+				return err
+			}
+		}
+		n.Running = true
+	}
+
+	return nil
+}
+
+// Stop sets the interfaces down and removes their addresses, and so
+// the routes, as a stopped container would do
+func (n *NetnsNode) Stop() error {
+	if n.Running {
+		n.Logger.Debug("Stop Node")
+
+		ns, err := n.GetNetns()
+		if err != nil {
+			return err
+		}
+		defer ns.Close()
+
+		for ifName := range n.Interfaces {
+			if err := link.FlushAddresses(ifName, ns); err != nil {
+				return err
+			}
+			if err := link.SetInterfaceState(ifName, ns, link.IFSTATE_DOWN); err != nil {
+				return err
+			}
+		}
+		if err := link.SetInterfaceState("lo", ns, link.IFSTATE_DOWN); err != nil {
+			return err
+		}
+		n.Running = false
+		n.ConfigLoaded = false
+	}
+
+	return nil
+}
+
+func (n *NetnsNode) AddInterface(ifName string, ifIndex int, ns netns.NsHandle) error {
+	targetIfName := n.GetInterfaceName(ifIndex)
+	if err := link.RenameLink(ifName, targetIfName, ns); err != nil {
+		return err
+	}
+
+	n.Interfaces[targetIfName] = link.IFSTATE_UP
+	return nil
+}
+
+// RemoveInterface deletes the interface ifIndex, and so the veth pair it
+// belongs to
+func (n *NetnsNode) RemoveInterface(ifIndex int) error {
+	ifName := n.GetInterfaceName(ifIndex)
+	if _, found := n.Interfaces[ifName]; !found {
+		return fmt.Errorf("Interface %s.%d not found", n.GetName(), ifIndex)
+	}
+
+	ns, err := n.GetNetns()
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+
+	// the interface is already deleted if it was the peer of a removed one
+	if link.IsLinkExist(ifName, ns) {
+		if err := link.DeleteLink(ifName, ns); err != nil {
+			return err
+		}
+	}
+	delete(n.Interfaces, ifName)
+
+	return nil
+}
+
+// LoadConfig applies the addresses and the routes of the file
+// <node>.net.conf
+func (n *NetnsNode) LoadConfig(confPath string) ([]string, error) {
+	if !n.Running {
+		n.Logger.Warn("LoadConfig: node not running")
+		return []string{}, nil
+	}
+	if n.ConfigLoaded {
+		return []string{}, nil
+	}
+
+	filename := path.Join(confPath, n.Name+".net.conf")
+	data, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		n.ConfigLoaded = true
+		return []string{}, nil
+	} else if err != nil {
+		return []string{}, err
+	}
+
+	var config NetConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return []string{}, fmt.Errorf("Unable to parse config file %s: %w", filename, err)
+	}
+
+	ns, err := n.GetNetns()
+	if err != nil {
+		return []string{}, err
+	}
+	defer ns.Close()
+
+	ifNames := make([]string, 0, len(config.Interfaces))
+	for ifName := range config.Interfaces {
+		ifNames = append(ifNames, ifName)
+	}
+	sort.Strings(ifNames)
+	for _, ifName := range ifNames {
+		for _, address := range config.Interfaces[ifName] {
+			if err := link.AddAddress(ifName, address, ns); err != nil {
+				return []string{}, err
+			}
+		}
+	}
+	for _, route := range config.Routes {
+		if err := link.AddRoute(link.Route{Dst: route.Dst, Gateway: route.Gateway}, ns); err != nil {
+			return []string{}, err
+		}
+	}
+
+	n.ConfigLoaded = true
+	return []string{}, nil
+}
+
+// Save writes the addresses and the routes of the node in the file
+// <node>.net.conf
+func (n *NetnsNode) Save(dstPath string) error {
+	if !n.Running || !n.ConfigLoaded {
+		n.Logger.Warn("Save: node not running")
+		return nil
+	}
+
+	ns, err := n.GetNetns()
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+
+	config := NetConfig{Interfaces: make(map[string][]string), Routes: make([]NetRoute, 0)}
+	for ifName := range n.Interfaces {
+		addresses, err := link.GetAddresses(ifName, ns)
+		if err != nil {
+			return err
+		}
+		if len(addresses) > 0 {
+			config.Interfaces[ifName] = addresses
+		}
+	}
+	routes, err := link.GetRoutes(ns)
+	if err != nil {
+		return err
+	}
+	for _, route := range routes {
+		family := familyV4
+		if strings.Contains(route.Gateway, ":") {
+			family = familyV6
+		}
+		config.Routes = append(config.Routes, NetRoute{Dst: route.Dst, Gateway: route.Gateway, Family: family})
+	}
+
+	data, err := json.MarshalIndent(config, "", "    ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path.Join(dstPath, n.Name+".net.conf"), data, 0644)
+}
+
+func (n *NetnsNode) CanRunConsole() error {
+	if !n.Running {
+		return errors.New("Not running")
+	}
+	return nil
+}
+
+// Console runs a shell in the namespace, with a pseudo terminal
+func (n *NetnsNode) Console(shell bool, in io.ReadCloser, out io.Writer, resizeCh chan term.Winsize) error {
+	if !n.Running {
+		return errors.New("Not running")
+	}
+
+	return runConsole(n.NetnsName, n.Name, in, out, resizeCh)
+}
+
+func (n *NetnsNode) Capture(ifIndex int, out io.Writer) error {
+	if !n.Running {
+		return errors.New("Not running")
+	}
+
+	return runCapture(n.NetnsName, n.GetInterfaceName(ifIndex), out)
+}
+
+func (n *NetnsNode) CopyFrom(srcPath, destPath string) error {
+	return fmt.Errorf("CopyFrom action not supported for netns node")
+}
+
+func (n *NetnsNode) CopyTo(srcPath, destPath string) error {
+	return fmt.Errorf("CopyTo action not supported for netns node")
+}
+
+func (n *NetnsNode) GetInterfacesState() map[string]link.IfState {
+	return n.Interfaces
+}
+
+// SetInterfaceState changes the state of an interface, it is applied when
+// the node starts if it is stopped
+func (n *NetnsNode) SetInterfaceState(ifIndex int, state link.IfState) error {
+	ifName := n.GetInterfaceName(ifIndex)
+	st, found := n.Interfaces[ifName]
+	if !found {
+		return fmt.Errorf("Interface %s.%d not found", n.GetName(), ifIndex)
+	}
+
+	if n.Running && state != st {
+		ns, err := n.GetNetns()
+		if err != nil {
+			return err
+		}
+		defer ns.Close()
+
+		if err := link.SetInterfaceState(ifName, ns, state); err != nil {
+			return err
+		}
+	}
+	n.Interfaces[ifName] = state
+	return nil
+}
+
+func (n *NetnsNode) Close() error {
+	n.Running = false
+	n.Interfaces = make(map[string]link.IfState)
+	return link.DeleteNetns(n.NetnsName)
+}
+
+// NewNetnsNode creates the namespace of the node, ipv6 is disabled
+// unless ipv6 is set, like in the docker nodes
+func NewNetnsNode(prjID, name, shortName string, ipv6 bool) (*NetnsNode, error) {
+	node := &NetnsNode{
+		PrjID:      prjID,
+		Name:       name,
+		ShortName:  shortName,
+		NetnsName:  fmt.Sprintf("%s%s.%s", options.NETEM_ID, prjID, name),
+		Interfaces: make(map[string]link.IfState),
+		Logger: logrus.WithFields(logrus.Fields{
+			"project": prjID,
+			"node":    "netns-" + name,
+		}),
+	}
+
+	ns, err := link.CreateNetns(node.NetnsName)
+	if err != nil {
+		return node, err
+	}
+	defer ns.Close()
+
+	if !ipv6 {
+		for _, key := range []string{"net.ipv6.conf.all.disable_ipv6", "net.ipv6.conf.default.disable_ipv6"} {
+			if err := link.SetSysctl(key, "1", ns); err != nil {
+				return node, err
+			}
+		}
+	}
+	return node, nil
+}
@@ -0,0 +1,97 @@
+package nsnode
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/mroy31/gonetem/internal/link"
+	"github.com/mroy31/gonetem/internal/utils"
+)
+
+func TestNetnsNode_LoadSaveConfig(t *testing.T) {
+	prjID := utils.RandString(3)
+	name := utils.RandString(4)
+
+	node, err := NewNetnsNode(prjID, name, name, false)
+	if err != nil {
+		t.Fatalf("Unable to create netns node: %v", err)
+	}
+	defer node.Close()
+
+	if err := node.Start(); err != nil {
+		t.Fatalf("Unable to start netns node: %v", err)
+	}
+
+	// connect eth0 and eth1 of the node
+	ns, err := node.GetNetns()
+	if err != nil {
+		t.Fatalf("Unable to get netns of the node: %v", err)
+	}
+	defer ns.Close()
+
+	_, err = link.CreateVethLink(prjID+".0", ns, prjID+".1", ns, link.DEFAULT_MTU, link.DEFAULT_TXQLEN)
+	if err != nil {
+		t.Fatalf("Unable to create veth: %v", err)
+	}
+	for ifIndex, ifName := range []string{prjID + ".0", prjID + ".1"} {
+		if err := node.AddInterface(ifName, ifIndex, ns); err != nil {
+			t.Fatalf("Unable to add interface %s: %v", ifName, err)
+		}
+	}
+
+	dir, err := ioutil.TempDir("/tmp", "ntmtst")
+	if err != nil {
+		t.Fatalf("Unable to create temp folder: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := NetConfig{
+		Interfaces: map[string][]string{
+			"eth0": {"10.0.0.1/24"},
+			"eth1": {"10.0.1.1/24"},
+		},
+		Routes: []NetRoute{{Dst: "192.168.0.0/16", Gateway: "10.0.0.254", Family: familyV4}},
+	}
+	data, _ := json.Marshal(config)
+	if err := ioutil.WriteFile(path.Join(dir, name+".net.conf"), data, 0644); err != nil {
+		t.Fatalf("Unable to write config: %v", err)
+	}
+
+	if _, err := node.LoadConfig(dir); err != nil {
+		t.Fatalf("Unable to load config: %v", err)
+	}
+	if err := os.Remove(path.Join(dir, name+".net.conf")); err != nil {
+		t.Fatalf("Unable to remove config: %v", err)
+	}
+	if err := node.Save(dir); err != nil {
+		t.Fatalf("Unable to save config: %v", err)
+	}
+
+	data, err = ioutil.ReadFile(path.Join(dir, name+".net.conf"))
+	if err != nil {
+		t.Fatalf("Config has not been saved: %v", err)
+	}
+	var saved NetConfig
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("Unable to parse saved config: %v", err)
+	}
+	if !reflect.DeepEqual(saved, config) {
+		t.Errorf("Wrong saved config: %v != %v", saved, config)
+	}
+
+	// addresses are removed when the node stops
+	if err := node.Stop(); err != nil {
+		t.Fatalf("Unable to stop netns node: %v", err)
+	}
+	addresses, err := link.GetAddresses("eth0", ns)
+	if err != nil {
+		t.Fatalf("Unable to get addresses: %v", err)
+	}
+	if len(addresses) != 0 {
+		t.Errorf("Addresses remain on a stopped node: %v", addresses)
+	}
+}
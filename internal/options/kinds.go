@@ -0,0 +1,81 @@
+package options
+
+import "fmt"
+
+// NodeKindFile is a configuration file of a node, stored in the project
+// as <node>.<Name> and copied to Path in the container
+type NodeKindFile struct {
+	Name string
+	Path string
+}
+
+// NodeKindProbe is a command which succeeds once the node is ready
+type NodeKindProbe struct {
+	Command string
+	Timeout int `yaml:",omitempty"` // seconds
+}
+
+// NodeKind defines a kind of docker node, used with the type docker.<kind>.
+// Commands are run with sh -c, except the console one
+type NodeKind struct {
+	Image   string
+	Files   []NodeKindFile `yaml:",omitempty"`
+	Console string         `yaml:",omitempty"` // /bin/bash if empty
+	Start   string         `yaml:",omitempty"` // run once the files are loaded
+	Save    string         `yaml:",omitempty"` // run before the files are saved
+	Ready   *NodeKindProbe `yaml:",omitempty"` // waited before the files are loaded
+}
+
+const (
+	DEFAULT_PROBE_TIMEOUT = 30
+	netConfigPath         = "/tmp/custom.net.conf"
+)
+
+// builtinKinds returns the kinds of the gonetem images
+func builtinKinds() map[string]NodeKind {
+	hostFiles := []NodeKindFile{
+		{Name: "net.conf", Path: netConfigPath},
+		{Name: "ntp.conf", Path: "/etc/ntp.conf"},
+	}
+	serverFiles := append(hostFiles,
+		NodeKindFile{Name: "dhcpd.conf", Path: "/etc/dhcp/dhcpd.conf"},
+		NodeKindFile{Name: "tftpd-hpa.default", Path: "/etc/default/tftpd-hpa"},
+	)
+	loadNetConfig := fmt.Sprintf("[ ! -f %s ] || network-config.py -l %s", netConfigPath, netConfigPath)
+	saveNetConfig := "network-config.py -s " + netConfigPath
+
+	return map[string]NodeKind{
+		"router": {
+			Image:   GetDockerImageId(IMG_ROUTER),
+			Files:   []NodeKindFile{{Name: "frr.conf", Path: "/etc/frr/frr.conf"}},
+			Console: "/usr/bin/vtysh",
+			Start:   "/usr/lib/frr/frrinit.sh start > /dev/null",
+			Save:    "vtysh -w && chmod +r /etc/frr/frr.conf",
+		},
+		"host": {
+			Image: GetDockerImageId(IMG_HOST),
+			Files: hostFiles,
+			Start: loadNetConfig,
+			Save:  saveNetConfig,
+		},
+		"server": {
+			Image: GetDockerImageId(IMG_SERVER),
+			Files: serverFiles,
+			Start: loadNetConfig,
+			Save:  saveNetConfig,
+		},
+	}
+}
+
+// GetNodeKind returns the kind name, searched in kinds (declared in the
+// network file), then in the server config and in the gonetem kinds
+func GetNodeKind(name string, kinds map[string]NodeKind) (NodeKind, bool) {
+	if kind, found := kinds[name]; found {
+		return kind, true
+	}
+	if kind, found := ServerConfig.Kinds[name]; found {
+		return kind, true
+	}
+	kind, found := builtinKinds()[name]
+	return kind, found
+}
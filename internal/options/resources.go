@@ -0,0 +1,96 @@
+package options
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	memoryRE    = regexp.MustCompile(`^([0-9]*\.?[0-9]+)\s*([kmgt]?)b?$`)
+	memoryUnits = map[string]float64{
+		"":  1,
+		"k": 1 << 10,
+		"m": 1 << 20,
+		"g": 1 << 30,
+		"t": 1 << 40,
+	}
+)
+
+// NodeResources are the resource limits of a docker node, a zero value
+// means no limit
+type NodeResources struct {
+	Cpus      float64 `yaml:",omitempty"`
+	Memory    string  `yaml:",omitempty"` // bytes if no unit, like 512m or 2g
+	PidsLimit int64   `yaml:"pids_limit,omitempty"`
+}
+
+// ParseMemory converts a memory size, with an optional unit k, m, g or t,
+// in bytes
+func ParseMemory(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	match := memoryRE.FindStringSubmatch(strings.ToLower(strings.TrimSpace(value)))
+	if match == nil {
+		return 0, fmt.Errorf("Memory '%s' is not valid", value)
+	}
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("Memory '%s' is not valid", value)
+	}
+	return int64(number * memoryUnits[match[2]]), nil
+}
+
+// GetNodeResources returns the limits of a node, the unset limits are
+// taken from the default then the maximum of the server config
+func GetNodeResources(resources NodeResources) (NodeResources, error) {
+	defaults, max := ServerConfig.Resources.Default, ServerConfig.Resources.Max
+
+	if resources.Cpus < 0 {
+		return resources, fmt.Errorf("cpus must be > 0")
+	}
+	if resources.Cpus == 0 {
+		resources.Cpus = defaults.Cpus
+	}
+	if resources.Cpus == 0 {
+		resources.Cpus = max.Cpus
+	}
+	if max.Cpus > 0 && resources.Cpus > max.Cpus {
+		return resources, fmt.Errorf("cpus %v exceeds the maximum %v of the server", resources.Cpus, max.Cpus)
+	}
+
+	if resources.Memory == "" {
+		resources.Memory = defaults.Memory
+	}
+	if resources.Memory == "" {
+		resources.Memory = max.Memory
+	}
+	memory, err := ParseMemory(resources.Memory)
+	if err != nil {
+		return resources, err
+	}
+	maxMemory, err := ParseMemory(max.Memory)
+	if err != nil {
+		return resources, fmt.Errorf("Maximum of the server: %w", err)
+	}
+	if maxMemory > 0 && memory > maxMemory {
+		return resources, fmt.Errorf("memory %s exceeds the maximum %s of the server", resources.Memory, max.Memory)
+	}
+
+	if resources.PidsLimit < 0 {
+		return resources, fmt.Errorf("pids_limit must be > 0")
+	}
+	if resources.PidsLimit == 0 {
+		resources.PidsLimit = defaults.PidsLimit
+	}
+	if resources.PidsLimit == 0 {
+		resources.PidsLimit = max.PidsLimit
+	}
+	if max.PidsLimit > 0 && resources.PidsLimit > max.PidsLimit {
+		return resources, fmt.Errorf("pids_limit %d exceeds the maximum %d of the server", resources.PidsLimit, max.PidsLimit)
+	}
+
+	return resources, nil
+}
@@ -17,6 +17,7 @@ const (
 	INITIAL_SERVER_CONFIG = `
 listen: "localhost:10110"
 workdir: /tmp
+library: /etc/gonetem/modules
 docker:
   images:
     server: mroy31/gonetem-server
@@ -38,6 +39,7 @@ const (
 type NetemServerConfig struct {
 	Listen  string
 	Workdir string
+	Library string
 	Docker  struct {
 		Images struct {
 			Server string
@@ -46,6 +48,14 @@ type NetemServerConfig struct {
 			Ovs    string
 		}
 	}
+	// kinds of docker nodes, they may redefine router, host and server
+	Kinds map[string]NodeKind
+	// limits of the docker nodes which do not set them, and highest
+	// limits a node may set
+	Resources struct {
+		Default NodeResources
+		Max     NodeResources
+	}
 }
 
 var (
@@ -42,3 +42,103 @@ func TestOptions_ImageId(t *testing.T) {
 		t.Fatalf("Error: %s != mroy31/ovs-img:0.0.0", id)
 	}
 }
+
+func TestOptions_NodeKind(t *testing.T) {
+	InitServerConfig()
+	ServerConfig.Kinds = map[string]NodeKind{
+		"bird":   {Image: "bird:server"},
+		"router": {Image: "frr:server"},
+	}
+	defer func() { ServerConfig.Kinds = nil }()
+
+	kinds := map[string]NodeKind{"bird": {Image: "bird:network"}}
+	tests := []struct {
+		desc          string
+		name          string
+		expectedImage string
+		expectedFound bool
+	}{
+		{desc: "NodeKind: network file", name: "bird", expectedImage: "bird:network", expectedFound: true},
+		{desc: "NodeKind: server config", name: "router", expectedImage: "frr:server", expectedFound: true},
+		{desc: "NodeKind: builtin", name: "host", expectedImage: GetDockerImageId(IMG_HOST), expectedFound: true},
+		{desc: "NodeKind: unknown", name: "suricata", expectedFound: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			kind, found := GetNodeKind(tt.name, kinds)
+			if found != tt.expectedFound {
+				t.Fatalf("Kind %s found: %v != %v", tt.name, found, tt.expectedFound)
+			}
+			if kind.Image != tt.expectedImage {
+				t.Errorf("Wrong image: %s != %s", kind.Image, tt.expectedImage)
+			}
+		})
+	}
+}
+
+func TestOptions_NodeResources(t *testing.T) {
+	InitServerConfig()
+	ServerConfig.Resources.Default = NodeResources{Cpus: 1, Memory: "256m"}
+	ServerConfig.Resources.Max = NodeResources{Cpus: 2, Memory: "1g", PidsLimit: 500}
+	defer func() {
+		ServerConfig.Resources.Default = NodeResources{}
+		ServerConfig.Resources.Max = NodeResources{}
+	}()
+
+	tests := []struct {
+		desc              string
+		resources         NodeResources
+		expectedResources NodeResources
+		expectedErr       bool
+	}{
+		{
+			desc:              "NodeResources: defaults",
+			resources:         NodeResources{},
+			expectedResources: NodeResources{Cpus: 1, Memory: "256m", PidsLimit: 500},
+		},
+		{
+			desc:              "NodeResources: node limits",
+			resources:         NodeResources{Cpus: 1.5, Memory: "512M", PidsLimit: 100},
+			expectedResources: NodeResources{Cpus: 1.5, Memory: "512M", PidsLimit: 100},
+		},
+		{
+			desc:        "NodeResources: cpus above maximum",
+			resources:   NodeResources{Cpus: 4},
+			expectedErr: true,
+		},
+		{
+			desc:        "NodeResources: memory above maximum",
+			resources:   NodeResources{Memory: "1.5g"},
+			expectedErr: true,
+		},
+		{
+			desc:        "NodeResources: invalid memory",
+			resources:   NodeResources{Memory: "12 apples"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			resources, err := GetNodeResources(tt.resources)
+			if tt.expectedErr {
+				if err == nil {
+					t.Fatalf("An error is expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if resources != tt.expectedResources {
+				t.Errorf("Wrong resources: %v != %v", resources, tt.expectedResources)
+			}
+		})
+	}
+
+	memory, err := ParseMemory("1.5g")
+	if err != nil || memory != 3<<29 {
+		t.Errorf("Wrong memory: %d, %v", memory, err)
+	}
+}
@@ -266,7 +266,9 @@ func NewOvsInstance(prjID string) (*OvsProjectInstance, error) {
 	}
 
 	containerName := fmt.Sprintf("%s%s.ovs", options.NETEM_ID, prjID)
-	containerId, err := client.Create(imgName, containerName, "ovs", []string{}, false, false)
+	containerId, err := client.Create(
+		imgName, containerName, "ovs", []string{}, false, false,
+		docker.ContainerResources{}, docker.ContainerOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -145,6 +145,37 @@ func (o *OvsNode) AddInterface(ifName string, ifIndex int, ns netns.NsHandle) er
 	return nil
 }
 
+// RemoveInterface deletes the port ifIndex of the switch, and so
+// the veth pair it belongs to
+func (o *OvsNode) RemoveInterface(ifIndex int) error {
+	ifName := o.GetInterfaceName(ifIndex)
+	if _, found := o.Interfaces[ifName]; !found {
+		return fmt.Errorf("Interface %s.%d not found", o.GetName(), ifIndex)
+	}
+
+	if o.Running {
+		if err := o.OvsInstance.DelPort(o.GetBridgeName(), ifName); err != nil {
+			return err
+		}
+	}
+
+	ns, err := o.GetNetns()
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+
+	// the interface is already deleted if it was the peer of a removed one
+	if link.IsLinkExist(ifName, ns) {
+		if err := link.DeleteLink(ifName, ns); err != nil {
+			return err
+		}
+	}
+	delete(o.Interfaces, ifName)
+
+	return nil
+}
+
 func (o *OvsNode) GetInterfacesState() map[string]link.IfState {
 	ifStates := make(map[string]link.IfState, 0)
 	for ifName, state := range o.Interfaces {
@@ -372,6 +372,107 @@ func (CaptureSrvMsg_Code) EnumDescriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{4, 0}
 }
 
+type LinkProfileRequest_Action int32
+
+const (
+	LinkProfileRequest_START  LinkProfileRequest_Action = 0
+	LinkProfileRequest_PAUSE  LinkProfileRequest_Action = 1
+	LinkProfileRequest_RESUME LinkProfileRequest_Action = 2
+	LinkProfileRequest_STOP   LinkProfileRequest_Action = 3
+)
+
+// Enum value maps for LinkProfileRequest_Action.
+var (
+	LinkProfileRequest_Action_name = map[int32]string{
+		0: "START",
+		1: "PAUSE",
+		2: "RESUME",
+		3: "STOP",
+	}
+	LinkProfileRequest_Action_value = map[string]int32{
+		"START":  0,
+		"PAUSE":  1,
+		"RESUME": 2,
+		"STOP":   3,
+	}
+)
+
+func (x LinkProfileRequest_Action) Enum() *LinkProfileRequest_Action {
+	p := new(LinkProfileRequest_Action)
+	*p = x
+	return p
+}
+
+func (x LinkProfileRequest_Action) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LinkProfileRequest_Action) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_netem_proto_enumTypes[7].Descriptor()
+}
+
+func (LinkProfileRequest_Action) Type() protoreflect.EnumType {
+	return &file_internal_proto_netem_proto_enumTypes[7]
+}
+
+func (x LinkProfileRequest_Action) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LinkProfileRequest_Action.Descriptor instead.
+func (LinkProfileRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{9, 0}
+}
+
+type LinkFlapRequest_Mode int32
+
+const (
+	LinkFlapRequest_STOP     LinkFlapRequest_Mode = 0
+	LinkFlapRequest_PERIODIC LinkFlapRequest_Mode = 1
+	LinkFlapRequest_RANDOM   LinkFlapRequest_Mode = 2
+)
+
+// Enum value maps for LinkFlapRequest_Mode.
+var (
+	LinkFlapRequest_Mode_name = map[int32]string{
+		0: "STOP",
+		1: "PERIODIC",
+		2: "RANDOM",
+	}
+	LinkFlapRequest_Mode_value = map[string]int32{
+		"STOP":     0,
+		"PERIODIC": 1,
+		"RANDOM":   2,
+	}
+)
+
+func (x LinkFlapRequest_Mode) Enum() *LinkFlapRequest_Mode {
+	p := new(LinkFlapRequest_Mode)
+	*p = x
+	return p
+}
+
+func (x LinkFlapRequest_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LinkFlapRequest_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_proto_netem_proto_enumTypes[8].Descriptor()
+}
+
+func (LinkFlapRequest_Mode) Type() protoreflect.EnumType {
+	return &file_internal_proto_netem_proto_enumTypes[8]
+}
+
+func (x LinkFlapRequest_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LinkFlapRequest_Mode.Descriptor instead.
+func (LinkFlapRequest_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{11, 0}
+}
+
 type CopyMsg struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -853,17 +954,20 @@ func (x *NodeInterfaceRequest) GetIfIndex() int32 {
 	return 0
 }
 
-type NodeRequest struct {
+type MoveNodeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PrjId string `protobuf:"bytes,1,opt,name=prjId,proto3" json:"prjId,omitempty"`
-	Node  string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	PrjId    string  `protobuf:"bytes,1,opt,name=prjId,proto3" json:"prjId,omitempty"`
+	Node     string  `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	X        float64 `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y        float64 `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	Duration int32   `protobuf:"varint,5,opt,name=duration,proto3" json:"duration,omitempty"` // ms
 }
 
-func (x *NodeRequest) Reset() {
-	*x = NodeRequest{}
+func (x *MoveNodeRequest) Reset() {
+	*x = MoveNodeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -871,13 +975,13 @@ func (x *NodeRequest) Reset() {
 	}
 }
 
-func (x *NodeRequest) String() string {
+func (x *MoveNodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NodeRequest) ProtoMessage() {}
+func (*MoveNodeRequest) ProtoMessage() {}
 
-func (x *NodeRequest) ProtoReflect() protoreflect.Message {
+func (x *MoveNodeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -889,35 +993,59 @@ func (x *NodeRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NodeRequest.ProtoReflect.Descriptor instead.
-func (*NodeRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MoveNodeRequest.ProtoReflect.Descriptor instead.
+func (*MoveNodeRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *NodeRequest) GetPrjId() string {
+func (x *MoveNodeRequest) GetPrjId() string {
 	if x != nil {
 		return x.PrjId
 	}
 	return ""
 }
 
-func (x *NodeRequest) GetNode() string {
+func (x *MoveNodeRequest) GetNode() string {
 	if x != nil {
 		return x.Node
 	}
 	return ""
 }
 
-type ProjectRequest struct {
+func (x *MoveNodeRequest) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *MoveNodeRequest) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *MoveNodeRequest) GetDuration() int32 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+type LinkParamsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PrjId   string            `protobuf:"bytes,1,opt,name=prjId,proto3" json:"prjId,omitempty"`
+	Node    string            `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	IfIndex int32             `protobuf:"varint,3,opt,name=ifIndex,proto3" json:"ifIndex,omitempty"`
+	Params  map[string]string `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
-func (x *ProjectRequest) Reset() {
-	*x = ProjectRequest{}
+func (x *LinkParamsRequest) Reset() {
+	*x = LinkParamsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -925,13 +1053,13 @@ func (x *ProjectRequest) Reset() {
 	}
 }
 
-func (x *ProjectRequest) String() string {
+func (x *LinkParamsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProjectRequest) ProtoMessage() {}
+func (*LinkParamsRequest) ProtoMessage() {}
 
-func (x *ProjectRequest) ProtoReflect() protoreflect.Message {
+func (x *LinkParamsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -943,29 +1071,54 @@ func (x *ProjectRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProjectRequest.ProtoReflect.Descriptor instead.
-func (*ProjectRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LinkParamsRequest.ProtoReflect.Descriptor instead.
+func (*LinkParamsRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ProjectRequest) GetId() string {
+func (x *LinkParamsRequest) GetPrjId() string {
 	if x != nil {
-		return x.Id
+		return x.PrjId
 	}
 	return ""
 }
 
-type WNetworkRequest struct {
+func (x *LinkParamsRequest) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+func (x *LinkParamsRequest) GetIfIndex() int32 {
+	if x != nil {
+		return x.IfIndex
+	}
+	return 0
+}
+
+func (x *LinkParamsRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+type LinkProfileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	PrjId   string                    `protobuf:"bytes,1,opt,name=prjId,proto3" json:"prjId,omitempty"`
+	Node    string                    `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	IfIndex int32                     `protobuf:"varint,3,opt,name=ifIndex,proto3" json:"ifIndex,omitempty"`
+	Action  LinkProfileRequest_Action `protobuf:"varint,4,opt,name=action,proto3,enum=netem.LinkProfileRequest_Action" json:"action,omitempty"`
+	Profile string                    `protobuf:"bytes,5,opt,name=profile,proto3" json:"profile,omitempty"`
+	Loop    bool                      `protobuf:"varint,6,opt,name=loop,proto3" json:"loop,omitempty"`
 }
 
-func (x *WNetworkRequest) Reset() {
-	*x = WNetworkRequest{}
+func (x *LinkProfileRequest) Reset() {
+	*x = LinkProfileRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -973,13 +1126,13 @@ func (x *WNetworkRequest) Reset() {
 	}
 }
 
-func (x *WNetworkRequest) String() string {
+func (x *LinkProfileRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*WNetworkRequest) ProtoMessage() {}
+func (*LinkProfileRequest) ProtoMessage() {}
 
-func (x *WNetworkRequest) ProtoReflect() protoreflect.Message {
+func (x *LinkProfileRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -991,36 +1144,66 @@ func (x *WNetworkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use WNetworkRequest.ProtoReflect.Descriptor instead.
-func (*WNetworkRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LinkProfileRequest.ProtoReflect.Descriptor instead.
+func (*LinkProfileRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *WNetworkRequest) GetId() string {
+func (x *LinkProfileRequest) GetPrjId() string {
 	if x != nil {
-		return x.Id
+		return x.PrjId
 	}
 	return ""
 }
 
-func (x *WNetworkRequest) GetData() []byte {
+func (x *LinkProfileRequest) GetNode() string {
 	if x != nil {
-		return x.Data
+		return x.Node
 	}
-	return nil
+	return ""
 }
 
-type OpenRequest struct {
+func (x *LinkProfileRequest) GetIfIndex() int32 {
+	if x != nil {
+		return x.IfIndex
+	}
+	return 0
+}
+
+func (x *LinkProfileRequest) GetAction() LinkProfileRequest_Action {
+	if x != nil {
+		return x.Action
+	}
+	return LinkProfileRequest_START
+}
+
+func (x *LinkProfileRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
+func (x *LinkProfileRequest) GetLoop() bool {
+	if x != nil {
+		return x.Loop
+	}
+	return false
+}
+
+type LinkStateRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	PrjId string  `protobuf:"bytes,1,opt,name=prjId,proto3" json:"prjId,omitempty"`
+	Peer1 string  `protobuf:"bytes,2,opt,name=peer1,proto3" json:"peer1,omitempty"`
+	Peer2 string  `protobuf:"bytes,3,opt,name=peer2,proto3" json:"peer2,omitempty"`
+	State IfState `protobuf:"varint,4,opt,name=state,proto3,enum=netem.IfState" json:"state,omitempty"`
 }
 
-func (x *OpenRequest) Reset() {
-	*x = OpenRequest{}
+func (x *LinkStateRequest) Reset() {
+	*x = LinkStateRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1028,13 +1211,13 @@ func (x *OpenRequest) Reset() {
 	}
 }
 
-func (x *OpenRequest) String() string {
+func (x *LinkStateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OpenRequest) ProtoMessage() {}
+func (*LinkStateRequest) ProtoMessage() {}
 
-func (x *OpenRequest) ProtoReflect() protoreflect.Message {
+func (x *LinkStateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1046,36 +1229,55 @@ func (x *OpenRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OpenRequest.ProtoReflect.Descriptor instead.
-func (*OpenRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use LinkStateRequest.ProtoReflect.Descriptor instead.
+func (*LinkStateRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *OpenRequest) GetName() string {
+func (x *LinkStateRequest) GetPrjId() string {
 	if x != nil {
-		return x.Name
+		return x.PrjId
 	}
 	return ""
 }
 
-func (x *OpenRequest) GetData() []byte {
+func (x *LinkStateRequest) GetPeer1() string {
 	if x != nil {
-		return x.Data
+		return x.Peer1
 	}
-	return nil
+	return ""
 }
 
-type Status struct {
+func (x *LinkStateRequest) GetPeer2() string {
+	if x != nil {
+		return x.Peer2
+	}
+	return ""
+}
+
+func (x *LinkStateRequest) GetState() IfState {
+	if x != nil {
+		return x.State
+	}
+	return IfState_UP
+}
+
+type LinkFlapRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Code  StatusCode `protobuf:"varint,1,opt,name=code,proto3,enum=netem.StatusCode" json:"code,omitempty"`
-	Error string     `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	PrjId string               `protobuf:"bytes,1,opt,name=prjId,proto3" json:"prjId,omitempty"`
+	Peer1 string               `protobuf:"bytes,2,opt,name=peer1,proto3" json:"peer1,omitempty"`
+	Peer2 string               `protobuf:"bytes,3,opt,name=peer2,proto3" json:"peer2,omitempty"`
+	Mode  LinkFlapRequest_Mode `protobuf:"varint,4,opt,name=mode,proto3,enum=netem.LinkFlapRequest_Mode" json:"mode,omitempty"`
+	// in ms, mean times with RANDOM mode
+	Up   int32 `protobuf:"varint,5,opt,name=up,proto3" json:"up,omitempty"`
+	Down int32 `protobuf:"varint,6,opt,name=down,proto3" json:"down,omitempty"`
 }
 
-func (x *Status) Reset() {
-	*x = Status{}
+func (x *LinkFlapRequest) Reset() {
+	*x = LinkFlapRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1083,13 +1285,13 @@ func (x *Status) Reset() {
 	}
 }
 
-func (x *Status) String() string {
+func (x *LinkFlapRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Status) ProtoMessage() {}
+func (*LinkFlapRequest) ProtoMessage() {}
 
-func (x *Status) ProtoReflect() protoreflect.Message {
+func (x *LinkFlapRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1101,35 +1303,64 @@ func (x *Status) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Status.ProtoReflect.Descriptor instead.
-func (*Status) Descriptor() ([]byte, []int) {
+// Deprecated: Use LinkFlapRequest.ProtoReflect.Descriptor instead.
+func (*LinkFlapRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Status) GetCode() StatusCode {
+func (x *LinkFlapRequest) GetPrjId() string {
 	if x != nil {
-		return x.Code
+		return x.PrjId
 	}
-	return StatusCode_OK
+	return ""
 }
 
-func (x *Status) GetError() string {
+func (x *LinkFlapRequest) GetPeer1() string {
 	if x != nil {
-		return x.Error
+		return x.Peer1
 	}
 	return ""
 }
 
-type AckResponse struct {
+func (x *LinkFlapRequest) GetPeer2() string {
+	if x != nil {
+		return x.Peer2
+	}
+	return ""
+}
+
+func (x *LinkFlapRequest) GetMode() LinkFlapRequest_Mode {
+	if x != nil {
+		return x.Mode
+	}
+	return LinkFlapRequest_STOP
+}
+
+func (x *LinkFlapRequest) GetUp() int32 {
+	if x != nil {
+		return x.Up
+	}
+	return 0
+}
+
+func (x *LinkFlapRequest) GetDown() int32 {
+	if x != nil {
+		return x.Down
+	}
+	return 0
+}
+
+type NodeRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	PrjId string `protobuf:"bytes,1,opt,name=prjId,proto3" json:"prjId,omitempty"`
+	Node  string `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
 }
 
-func (x *AckResponse) Reset() {
-	*x = AckResponse{}
+func (x *NodeRequest) Reset() {
+	*x = NodeRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1137,13 +1368,13 @@ func (x *AckResponse) Reset() {
 	}
 }
 
-func (x *AckResponse) String() string {
+func (x *NodeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AckResponse) ProtoMessage() {}
+func (*NodeRequest) ProtoMessage() {}
 
-func (x *AckResponse) ProtoReflect() protoreflect.Message {
+func (x *NodeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1155,29 +1386,35 @@ func (x *AckResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AckResponse.ProtoReflect.Descriptor instead.
-func (*AckResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use NodeRequest.ProtoReflect.Descriptor instead.
+func (*NodeRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *AckResponse) GetStatus() *Status {
+func (x *NodeRequest) GetPrjId() string {
 	if x != nil {
-		return x.Status
+		return x.PrjId
 	}
-	return nil
+	return ""
 }
 
-type RunResponse struct {
+func (x *NodeRequest) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
+type ProjectRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status       *Status                     `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	NodeMessages []*RunResponse_NodeMessages `protobuf:"bytes,2,rep,name=nodeMessages,proto3" json:"nodeMessages,omitempty"`
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *RunResponse) Reset() {
-	*x = RunResponse{}
+func (x *ProjectRequest) Reset() {
+	*x = ProjectRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1185,13 +1422,13 @@ func (x *RunResponse) Reset() {
 	}
 }
 
-func (x *RunResponse) String() string {
+func (x *ProjectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunResponse) ProtoMessage() {}
+func (*ProjectRequest) ProtoMessage() {}
 
-func (x *RunResponse) ProtoReflect() protoreflect.Message {
+func (x *ProjectRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1203,36 +1440,29 @@ func (x *RunResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunResponse.ProtoReflect.Descriptor instead.
-func (*RunResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProjectRequest.ProtoReflect.Descriptor instead.
+func (*ProjectRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *RunResponse) GetStatus() *Status {
-	if x != nil {
-		return x.Status
-	}
-	return nil
-}
-
-func (x *RunResponse) GetNodeMessages() []*RunResponse_NodeMessages {
+func (x *ProjectRequest) GetId() string {
 	if x != nil {
-		return x.NodeMessages
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type FileResponse struct {
+type WNetworkRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Data   []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *FileResponse) Reset() {
-	*x = FileResponse{}
+func (x *WNetworkRequest) Reset() {
+	*x = WNetworkRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1240,13 +1470,13 @@ func (x *FileResponse) Reset() {
 	}
 }
 
-func (x *FileResponse) String() string {
+func (x *WNetworkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FileResponse) ProtoMessage() {}
+func (*WNetworkRequest) ProtoMessage() {}
 
-func (x *FileResponse) ProtoReflect() protoreflect.Message {
+func (x *WNetworkRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1258,36 +1488,36 @@ func (x *FileResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FileResponse.ProtoReflect.Descriptor instead.
-func (*FileResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use WNetworkRequest.ProtoReflect.Descriptor instead.
+func (*WNetworkRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *FileResponse) GetStatus() *Status {
+func (x *WNetworkRequest) GetId() string {
 	if x != nil {
-		return x.Status
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *FileResponse) GetData() []byte {
+func (x *WNetworkRequest) GetData() []byte {
 	if x != nil {
 		return x.Data
 	}
 	return nil
 }
 
-type VersionResponse struct {
+type OpenRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status  *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Version string  `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *VersionResponse) Reset() {
-	*x = VersionResponse{}
+func (x *OpenRequest) Reset() {
+	*x = OpenRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1295,13 +1525,13 @@ func (x *VersionResponse) Reset() {
 	}
 }
 
-func (x *VersionResponse) String() string {
+func (x *OpenRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VersionResponse) ProtoMessage() {}
+func (*OpenRequest) ProtoMessage() {}
 
-func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+func (x *OpenRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1313,40 +1543,36 @@ func (x *VersionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
-func (*VersionResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use OpenRequest.ProtoReflect.Descriptor instead.
+func (*OpenRequest) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *VersionResponse) GetStatus() *Status {
+func (x *OpenRequest) GetName() string {
 	if x != nil {
-		return x.Status
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *VersionResponse) GetVersion() string {
+func (x *OpenRequest) GetData() []byte {
 	if x != nil {
-		return x.Version
+		return x.Data
 	}
-	return ""
+	return nil
 }
 
-type StatusResponse struct {
+type Status struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status  *Status                      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Name    string                       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Id      string                       `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
-	OpenAt  string                       `protobuf:"bytes,4,opt,name=openAt,proto3" json:"openAt,omitempty"`
-	Running bool                         `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
-	Nodes   []*StatusResponse_NodeStatus `protobuf:"bytes,10,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Code  StatusCode `protobuf:"varint,1,opt,name=code,proto3,enum=netem.StatusCode" json:"code,omitempty"`
+	Error string     `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *StatusResponse) Reset() {
-	*x = StatusResponse{}
+func (x *Status) Reset() {
+	*x = Status{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_internal_proto_netem_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1354,13 +1580,13 @@ func (x *StatusResponse) Reset() {
 	}
 }
 
-func (x *StatusResponse) String() string {
+func (x *Status) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse) ProtoMessage() {}
+func (*Status) ProtoMessage() {}
 
-func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+func (x *Status) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_proto_netem_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1372,79 +1598,99 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
-func (*StatusResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
 	return file_internal_proto_netem_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *StatusResponse) GetStatus() *Status {
+func (x *Status) GetCode() StatusCode {
 	if x != nil {
-		return x.Status
+		return x.Code
 	}
-	return nil
+	return StatusCode_OK
 }
 
-func (x *StatusResponse) GetName() string {
+func (x *Status) GetError() string {
 	if x != nil {
-		return x.Name
+		return x.Error
 	}
 	return ""
 }
 
-func (x *StatusResponse) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+type AckResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 }
 
-func (x *StatusResponse) GetOpenAt() string {
-	if x != nil {
-		return x.OpenAt
+func (x *AckResponse) Reset() {
+	*x = AckResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *StatusResponse) GetRunning() bool {
-	if x != nil {
-		return x.Running
+func (x *AckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckResponse) ProtoMessage() {}
+
+func (x *AckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *StatusResponse) GetNodes() []*StatusResponse_NodeStatus {
+// Deprecated: Use AckResponse.ProtoReflect.Descriptor instead.
+func (*AckResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AckResponse) GetStatus() *Status {
 	if x != nil {
-		return x.Nodes
+		return x.Status
 	}
 	return nil
 }
 
-type PrjListResponse struct {
+type RunResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status   *Status                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Projects []*PrjListResponse_Info `protobuf:"bytes,2,rep,name=projects,proto3" json:"projects,omitempty"`
+	Status       *Status                     `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	NodeMessages []*RunResponse_NodeMessages `protobuf:"bytes,2,rep,name=nodeMessages,proto3" json:"nodeMessages,omitempty"`
+	Changes      []*RunResponse_Change       `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
 }
 
-func (x *PrjListResponse) Reset() {
-	*x = PrjListResponse{}
+func (x *RunResponse) Reset() {
+	*x = RunResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_netem_proto_msgTypes[17]
+		mi := &file_internal_proto_netem_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PrjListResponse) String() string {
+func (x *RunResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrjListResponse) ProtoMessage() {}
+func (*RunResponse) ProtoMessage() {}
 
-func (x *PrjListResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_netem_proto_msgTypes[17]
+func (x *RunResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1455,51 +1701,59 @@ func (x *PrjListResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PrjListResponse.ProtoReflect.Descriptor instead.
-func (*PrjListResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_netem_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use RunResponse.ProtoReflect.Descriptor instead.
+func (*RunResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *PrjListResponse) GetStatus() *Status {
+func (x *RunResponse) GetStatus() *Status {
 	if x != nil {
 		return x.Status
 	}
 	return nil
 }
 
-func (x *PrjListResponse) GetProjects() []*PrjListResponse_Info {
+func (x *RunResponse) GetNodeMessages() []*RunResponse_NodeMessages {
 	if x != nil {
-		return x.Projects
+		return x.NodeMessages
 	}
 	return nil
 }
 
-type PrjOpenResponse struct {
+func (x *RunResponse) GetChanges() []*RunResponse_Change {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+type LintResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Id     string  `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	// problems found in the topology and the configuration files
+	Issues []string `protobuf:"bytes,2,rep,name=issues,proto3" json:"issues,omitempty"`
 }
 
-func (x *PrjOpenResponse) Reset() {
-	*x = PrjOpenResponse{}
+func (x *LintResponse) Reset() {
+	*x = LintResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_netem_proto_msgTypes[18]
+		mi := &file_internal_proto_netem_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PrjOpenResponse) String() string {
+func (x *LintResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PrjOpenResponse) ProtoMessage() {}
+func (*LintResponse) ProtoMessage() {}
 
-func (x *PrjOpenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_netem_proto_msgTypes[18]
+func (x *LintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1510,51 +1764,51 @@ func (x *PrjOpenResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PrjOpenResponse.ProtoReflect.Descriptor instead.
-func (*PrjOpenResponse) Descriptor() ([]byte, []int) {
-	return file_internal_proto_netem_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use LintResponse.ProtoReflect.Descriptor instead.
+func (*LintResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *PrjOpenResponse) GetStatus() *Status {
+func (x *LintResponse) GetStatus() *Status {
 	if x != nil {
 		return x.Status
 	}
 	return nil
 }
 
-func (x *PrjOpenResponse) GetId() string {
+func (x *LintResponse) GetIssues() []string {
 	if x != nil {
-		return x.Id
+		return x.Issues
 	}
-	return ""
+	return nil
 }
 
-type RunResponse_NodeMessages struct {
+type ExportRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name     string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Messages []string `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	PrjId  string `protobuf:"bytes,1,opt,name=prjId,proto3" json:"prjId,omitempty"`
+	Format string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // dot, svg or json
 }
 
-func (x *RunResponse_NodeMessages) Reset() {
-	*x = RunResponse_NodeMessages{}
+func (x *ExportRequest) Reset() {
+	*x = ExportRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_netem_proto_msgTypes[19]
+		mi := &file_internal_proto_netem_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RunResponse_NodeMessages) String() string {
+func (x *ExportRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunResponse_NodeMessages) ProtoMessage() {}
+func (*ExportRequest) ProtoMessage() {}
 
-func (x *RunResponse_NodeMessages) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_netem_proto_msgTypes[19]
+func (x *ExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1565,107 +1819,852 @@ func (x *RunResponse_NodeMessages) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunResponse_NodeMessages.ProtoReflect.Descriptor instead.
-func (*RunResponse_NodeMessages) Descriptor() ([]byte, []int) {
-	return file_internal_proto_netem_proto_rawDescGZIP(), []int{13, 0}
+// Deprecated: Use ExportRequest.ProtoReflect.Descriptor instead.
+func (*ExportRequest) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *RunResponse_NodeMessages) GetName() string {
+func (x *ExportRequest) GetPrjId() string {
 	if x != nil {
-		return x.Name
+		return x.PrjId
 	}
 	return ""
 }
 
-func (x *RunResponse_NodeMessages) GetMessages() []string {
+func (x *ExportRequest) GetFormat() string {
 	if x != nil {
-		return x.Messages
+		return x.Format
 	}
-	return nil
+	return ""
 }
 
-type StatusResponse_IfStatus struct {
+type FileResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	State IfState `protobuf:"varint,2,opt,name=state,proto3,enum=netem.IfState" json:"state,omitempty"`
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Data   []byte  `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *StatusResponse_IfStatus) Reset() {
-	*x = StatusResponse_IfStatus{}
+func (x *FileResponse) Reset() {
+	*x = FileResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_netem_proto_msgTypes[20]
+		mi := &file_internal_proto_netem_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatusResponse_IfStatus) String() string {
+func (x *FileResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse_IfStatus) ProtoMessage() {}
+func (*FileResponse) ProtoMessage() {}
 
-func (x *StatusResponse_IfStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_netem_proto_msgTypes[20]
-	if protoimpl.UnsafeEnabled && x != nil {
+func (x *FileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileResponse.ProtoReflect.Descriptor instead.
+func (*FileResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FileResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *FileResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type VersionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Version string  `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *VersionResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *VersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type StatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  *Status                      `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Name    string                       `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Id      string                       `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	OpenAt  string                       `protobuf:"bytes,4,opt,name=openAt,proto3" json:"openAt,omitempty"`
+	Running bool                         `protobuf:"varint,5,opt,name=running,proto3" json:"running,omitempty"`
+	Nodes   []*StatusResponse_NodeStatus `protobuf:"bytes,10,rep,name=nodes,proto3" json:"nodes,omitempty"`
+}
+
+func (x *StatusResponse) Reset() {
+	*x = StatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse) ProtoMessage() {}
+
+func (x *StatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
+func (*StatusResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StatusResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *StatusResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetOpenAt() string {
+	if x != nil {
+		return x.OpenAt
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *StatusResponse) GetNodes() []*StatusResponse_NodeStatus {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type PrjListResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   *Status                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Projects []*PrjListResponse_Info `protobuf:"bytes,2,rep,name=projects,proto3" json:"projects,omitempty"`
+}
+
+func (x *PrjListResponse) Reset() {
+	*x = PrjListResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrjListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrjListResponse) ProtoMessage() {}
+
+func (x *PrjListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrjListResponse.ProtoReflect.Descriptor instead.
+func (*PrjListResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PrjListResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *PrjListResponse) GetProjects() []*PrjListResponse_Info {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type LinkStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	// time of the poll, in ns since epoch
+	Timestamp  int64                        `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Interfaces []*LinkStatsResponse_IfStats `protobuf:"bytes,3,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+}
+
+func (x *LinkStatsResponse) Reset() {
+	*x = LinkStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkStatsResponse) ProtoMessage() {}
+
+func (x *LinkStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkStatsResponse.ProtoReflect.Descriptor instead.
+func (*LinkStatsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LinkStatsResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *LinkStatsResponse) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *LinkStatsResponse) GetInterfaces() []*LinkStatsResponse_IfStats {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+type PrjOpenResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status *Status `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Id     string  `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *PrjOpenResponse) Reset() {
+	*x = PrjOpenResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrjOpenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrjOpenResponse) ProtoMessage() {}
+
+func (x *PrjOpenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrjOpenResponse.ProtoReflect.Descriptor instead.
+func (*PrjOpenResponse) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PrjOpenResponse) GetStatus() *Status {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *PrjOpenResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RunResponse_NodeMessages struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Messages []string `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *RunResponse_NodeMessages) Reset() {
+	*x = RunResponse_NodeMessages{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunResponse_NodeMessages) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunResponse_NodeMessages) ProtoMessage() {}
+
+func (x *RunResponse_NodeMessages) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunResponse_NodeMessages.ProtoReflect.Descriptor instead.
+func (*RunResponse_NodeMessages) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{18, 0}
+}
+
+func (x *RunResponse_NodeMessages) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RunResponse_NodeMessages) GetMessages() []string {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+// element of the topology modified by a reload
+type RunResponse_Change struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"` // added, removed or modified
+	Kind   string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`     // node, link, bridge or segment
+	Name   string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RunResponse_Change) Reset() {
+	*x = RunResponse_Change{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunResponse_Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunResponse_Change) ProtoMessage() {}
+
+func (x *RunResponse_Change) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunResponse_Change.ProtoReflect.Descriptor instead.
+func (*RunResponse_Change) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{18, 1}
+}
+
+func (x *RunResponse_Change) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *RunResponse_Change) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *RunResponse_Change) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type StatusResponse_IfStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	State IfState `protobuf:"varint,2,opt,name=state,proto3,enum=netem.IfState" json:"state,omitempty"`
+}
+
+func (x *StatusResponse_IfStatus) Reset() {
+	*x = StatusResponse_IfStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_IfStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_IfStatus) ProtoMessage() {}
+
+func (x *StatusResponse_IfStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_IfStatus.ProtoReflect.Descriptor instead.
+func (*StatusResponse_IfStatus) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{23, 0}
+}
+
+func (x *StatusResponse_IfStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StatusResponse_IfStatus) GetState() IfState {
+	if x != nil {
+		return x.State
+	}
+	return IfState_UP
+}
+
+type StatusResponse_NodeStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string                     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Running    bool                       `protobuf:"varint,2,opt,name=running,proto3" json:"running,omitempty"`
+	Interfaces []*StatusResponse_IfStatus `protobuf:"bytes,10,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+}
+
+func (x *StatusResponse_NodeStatus) Reset() {
+	*x = StatusResponse_NodeStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResponse_NodeStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResponse_NodeStatus) ProtoMessage() {}
+
+func (x *StatusResponse_NodeStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResponse_NodeStatus.ProtoReflect.Descriptor instead.
+func (*StatusResponse_NodeStatus) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{23, 1}
+}
+
+func (x *StatusResponse_NodeStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StatusResponse_NodeStatus) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *StatusResponse_NodeStatus) GetInterfaces() []*StatusResponse_IfStatus {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+type PrjListResponse_Info struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	OpenAt string `protobuf:"bytes,3,opt,name=openAt,proto3" json:"openAt,omitempty"`
+}
+
+func (x *PrjListResponse_Info) Reset() {
+	*x = PrjListResponse_Info{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrjListResponse_Info) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrjListResponse_Info) ProtoMessage() {}
+
+func (x *PrjListResponse_Info) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrjListResponse_Info.ProtoReflect.Descriptor instead.
+func (*PrjListResponse_Info) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{24, 0}
+}
+
+func (x *PrjListResponse_Info) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PrjListResponse_Info) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PrjListResponse_Info) GetOpenAt() string {
+	if x != nil {
+		return x.OpenAt
+	}
+	return ""
+}
+
+type LinkStatsResponse_QdiscStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind       string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Handle     string `protobuf:"bytes,2,opt,name=handle,proto3" json:"handle,omitempty"`
+	Parent     string `protobuf:"bytes,3,opt,name=parent,proto3" json:"parent,omitempty"`
+	Bytes      uint64 `protobuf:"varint,4,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Packets    uint32 `protobuf:"varint,5,opt,name=packets,proto3" json:"packets,omitempty"`
+	Backlog    uint32 `protobuf:"varint,6,opt,name=backlog,proto3" json:"backlog,omitempty"`
+	Qlen       uint32 `protobuf:"varint,7,opt,name=qlen,proto3" json:"qlen,omitempty"`
+	Drops      uint32 `protobuf:"varint,8,opt,name=drops,proto3" json:"drops,omitempty"`
+	Overlimits uint32 `protobuf:"varint,9,opt,name=overlimits,proto3" json:"overlimits,omitempty"`
+	Requeues   uint32 `protobuf:"varint,10,opt,name=requeues,proto3" json:"requeues,omitempty"`
+}
+
+func (x *LinkStatsResponse_QdiscStats) Reset() {
+	*x = LinkStatsResponse_QdiscStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_proto_netem_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LinkStatsResponse_QdiscStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkStatsResponse_QdiscStats) ProtoMessage() {}
+
+func (x *LinkStatsResponse_QdiscStats) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkStatsResponse_QdiscStats.ProtoReflect.Descriptor instead.
+func (*LinkStatsResponse_QdiscStats) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{25, 0}
+}
+
+func (x *LinkStatsResponse_QdiscStats) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *LinkStatsResponse_QdiscStats) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *LinkStatsResponse_QdiscStats) GetParent() string {
+	if x != nil {
+		return x.Parent
+	}
+	return ""
+}
+
+func (x *LinkStatsResponse_QdiscStats) GetBytes() uint64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+func (x *LinkStatsResponse_QdiscStats) GetPackets() uint32 {
+	if x != nil {
+		return x.Packets
+	}
+	return 0
+}
+
+func (x *LinkStatsResponse_QdiscStats) GetBacklog() uint32 {
+	if x != nil {
+		return x.Backlog
+	}
+	return 0
 }
 
-// Deprecated: Use StatusResponse_IfStatus.ProtoReflect.Descriptor instead.
-func (*StatusResponse_IfStatus) Descriptor() ([]byte, []int) {
-	return file_internal_proto_netem_proto_rawDescGZIP(), []int{16, 0}
+func (x *LinkStatsResponse_QdiscStats) GetQlen() uint32 {
+	if x != nil {
+		return x.Qlen
+	}
+	return 0
 }
 
-func (x *StatusResponse_IfStatus) GetName() string {
+func (x *LinkStatsResponse_QdiscStats) GetDrops() uint32 {
 	if x != nil {
-		return x.Name
+		return x.Drops
 	}
-	return ""
+	return 0
 }
 
-func (x *StatusResponse_IfStatus) GetState() IfState {
+func (x *LinkStatsResponse_QdiscStats) GetOverlimits() uint32 {
 	if x != nil {
-		return x.State
+		return x.Overlimits
 	}
-	return IfState_UP
+	return 0
 }
 
-type StatusResponse_NodeStatus struct {
+func (x *LinkStatsResponse_QdiscStats) GetRequeues() uint32 {
+	if x != nil {
+		return x.Requeues
+	}
+	return 0
+}
+
+type LinkStatsResponse_IfStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name       string                     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Running    bool                       `protobuf:"varint,2,opt,name=running,proto3" json:"running,omitempty"`
-	Interfaces []*StatusResponse_IfStatus `protobuf:"bytes,10,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
-}
-
-func (x *StatusResponse_NodeStatus) Reset() {
-	*x = StatusResponse_NodeStatus{}
+	Node    string `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	IfIndex int32  `protobuf:"varint,2,opt,name=ifIndex,proto3" json:"ifIndex,omitempty"`
+	// other peer of the link or bridge name
+	Remote    string                          `protobuf:"bytes,3,opt,name=remote,proto3" json:"remote,omitempty"`
+	RxBytes   uint64                          `protobuf:"varint,4,opt,name=rxBytes,proto3" json:"rxBytes,omitempty"`
+	RxPackets uint64                          `protobuf:"varint,5,opt,name=rxPackets,proto3" json:"rxPackets,omitempty"`
+	RxErrors  uint64                          `protobuf:"varint,6,opt,name=rxErrors,proto3" json:"rxErrors,omitempty"`
+	RxDropped uint64                          `protobuf:"varint,7,opt,name=rxDropped,proto3" json:"rxDropped,omitempty"`
+	TxBytes   uint64                          `protobuf:"varint,8,opt,name=txBytes,proto3" json:"txBytes,omitempty"`
+	TxPackets uint64                          `protobuf:"varint,9,opt,name=txPackets,proto3" json:"txPackets,omitempty"`
+	TxErrors  uint64                          `protobuf:"varint,10,opt,name=txErrors,proto3" json:"txErrors,omitempty"`
+	TxDropped uint64                          `protobuf:"varint,11,opt,name=txDropped,proto3" json:"txDropped,omitempty"`
+	Qdiscs    []*LinkStatsResponse_QdiscStats `protobuf:"bytes,12,rep,name=qdiscs,proto3" json:"qdiscs,omitempty"`
+}
+
+func (x *LinkStatsResponse_IfStats) Reset() {
+	*x = LinkStatsResponse_IfStats{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_netem_proto_msgTypes[21]
+		mi := &file_internal_proto_netem_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StatusResponse_NodeStatus) String() string {
+func (x *LinkStatsResponse_IfStats) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StatusResponse_NodeStatus) ProtoMessage() {}
+func (*LinkStatsResponse_IfStats) ProtoMessage() {}
 
-func (x *StatusResponse_NodeStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_netem_proto_msgTypes[21]
+func (x *LinkStatsResponse_IfStats) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_proto_netem_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1676,93 +2675,93 @@ func (x *StatusResponse_NodeStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StatusResponse_NodeStatus.ProtoReflect.Descriptor instead.
-func (*StatusResponse_NodeStatus) Descriptor() ([]byte, []int) {
-	return file_internal_proto_netem_proto_rawDescGZIP(), []int{16, 1}
+// Deprecated: Use LinkStatsResponse_IfStats.ProtoReflect.Descriptor instead.
+func (*LinkStatsResponse_IfStats) Descriptor() ([]byte, []int) {
+	return file_internal_proto_netem_proto_rawDescGZIP(), []int{25, 1}
 }
 
-func (x *StatusResponse_NodeStatus) GetName() string {
+func (x *LinkStatsResponse_IfStats) GetNode() string {
 	if x != nil {
-		return x.Name
+		return x.Node
 	}
 	return ""
 }
 
-func (x *StatusResponse_NodeStatus) GetRunning() bool {
+func (x *LinkStatsResponse_IfStats) GetIfIndex() int32 {
 	if x != nil {
-		return x.Running
+		return x.IfIndex
 	}
-	return false
+	return 0
 }
 
-func (x *StatusResponse_NodeStatus) GetInterfaces() []*StatusResponse_IfStatus {
+func (x *LinkStatsResponse_IfStats) GetRemote() string {
 	if x != nil {
-		return x.Interfaces
+		return x.Remote
 	}
-	return nil
+	return ""
 }
 
-type PrjListResponse_Info struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name   string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	OpenAt string `protobuf:"bytes,3,opt,name=openAt,proto3" json:"openAt,omitempty"`
+func (x *LinkStatsResponse_IfStats) GetRxBytes() uint64 {
+	if x != nil {
+		return x.RxBytes
+	}
+	return 0
 }
 
-func (x *PrjListResponse_Info) Reset() {
-	*x = PrjListResponse_Info{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_proto_netem_proto_msgTypes[22]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *LinkStatsResponse_IfStats) GetRxPackets() uint64 {
+	if x != nil {
+		return x.RxPackets
 	}
+	return 0
 }
 
-func (x *PrjListResponse_Info) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *LinkStatsResponse_IfStats) GetRxErrors() uint64 {
+	if x != nil {
+		return x.RxErrors
+	}
+	return 0
 }
 
-func (*PrjListResponse_Info) ProtoMessage() {}
+func (x *LinkStatsResponse_IfStats) GetRxDropped() uint64 {
+	if x != nil {
+		return x.RxDropped
+	}
+	return 0
+}
 
-func (x *PrjListResponse_Info) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_proto_netem_proto_msgTypes[22]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *LinkStatsResponse_IfStats) GetTxBytes() uint64 {
+	if x != nil {
+		return x.TxBytes
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use PrjListResponse_Info.ProtoReflect.Descriptor instead.
-func (*PrjListResponse_Info) Descriptor() ([]byte, []int) {
-	return file_internal_proto_netem_proto_rawDescGZIP(), []int{17, 0}
+func (x *LinkStatsResponse_IfStats) GetTxPackets() uint64 {
+	if x != nil {
+		return x.TxPackets
+	}
+	return 0
 }
 
-func (x *PrjListResponse_Info) GetId() string {
+func (x *LinkStatsResponse_IfStats) GetTxErrors() uint64 {
 	if x != nil {
-		return x.Id
+		return x.TxErrors
 	}
-	return ""
+	return 0
 }
 
-func (x *PrjListResponse_Info) GetName() string {
+func (x *LinkStatsResponse_IfStats) GetTxDropped() uint64 {
 	if x != nil {
-		return x.Name
+		return x.TxDropped
 	}
-	return ""
+	return 0
 }
 
-func (x *PrjListResponse_Info) GetOpenAt() string {
+func (x *LinkStatsResponse_IfStats) GetQdiscs() []*LinkStatsResponse_QdiscStats {
 	if x != nil {
-		return x.OpenAt
+		return x.Qdiscs
 	}
-	return ""
+	return nil
 }
 
 var File_internal_proto_netem_proto protoreflect.FileDescriptor
@@ -1839,143 +2838,298 @@ var file_internal_proto_netem_proto_rawDesc = []byte{
 	0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02,
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x66,
 	0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x69, 0x66, 0x49,
-	0x6e, 0x64, 0x65, 0x78, 0x22, 0x37, 0x0a, 0x0b, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x6e, 0x64, 0x65, 0x78, 0x22, 0x73, 0x0a, 0x0f, 0x4d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64,
+	0x65, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12,
+	0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x1a, 0x0a,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xd0, 0x01, 0x0a, 0x11, 0x4c, 0x69,
+	0x6e, 0x6b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x70, 0x72, 0x6a, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x66, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x69, 0x66, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x3c, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x69, 0x6e, 0x6b,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf6, 0x01, 0x0a,
+	0x12, 0x4c, 0x69, 0x6e, 0x6b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x22, 0x20, 0x0a,
-	0x0e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0x35, 0x0a, 0x0f, 0x57, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x35, 0x0a, 0x0b, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x45, 0x0a,
-	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
-	0x72, 0x72, 0x6f, 0x72, 0x22, 0x34, 0x0a, 0x0b, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xb9, 0x01, 0x0a, 0x0b, 0x52,
-	0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74,
-	0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x43, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
-	0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
-	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x1a, 0x3e, 0x0a, 0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x22, 0x49, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x69, 0x66, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07,
+	0x69, 0x66, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x38, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c,
+	0x6f, 0x6f, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6c, 0x6f, 0x6f, 0x70, 0x22,
+	0x34, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x54, 0x41,
+	0x52, 0x54, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x55, 0x53, 0x45, 0x10, 0x01, 0x12,
+	0x0a, 0x0a, 0x06, 0x52, 0x45, 0x53, 0x55, 0x4d, 0x45, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x53,
+	0x54, 0x4f, 0x50, 0x10, 0x03, 0x22, 0x7a, 0x0a, 0x10, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6a,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x70, 0x65, 0x65, 0x72, 0x31, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x32, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x32, 0x12, 0x24, 0x0a, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6e, 0x65, 0x74,
+	0x65, 0x6d, 0x2e, 0x49, 0x66, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x22, 0xd4, 0x01, 0x0a, 0x0f, 0x4c, 0x69, 0x6e, 0x6b, 0x46, 0x6c, 0x61, 0x70, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70,
+	0x65, 0x65, 0x72, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x65, 0x65, 0x72,
+	0x31, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x65, 0x65, 0x72, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x70, 0x65, 0x65, 0x72, 0x32, 0x12, 0x2f, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x69,
+	0x6e, 0x6b, 0x46, 0x6c, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x75, 0x70, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x02, 0x75, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x77, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x6f, 0x77, 0x6e, 0x22, 0x2a, 0x0a, 0x04,
+	0x4d, 0x6f, 0x64, 0x65, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x00, 0x12, 0x0c,
+	0x0a, 0x08, 0x50, 0x45, 0x52, 0x49, 0x4f, 0x44, 0x49, 0x43, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06,
+	0x52, 0x41, 0x4e, 0x44, 0x4f, 0x4d, 0x10, 0x02, 0x22, 0x37, 0x0a, 0x0b, 0x4e, 0x6f, 0x64, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64,
+	0x65, 0x22, 0x20, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x22, 0x35, 0x0a, 0x0f, 0x57, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x35, 0x0a, 0x0b, 0x4f, 0x70,
+	0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a,
 	0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74,
-	0x61, 0x22, 0x52, 0x0a, 0x0f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70,
+	0x61, 0x22, 0x45, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25, 0x0a, 0x04, 0x63,
+	0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6e, 0x65, 0x74, 0x65,
+	0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x34, 0x0a, 0x0b, 0x41, 0x63, 0x6b, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xb8,
+	0x02, 0x0a, 0x0b, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x43, 0x0a, 0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x6e, 0x65,
+	0x74, 0x65, 0x6d, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x52, 0x0c, 0x6e, 0x6f,
+	0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6e, 0x65,
+	0x74, 0x65, 0x6d, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x1a,
+	0x3e, 0x0a, 0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x1a,
+	0x48, 0x0a, 0x06, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x4d, 0x0a, 0x0c, 0x4c, 0x69, 0x6e,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65,
+	0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x22, 0x3d, 0x0a, 0x0d, 0x45, 0x78, 0x70, 0x6f,
+	0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x6a,
+	0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x6a, 0x49, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x49, 0x0a, 0x0c, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0x52, 0x0a, 0x0f, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x87, 0x03, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65,
+	0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x70, 0x65, 0x6e, 0x41, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x70, 0x65, 0x6e, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72,
+	0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x36, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x44,
+	0x0a, 0x08, 0x49, 0x66, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e,
+	0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x49, 0x66, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
+	0x74, 0x61, 0x74, 0x65, 0x1a, 0x7a, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e,
+	0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
+	0x12, 0x3e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x66, 0x53, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73,
+	0x22, 0xb5, 0x01, 0x0a, 0x0f, 0x50, 0x72, 0x6a, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
 	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x87, 0x03, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d,
-	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x70, 0x65, 0x6e, 0x41, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x70, 0x65, 0x6e, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72,
-	0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75,
-	0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x36, 0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x0a,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65,
-	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x1a, 0x44, 0x0a,
-	0x08, 0x49, 0x66, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a,
-	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x6e,
-	0x65, 0x74, 0x65, 0x6d, 0x2e, 0x49, 0x66, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74,
-	0x61, 0x74, 0x65, 0x1a, 0x7a, 0x0a, 0x0a, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12,
-	0x3e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x0a, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x66, 0x53, 0x74, 0x61,
-	0x74, 0x75, 0x73, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x73, 0x22,
-	0xb5, 0x01, 0x0a, 0x0f, 0x50, 0x72, 0x6a, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a, 0x08, 0x70, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6e,
-	0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6a, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x73, 0x1a, 0x42, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x16, 0x0a, 0x06, 0x6f, 0x70, 0x65, 0x6e, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x6f, 0x70, 0x65, 0x6e, 0x41, 0x74, 0x22, 0x48, 0x0a, 0x0f, 0x50, 0x72, 0x6a, 0x4f, 0x70,
-	0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6e, 0x65, 0x74,
-	0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x2a, 0x1f, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12,
-	0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45, 0x52, 0x52, 0x4f, 0x52,
-	0x10, 0x01, 0x2a, 0x1b, 0x0a, 0x07, 0x49, 0x66, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x06, 0x0a,
-	0x02, 0x55, 0x50, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x4f, 0x57, 0x4e, 0x10, 0x01, 0x32,
-	0x90, 0x0a, 0x0a, 0x05, 0x4e, 0x65, 0x74, 0x65, 0x6d, 0x12, 0x3e, 0x0a, 0x0a, 0x47, 0x65, 0x74,
-	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x16, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0a, 0x50, 0x75, 0x6c,
-	0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
-	0x11, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x75, 0x6c, 0x6c, 0x53, 0x72, 0x76, 0x4d,
-	0x73, 0x67, 0x22, 0x00, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x05, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x12,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
-	0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a,
-	0x0b, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6a,
-	0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b,
-	0x0a, 0x0b, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x12, 0x2e,
-	0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x16, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6a, 0x4f, 0x70, 0x65,
-	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0c, 0x43,
-	0x6c, 0x6f, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x6e, 0x65,
-	0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0b, 0x53, 0x61, 0x76, 0x65,
-	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
-	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
-	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a,
-	0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65,
-	0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0f, 0x52, 0x65, 0x61,
-	0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x6e,
-	0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x46, 0x69, 0x6c, 0x65,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x10, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x16,
-	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x57, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x52,
+	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x37, 0x0a, 0x08, 0x70,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e,
+	0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6a, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x73, 0x1a, 0x42, 0x0a, 0x04, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x6f, 0x70, 0x65, 0x6e, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6f, 0x70, 0x65, 0x6e, 0x41, 0x74, 0x22, 0x90, 0x06, 0x0a, 0x11, 0x4c, 0x69, 0x6e,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x40, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x49, 0x66, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x73, 0x1a, 0x80, 0x02, 0x0a, 0x0a, 0x51, 0x64, 0x69, 0x73, 0x63, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x61, 0x6e, 0x64,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x61, 0x6e, 0x64, 0x6c, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x07, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b,
+	0x6c, 0x6f, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6c,
+	0x6f, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x71, 0x6c, 0x65, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x04, 0x71, 0x6c, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x72, 0x6f, 0x70, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x64, 0x72, 0x6f, 0x70, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x6f, 0x76, 0x65, 0x72, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x0a, 0x6f, 0x76, 0x65, 0x72, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x75, 0x65, 0x73, 0x1a, 0xf0, 0x02, 0x0a, 0x07, 0x49, 0x66, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x66, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x69, 0x66, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x78,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x72, 0x78, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x72, 0x78, 0x50, 0x61, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x72, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x12, 0x1c,
+	0x0a, 0x09, 0x72, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x72, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x74, 0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74,
+	0x78, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x78, 0x50, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x74, 0x78, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x74, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x78, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x12, 0x3b,
+	0x0a, 0x06, 0x71, 0x64, 0x69, 0x73, 0x63, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23,
+	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x51, 0x64, 0x69, 0x73, 0x63, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x06, 0x71, 0x64, 0x69, 0x73, 0x63, 0x73, 0x22, 0x48, 0x0a, 0x0f, 0x50,
+	0x72, 0x6a, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x25,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x2a, 0x1f, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x43,
+	0x6f, 0x64, 0x65, 0x12, 0x06, 0x0a, 0x02, 0x4f, 0x4b, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x2a, 0x1b, 0x0a, 0x07, 0x49, 0x66, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x12, 0x06, 0x0a, 0x02, 0x55, 0x50, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x4f, 0x57,
+	0x4e, 0x10, 0x01, 0x32, 0xc6, 0x0e, 0x0a, 0x05, 0x4e, 0x65, 0x74, 0x65, 0x6d, 0x12, 0x3e, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a,
+	0x0a, 0x50, 0x75, 0x6c, 0x6c, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x75, 0x6c, 0x6c,
+	0x53, 0x72, 0x76, 0x4d, 0x73, 0x67, 0x22, 0x00, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x05, 0x43, 0x6c,
+	0x65, 0x61, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x6e, 0x65,
+	0x74, 0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3f, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73,
+	0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d,
+	0x2e, 0x50, 0x72, 0x6a, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0b, 0x4f, 0x70, 0x65, 0x6e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x12, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72,
+	0x6a, 0x4f, 0x70, 0x65, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x3b, 0x0a, 0x0c, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12,
+	0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52,
 	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41,
-	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x05,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e,
-	0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x35, 0x0a, 0x06, 0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x15, 0x2e, 0x6e,
-	0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x75, 0x6e, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x03, 0x52, 0x75, 0x6e,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0b,
+	0x53, 0x61, 0x76, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x12, 0x15, 0x2e, 0x6e, 0x65,
+	0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x10, 0x47, 0x65, 0x74,
+	0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x15, 0x2e,
+	0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a,
+	0x0f, 0x52, 0x65, 0x61, 0x64, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x69, 0x6c, 0x65,
 	0x12, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
-	0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47,
+	0x0a, 0x17, 0x52, 0x65, 0x61, 0x64, 0x45, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x4e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65,
+	0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x13, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x06, 0x45, 0x78, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x14, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40,
+	0x0a, 0x10, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x16, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x57, 0x4e, 0x65, 0x74, 0x77,
+	0x6f, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74,
+	0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x34, 0x0a, 0x05, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65,
+	0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x34, 0x0a, 0x04, 0x4c, 0x69, 0x6e, 0x74, 0x12, 0x15,
+	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x69,
+	0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x06,
+	0x52, 0x65, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x15, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x50,
+	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x03, 0x52, 0x75, 0x6e, 0x12, 0x15, 0x2e, 0x6e, 0x65, 0x74,
+	0x65, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x52, 0x75, 0x6e, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0d, 0x53, 0x65, 0x74, 0x4c, 0x69,
+	0x6e, 0x6b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x18, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d,
+	0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0b, 0x4c, 0x69, 0x6e, 0x6b,
+	0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x19, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e,
+	0x4c, 0x69, 0x6e, 0x6b, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0c, 0x53, 0x65, 0x74, 0x4c,
+	0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x17, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d,
+	0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x0b, 0x53, 0x65, 0x74, 0x4c, 0x69,
+	0x6e, 0x6b, 0x46, 0x6c, 0x61, 0x70, 0x12, 0x16, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4c,
+	0x69, 0x6e, 0x6b, 0x46, 0x6c, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12,
+	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4c, 0x69, 0x6e, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x1b, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4e, 0x6f, 0x64,
+	0x65, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x18, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4c, 0x69, 0x6e, 0x6b, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a,
 	0x0d, 0x43, 0x61, 0x6e, 0x52, 0x75, 0x6e, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x65, 0x12, 0x12,
 	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65,
@@ -2007,10 +3161,13 @@ var file_internal_proto_netem_proto_rawDesc = []byte{
 	0x12, 0x30, 0x0a, 0x06, 0x43, 0x6f, 0x70, 0x79, 0x54, 0x6f, 0x12, 0x0e, 0x2e, 0x6e, 0x65, 0x74,
 	0x65, 0x6d, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x4d, 0x73, 0x67, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74,
 	0x65, 0x6d, 0x2e, 0x41, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x28, 0x01, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
-	0x2f, 0x6d, 0x72, 0x6f, 0x79, 0x33, 0x31, 0x2f, 0x67, 0x6f, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x28, 0x01, 0x12, 0x38, 0x0a, 0x08, 0x4d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x16,
+	0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x4d, 0x6f, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2e, 0x41,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2a, 0x5a, 0x28,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x72, 0x6f, 0x79, 0x33,
+	0x31, 0x2f, 0x67, 0x6f, 0x6e, 0x65, 0x74, 0x65, 0x6d, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2025,40 +3182,54 @@ func file_internal_proto_netem_proto_rawDescGZIP() []byte {
 	return file_internal_proto_netem_proto_rawDescData
 }
 
-var file_internal_proto_netem_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_internal_proto_netem_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_internal_proto_netem_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_internal_proto_netem_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_internal_proto_netem_proto_goTypes = []interface{}{
-	(StatusCode)(0),                   // 0: netem.StatusCode
-	(IfState)(0),                      // 1: netem.IfState
-	(CopyMsg_Code)(0),                 // 2: netem.CopyMsg.Code
-	(ConsoleCltMsg_Code)(0),           // 3: netem.ConsoleCltMsg.Code
-	(ConsoleSrvMsg_Code)(0),           // 4: netem.ConsoleSrvMsg.Code
-	(PullSrvMsg_Code)(0),              // 5: netem.PullSrvMsg.Code
-	(CaptureSrvMsg_Code)(0),           // 6: netem.CaptureSrvMsg.Code
-	(*CopyMsg)(nil),                   // 7: netem.CopyMsg
-	(*ConsoleCltMsg)(nil),             // 8: netem.ConsoleCltMsg
-	(*ConsoleSrvMsg)(nil),             // 9: netem.ConsoleSrvMsg
-	(*PullSrvMsg)(nil),                // 10: netem.PullSrvMsg
-	(*CaptureSrvMsg)(nil),             // 11: netem.CaptureSrvMsg
-	(*NodeIfStateRequest)(nil),        // 12: netem.NodeIfStateRequest
-	(*NodeInterfaceRequest)(nil),      // 13: netem.NodeInterfaceRequest
-	(*NodeRequest)(nil),               // 14: netem.NodeRequest
-	(*ProjectRequest)(nil),            // 15: netem.ProjectRequest
-	(*WNetworkRequest)(nil),           // 16: netem.WNetworkRequest
-	(*OpenRequest)(nil),               // 17: netem.OpenRequest
-	(*Status)(nil),                    // 18: netem.Status
-	(*AckResponse)(nil),               // 19: netem.AckResponse
-	(*RunResponse)(nil),               // 20: netem.RunResponse
-	(*FileResponse)(nil),              // 21: netem.FileResponse
-	(*VersionResponse)(nil),           // 22: netem.VersionResponse
-	(*StatusResponse)(nil),            // 23: netem.StatusResponse
-	(*PrjListResponse)(nil),           // 24: netem.PrjListResponse
-	(*PrjOpenResponse)(nil),           // 25: netem.PrjOpenResponse
-	(*RunResponse_NodeMessages)(nil),  // 26: netem.RunResponse.NodeMessages
-	(*StatusResponse_IfStatus)(nil),   // 27: netem.StatusResponse.IfStatus
-	(*StatusResponse_NodeStatus)(nil), // 28: netem.StatusResponse.NodeStatus
-	(*PrjListResponse_Info)(nil),      // 29: netem.PrjListResponse.Info
-	(*empty.Empty)(nil),               // 30: google.protobuf.Empty
+	(StatusCode)(0),                      // 0: netem.StatusCode
+	(IfState)(0),                         // 1: netem.IfState
+	(CopyMsg_Code)(0),                    // 2: netem.CopyMsg.Code
+	(ConsoleCltMsg_Code)(0),              // 3: netem.ConsoleCltMsg.Code
+	(ConsoleSrvMsg_Code)(0),              // 4: netem.ConsoleSrvMsg.Code
+	(PullSrvMsg_Code)(0),                 // 5: netem.PullSrvMsg.Code
+	(CaptureSrvMsg_Code)(0),              // 6: netem.CaptureSrvMsg.Code
+	(LinkProfileRequest_Action)(0),       // 7: netem.LinkProfileRequest.Action
+	(LinkFlapRequest_Mode)(0),            // 8: netem.LinkFlapRequest.Mode
+	(*CopyMsg)(nil),                      // 9: netem.CopyMsg
+	(*ConsoleCltMsg)(nil),                // 10: netem.ConsoleCltMsg
+	(*ConsoleSrvMsg)(nil),                // 11: netem.ConsoleSrvMsg
+	(*PullSrvMsg)(nil),                   // 12: netem.PullSrvMsg
+	(*CaptureSrvMsg)(nil),                // 13: netem.CaptureSrvMsg
+	(*NodeIfStateRequest)(nil),           // 14: netem.NodeIfStateRequest
+	(*NodeInterfaceRequest)(nil),         // 15: netem.NodeInterfaceRequest
+	(*MoveNodeRequest)(nil),              // 16: netem.MoveNodeRequest
+	(*LinkParamsRequest)(nil),            // 17: netem.LinkParamsRequest
+	(*LinkProfileRequest)(nil),           // 18: netem.LinkProfileRequest
+	(*LinkStateRequest)(nil),             // 19: netem.LinkStateRequest
+	(*LinkFlapRequest)(nil),              // 20: netem.LinkFlapRequest
+	(*NodeRequest)(nil),                  // 21: netem.NodeRequest
+	(*ProjectRequest)(nil),               // 22: netem.ProjectRequest
+	(*WNetworkRequest)(nil),              // 23: netem.WNetworkRequest
+	(*OpenRequest)(nil),                  // 24: netem.OpenRequest
+	(*Status)(nil),                       // 25: netem.Status
+	(*AckResponse)(nil),                  // 26: netem.AckResponse
+	(*RunResponse)(nil),                  // 27: netem.RunResponse
+	(*LintResponse)(nil),                 // 28: netem.LintResponse
+	(*ExportRequest)(nil),                // 29: netem.ExportRequest
+	(*FileResponse)(nil),                 // 30: netem.FileResponse
+	(*VersionResponse)(nil),              // 31: netem.VersionResponse
+	(*StatusResponse)(nil),               // 32: netem.StatusResponse
+	(*PrjListResponse)(nil),              // 33: netem.PrjListResponse
+	(*LinkStatsResponse)(nil),            // 34: netem.LinkStatsResponse
+	(*PrjOpenResponse)(nil),              // 35: netem.PrjOpenResponse
+	nil,                                  // 36: netem.LinkParamsRequest.ParamsEntry
+	(*RunResponse_NodeMessages)(nil),     // 37: netem.RunResponse.NodeMessages
+	(*RunResponse_Change)(nil),           // 38: netem.RunResponse.Change
+	(*StatusResponse_IfStatus)(nil),      // 39: netem.StatusResponse.IfStatus
+	(*StatusResponse_NodeStatus)(nil),    // 40: netem.StatusResponse.NodeStatus
+	(*PrjListResponse_Info)(nil),         // 41: netem.PrjListResponse.Info
+	(*LinkStatsResponse_QdiscStats)(nil), // 42: netem.LinkStatsResponse.QdiscStats
+	(*LinkStatsResponse_IfStats)(nil),    // 43: netem.LinkStatsResponse.IfStats
+	(*empty.Empty)(nil),                  // 44: google.protobuf.Empty
 }
 var file_internal_proto_netem_proto_depIdxs = []int32{
 	2,  // 0: netem.CopyMsg.code:type_name -> netem.CopyMsg.Code
@@ -2067,68 +3238,95 @@ var file_internal_proto_netem_proto_depIdxs = []int32{
 	5,  // 3: netem.PullSrvMsg.code:type_name -> netem.PullSrvMsg.Code
 	6,  // 4: netem.CaptureSrvMsg.code:type_name -> netem.CaptureSrvMsg.Code
 	1,  // 5: netem.NodeIfStateRequest.state:type_name -> netem.IfState
-	0,  // 6: netem.Status.code:type_name -> netem.StatusCode
-	18, // 7: netem.AckResponse.status:type_name -> netem.Status
-	18, // 8: netem.RunResponse.status:type_name -> netem.Status
-	26, // 9: netem.RunResponse.nodeMessages:type_name -> netem.RunResponse.NodeMessages
-	18, // 10: netem.FileResponse.status:type_name -> netem.Status
-	18, // 11: netem.VersionResponse.status:type_name -> netem.Status
-	18, // 12: netem.StatusResponse.status:type_name -> netem.Status
-	28, // 13: netem.StatusResponse.nodes:type_name -> netem.StatusResponse.NodeStatus
-	18, // 14: netem.PrjListResponse.status:type_name -> netem.Status
-	29, // 15: netem.PrjListResponse.projects:type_name -> netem.PrjListResponse.Info
-	18, // 16: netem.PrjOpenResponse.status:type_name -> netem.Status
-	1,  // 17: netem.StatusResponse.IfStatus.state:type_name -> netem.IfState
-	27, // 18: netem.StatusResponse.NodeStatus.interfaces:type_name -> netem.StatusResponse.IfStatus
-	30, // 19: netem.Netem.GetVersion:input_type -> google.protobuf.Empty
-	30, // 20: netem.Netem.PullImages:input_type -> google.protobuf.Empty
-	30, // 21: netem.Netem.Clean:input_type -> google.protobuf.Empty
-	30, // 22: netem.Netem.GetProjects:input_type -> google.protobuf.Empty
-	17, // 23: netem.Netem.OpenProject:input_type -> netem.OpenRequest
-	15, // 24: netem.Netem.CloseProject:input_type -> netem.ProjectRequest
-	15, // 25: netem.Netem.SaveProject:input_type -> netem.ProjectRequest
-	15, // 26: netem.Netem.GetProjectStatus:input_type -> netem.ProjectRequest
-	15, // 27: netem.Netem.ReadNetworkFile:input_type -> netem.ProjectRequest
-	16, // 28: netem.Netem.WriteNetworkFile:input_type -> netem.WNetworkRequest
-	15, // 29: netem.Netem.Check:input_type -> netem.ProjectRequest
-	15, // 30: netem.Netem.Reload:input_type -> netem.ProjectRequest
-	15, // 31: netem.Netem.Run:input_type -> netem.ProjectRequest
-	14, // 32: netem.Netem.CanRunConsole:input_type -> netem.NodeRequest
-	8,  // 33: netem.Netem.Console:input_type -> netem.ConsoleCltMsg
-	14, // 34: netem.Netem.Start:input_type -> netem.NodeRequest
-	14, // 35: netem.Netem.Stop:input_type -> netem.NodeRequest
-	14, // 36: netem.Netem.Restart:input_type -> netem.NodeRequest
-	12, // 37: netem.Netem.SetIfState:input_type -> netem.NodeIfStateRequest
-	13, // 38: netem.Netem.Capture:input_type -> netem.NodeInterfaceRequest
-	7,  // 39: netem.Netem.CopyFrom:input_type -> netem.CopyMsg
-	7,  // 40: netem.Netem.CopyTo:input_type -> netem.CopyMsg
-	22, // 41: netem.Netem.GetVersion:output_type -> netem.VersionResponse
-	10, // 42: netem.Netem.PullImages:output_type -> netem.PullSrvMsg
-	19, // 43: netem.Netem.Clean:output_type -> netem.AckResponse
-	24, // 44: netem.Netem.GetProjects:output_type -> netem.PrjListResponse
-	25, // 45: netem.Netem.OpenProject:output_type -> netem.PrjOpenResponse
-	19, // 46: netem.Netem.CloseProject:output_type -> netem.AckResponse
-	21, // 47: netem.Netem.SaveProject:output_type -> netem.FileResponse
-	23, // 48: netem.Netem.GetProjectStatus:output_type -> netem.StatusResponse
-	21, // 49: netem.Netem.ReadNetworkFile:output_type -> netem.FileResponse
-	19, // 50: netem.Netem.WriteNetworkFile:output_type -> netem.AckResponse
-	19, // 51: netem.Netem.Check:output_type -> netem.AckResponse
-	20, // 52: netem.Netem.Reload:output_type -> netem.RunResponse
-	20, // 53: netem.Netem.Run:output_type -> netem.RunResponse
-	19, // 54: netem.Netem.CanRunConsole:output_type -> netem.AckResponse
-	9,  // 55: netem.Netem.Console:output_type -> netem.ConsoleSrvMsg
-	19, // 56: netem.Netem.Start:output_type -> netem.AckResponse
-	19, // 57: netem.Netem.Stop:output_type -> netem.AckResponse
-	19, // 58: netem.Netem.Restart:output_type -> netem.AckResponse
-	19, // 59: netem.Netem.SetIfState:output_type -> netem.AckResponse
-	11, // 60: netem.Netem.Capture:output_type -> netem.CaptureSrvMsg
-	7,  // 61: netem.Netem.CopyFrom:output_type -> netem.CopyMsg
-	19, // 62: netem.Netem.CopyTo:output_type -> netem.AckResponse
-	41, // [41:63] is the sub-list for method output_type
-	19, // [19:41] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	36, // 6: netem.LinkParamsRequest.params:type_name -> netem.LinkParamsRequest.ParamsEntry
+	7,  // 7: netem.LinkProfileRequest.action:type_name -> netem.LinkProfileRequest.Action
+	1,  // 8: netem.LinkStateRequest.state:type_name -> netem.IfState
+	8,  // 9: netem.LinkFlapRequest.mode:type_name -> netem.LinkFlapRequest.Mode
+	0,  // 10: netem.Status.code:type_name -> netem.StatusCode
+	25, // 11: netem.AckResponse.status:type_name -> netem.Status
+	25, // 12: netem.RunResponse.status:type_name -> netem.Status
+	37, // 13: netem.RunResponse.nodeMessages:type_name -> netem.RunResponse.NodeMessages
+	38, // 14: netem.RunResponse.changes:type_name -> netem.RunResponse.Change
+	25, // 15: netem.LintResponse.status:type_name -> netem.Status
+	25, // 16: netem.FileResponse.status:type_name -> netem.Status
+	25, // 17: netem.VersionResponse.status:type_name -> netem.Status
+	25, // 18: netem.StatusResponse.status:type_name -> netem.Status
+	40, // 19: netem.StatusResponse.nodes:type_name -> netem.StatusResponse.NodeStatus
+	25, // 20: netem.PrjListResponse.status:type_name -> netem.Status
+	41, // 21: netem.PrjListResponse.projects:type_name -> netem.PrjListResponse.Info
+	25, // 22: netem.LinkStatsResponse.status:type_name -> netem.Status
+	43, // 23: netem.LinkStatsResponse.interfaces:type_name -> netem.LinkStatsResponse.IfStats
+	25, // 24: netem.PrjOpenResponse.status:type_name -> netem.Status
+	1,  // 25: netem.StatusResponse.IfStatus.state:type_name -> netem.IfState
+	39, // 26: netem.StatusResponse.NodeStatus.interfaces:type_name -> netem.StatusResponse.IfStatus
+	42, // 27: netem.LinkStatsResponse.IfStats.qdiscs:type_name -> netem.LinkStatsResponse.QdiscStats
+	44, // 28: netem.Netem.GetVersion:input_type -> google.protobuf.Empty
+	44, // 29: netem.Netem.PullImages:input_type -> google.protobuf.Empty
+	44, // 30: netem.Netem.Clean:input_type -> google.protobuf.Empty
+	44, // 31: netem.Netem.GetProjects:input_type -> google.protobuf.Empty
+	24, // 32: netem.Netem.OpenProject:input_type -> netem.OpenRequest
+	22, // 33: netem.Netem.CloseProject:input_type -> netem.ProjectRequest
+	22, // 34: netem.Netem.SaveProject:input_type -> netem.ProjectRequest
+	22, // 35: netem.Netem.GetProjectStatus:input_type -> netem.ProjectRequest
+	22, // 36: netem.Netem.ReadNetworkFile:input_type -> netem.ProjectRequest
+	22, // 37: netem.Netem.ReadExpandedNetworkFile:input_type -> netem.ProjectRequest
+	29, // 38: netem.Netem.Export:input_type -> netem.ExportRequest
+	23, // 39: netem.Netem.WriteNetworkFile:input_type -> netem.WNetworkRequest
+	22, // 40: netem.Netem.Check:input_type -> netem.ProjectRequest
+	22, // 41: netem.Netem.Lint:input_type -> netem.ProjectRequest
+	22, // 42: netem.Netem.Reload:input_type -> netem.ProjectRequest
+	22, // 43: netem.Netem.Run:input_type -> netem.ProjectRequest
+	17, // 44: netem.Netem.SetLinkParams:input_type -> netem.LinkParamsRequest
+	18, // 45: netem.Netem.LinkProfile:input_type -> netem.LinkProfileRequest
+	19, // 46: netem.Netem.SetLinkState:input_type -> netem.LinkStateRequest
+	20, // 47: netem.Netem.SetLinkFlap:input_type -> netem.LinkFlapRequest
+	15, // 48: netem.Netem.GetLinkStats:input_type -> netem.NodeInterfaceRequest
+	21, // 49: netem.Netem.CanRunConsole:input_type -> netem.NodeRequest
+	10, // 50: netem.Netem.Console:input_type -> netem.ConsoleCltMsg
+	21, // 51: netem.Netem.Start:input_type -> netem.NodeRequest
+	21, // 52: netem.Netem.Stop:input_type -> netem.NodeRequest
+	21, // 53: netem.Netem.Restart:input_type -> netem.NodeRequest
+	14, // 54: netem.Netem.SetIfState:input_type -> netem.NodeIfStateRequest
+	15, // 55: netem.Netem.Capture:input_type -> netem.NodeInterfaceRequest
+	9,  // 56: netem.Netem.CopyFrom:input_type -> netem.CopyMsg
+	9,  // 57: netem.Netem.CopyTo:input_type -> netem.CopyMsg
+	16, // 58: netem.Netem.MoveNode:input_type -> netem.MoveNodeRequest
+	31, // 59: netem.Netem.GetVersion:output_type -> netem.VersionResponse
+	12, // 60: netem.Netem.PullImages:output_type -> netem.PullSrvMsg
+	26, // 61: netem.Netem.Clean:output_type -> netem.AckResponse
+	33, // 62: netem.Netem.GetProjects:output_type -> netem.PrjListResponse
+	35, // 63: netem.Netem.OpenProject:output_type -> netem.PrjOpenResponse
+	26, // 64: netem.Netem.CloseProject:output_type -> netem.AckResponse
+	30, // 65: netem.Netem.SaveProject:output_type -> netem.FileResponse
+	32, // 66: netem.Netem.GetProjectStatus:output_type -> netem.StatusResponse
+	30, // 67: netem.Netem.ReadNetworkFile:output_type -> netem.FileResponse
+	30, // 68: netem.Netem.ReadExpandedNetworkFile:output_type -> netem.FileResponse
+	30, // 69: netem.Netem.Export:output_type -> netem.FileResponse
+	26, // 70: netem.Netem.WriteNetworkFile:output_type -> netem.AckResponse
+	26, // 71: netem.Netem.Check:output_type -> netem.AckResponse
+	28, // 72: netem.Netem.Lint:output_type -> netem.LintResponse
+	27, // 73: netem.Netem.Reload:output_type -> netem.RunResponse
+	27, // 74: netem.Netem.Run:output_type -> netem.RunResponse
+	26, // 75: netem.Netem.SetLinkParams:output_type -> netem.AckResponse
+	26, // 76: netem.Netem.LinkProfile:output_type -> netem.AckResponse
+	26, // 77: netem.Netem.SetLinkState:output_type -> netem.AckResponse
+	26, // 78: netem.Netem.SetLinkFlap:output_type -> netem.AckResponse
+	34, // 79: netem.Netem.GetLinkStats:output_type -> netem.LinkStatsResponse
+	26, // 80: netem.Netem.CanRunConsole:output_type -> netem.AckResponse
+	11, // 81: netem.Netem.Console:output_type -> netem.ConsoleSrvMsg
+	26, // 82: netem.Netem.Start:output_type -> netem.AckResponse
+	26, // 83: netem.Netem.Stop:output_type -> netem.AckResponse
+	26, // 84: netem.Netem.Restart:output_type -> netem.AckResponse
+	26, // 85: netem.Netem.SetIfState:output_type -> netem.AckResponse
+	13, // 86: netem.Netem.Capture:output_type -> netem.CaptureSrvMsg
+	9,  // 87: netem.Netem.CopyFrom:output_type -> netem.CopyMsg
+	26, // 88: netem.Netem.CopyTo:output_type -> netem.AckResponse
+	26, // 89: netem.Netem.MoveNode:output_type -> netem.AckResponse
+	59, // [59:90] is the sub-list for method output_type
+	28, // [28:59] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_internal_proto_netem_proto_init() }
@@ -2222,7 +3420,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*NodeRequest); i {
+			switch v := v.(*MoveNodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2234,7 +3432,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProjectRequest); i {
+			switch v := v.(*LinkParamsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2246,7 +3444,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WNetworkRequest); i {
+			switch v := v.(*LinkProfileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2258,7 +3456,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OpenRequest); i {
+			switch v := v.(*LinkStateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2270,7 +3468,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Status); i {
+			switch v := v.(*LinkFlapRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2282,7 +3480,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AckResponse); i {
+			switch v := v.(*NodeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2294,7 +3492,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RunResponse); i {
+			switch v := v.(*ProjectRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2306,7 +3504,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FileResponse); i {
+			switch v := v.(*WNetworkRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2318,7 +3516,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VersionResponse); i {
+			switch v := v.(*OpenRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2330,7 +3528,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse); i {
+			switch v := v.(*Status); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2342,7 +3540,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrjListResponse); i {
+			switch v := v.(*AckResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2354,7 +3552,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrjOpenResponse); i {
+			switch v := v.(*RunResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2366,7 +3564,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RunResponse_NodeMessages); i {
+			switch v := v.(*LintResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2378,7 +3576,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse_IfStatus); i {
+			switch v := v.(*ExportRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2390,7 +3588,7 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StatusResponse_NodeStatus); i {
+			switch v := v.(*FileResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2402,6 +3600,114 @@ func file_internal_proto_netem_proto_init() {
 			}
 		}
 		file_internal_proto_netem_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrjListResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrjOpenResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunResponse_NodeMessages); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RunResponse_Change); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_IfStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResponse_NodeStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PrjListResponse_Info); i {
 			case 0:
 				return &v.state
@@ -2413,14 +3719,38 @@ func file_internal_proto_netem_proto_init() {
 				return nil
 			}
 		}
+		file_internal_proto_netem_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkStatsResponse_QdiscStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_proto_netem_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LinkStatsResponse_IfStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_proto_netem_proto_rawDesc,
-			NumEnums:      7,
-			NumMessages:   23,
+			NumEnums:      9,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -31,11 +31,20 @@ type NetemClient interface {
 	GetProjectStatus(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	// Read/Write network topology
 	ReadNetworkFile(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*FileResponse, error)
+	ReadExpandedNetworkFile(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*FileResponse, error)
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*FileResponse, error)
 	WriteNetworkFile(ctx context.Context, in *WNetworkRequest, opts ...grpc.CallOption) (*AckResponse, error)
 	// topology actions
 	Check(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*AckResponse, error)
+	Lint(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*LintResponse, error)
 	Reload(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*RunResponse, error)
 	Run(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*RunResponse, error)
+	// Link actions
+	SetLinkParams(ctx context.Context, in *LinkParamsRequest, opts ...grpc.CallOption) (*AckResponse, error)
+	LinkProfile(ctx context.Context, in *LinkProfileRequest, opts ...grpc.CallOption) (*AckResponse, error)
+	SetLinkState(ctx context.Context, in *LinkStateRequest, opts ...grpc.CallOption) (*AckResponse, error)
+	SetLinkFlap(ctx context.Context, in *LinkFlapRequest, opts ...grpc.CallOption) (*AckResponse, error)
+	GetLinkStats(ctx context.Context, in *NodeInterfaceRequest, opts ...grpc.CallOption) (*LinkStatsResponse, error)
 	// Node actions
 	CanRunConsole(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*AckResponse, error)
 	Console(ctx context.Context, opts ...grpc.CallOption) (Netem_ConsoleClient, error)
@@ -46,6 +55,7 @@ type NetemClient interface {
 	Capture(ctx context.Context, in *NodeInterfaceRequest, opts ...grpc.CallOption) (Netem_CaptureClient, error)
 	CopyFrom(ctx context.Context, in *CopyMsg, opts ...grpc.CallOption) (Netem_CopyFromClient, error)
 	CopyTo(ctx context.Context, opts ...grpc.CallOption) (Netem_CopyToClient, error)
+	MoveNode(ctx context.Context, in *MoveNodeRequest, opts ...grpc.CallOption) (*AckResponse, error)
 }
 
 type netemClient struct {
@@ -160,6 +170,24 @@ func (c *netemClient) ReadNetworkFile(ctx context.Context, in *ProjectRequest, o
 	return out, nil
 }
 
+func (c *netemClient) ReadExpandedNetworkFile(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*FileResponse, error) {
+	out := new(FileResponse)
+	err := c.cc.Invoke(ctx, "/netem.Netem/ReadExpandedNetworkFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netemClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*FileResponse, error) {
+	out := new(FileResponse)
+	err := c.cc.Invoke(ctx, "/netem.Netem/Export", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *netemClient) WriteNetworkFile(ctx context.Context, in *WNetworkRequest, opts ...grpc.CallOption) (*AckResponse, error) {
 	out := new(AckResponse)
 	err := c.cc.Invoke(ctx, "/netem.Netem/WriteNetworkFile", in, out, opts...)
@@ -178,6 +206,15 @@ func (c *netemClient) Check(ctx context.Context, in *ProjectRequest, opts ...grp
 	return out, nil
 }
 
+func (c *netemClient) Lint(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*LintResponse, error) {
+	out := new(LintResponse)
+	err := c.cc.Invoke(ctx, "/netem.Netem/Lint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *netemClient) Reload(ctx context.Context, in *ProjectRequest, opts ...grpc.CallOption) (*RunResponse, error) {
 	out := new(RunResponse)
 	err := c.cc.Invoke(ctx, "/netem.Netem/Reload", in, out, opts...)
@@ -196,6 +233,51 @@ func (c *netemClient) Run(ctx context.Context, in *ProjectRequest, opts ...grpc.
 	return out, nil
 }
 
+func (c *netemClient) SetLinkParams(ctx context.Context, in *LinkParamsRequest, opts ...grpc.CallOption) (*AckResponse, error) {
+	out := new(AckResponse)
+	err := c.cc.Invoke(ctx, "/netem.Netem/SetLinkParams", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netemClient) LinkProfile(ctx context.Context, in *LinkProfileRequest, opts ...grpc.CallOption) (*AckResponse, error) {
+	out := new(AckResponse)
+	err := c.cc.Invoke(ctx, "/netem.Netem/LinkProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netemClient) SetLinkState(ctx context.Context, in *LinkStateRequest, opts ...grpc.CallOption) (*AckResponse, error) {
+	out := new(AckResponse)
+	err := c.cc.Invoke(ctx, "/netem.Netem/SetLinkState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netemClient) SetLinkFlap(ctx context.Context, in *LinkFlapRequest, opts ...grpc.CallOption) (*AckResponse, error) {
+	out := new(AckResponse)
+	err := c.cc.Invoke(ctx, "/netem.Netem/SetLinkFlap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *netemClient) GetLinkStats(ctx context.Context, in *NodeInterfaceRequest, opts ...grpc.CallOption) (*LinkStatsResponse, error) {
+	out := new(LinkStatsResponse)
+	err := c.cc.Invoke(ctx, "/netem.Netem/GetLinkStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *netemClient) CanRunConsole(ctx context.Context, in *NodeRequest, opts ...grpc.CallOption) (*AckResponse, error) {
 	out := new(AckResponse)
 	err := c.cc.Invoke(ctx, "/netem.Netem/CanRunConsole", in, out, opts...)
@@ -370,6 +452,15 @@ func (x *netemCopyToClient) CloseAndRecv() (*AckResponse, error) {
 	return m, nil
 }
 
+func (c *netemClient) MoveNode(ctx context.Context, in *MoveNodeRequest, opts ...grpc.CallOption) (*AckResponse, error) {
+	out := new(AckResponse)
+	err := c.cc.Invoke(ctx, "/netem.Netem/MoveNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NetemServer is the server API for Netem service.
 // All implementations must embed UnimplementedNetemServer
 // for forward compatibility
@@ -386,11 +477,20 @@ type NetemServer interface {
 	GetProjectStatus(context.Context, *ProjectRequest) (*StatusResponse, error)
 	// Read/Write network topology
 	ReadNetworkFile(context.Context, *ProjectRequest) (*FileResponse, error)
+	ReadExpandedNetworkFile(context.Context, *ProjectRequest) (*FileResponse, error)
+	Export(context.Context, *ExportRequest) (*FileResponse, error)
 	WriteNetworkFile(context.Context, *WNetworkRequest) (*AckResponse, error)
 	// topology actions
 	Check(context.Context, *ProjectRequest) (*AckResponse, error)
+	Lint(context.Context, *ProjectRequest) (*LintResponse, error)
 	Reload(context.Context, *ProjectRequest) (*RunResponse, error)
 	Run(context.Context, *ProjectRequest) (*RunResponse, error)
+	// Link actions
+	SetLinkParams(context.Context, *LinkParamsRequest) (*AckResponse, error)
+	LinkProfile(context.Context, *LinkProfileRequest) (*AckResponse, error)
+	SetLinkState(context.Context, *LinkStateRequest) (*AckResponse, error)
+	SetLinkFlap(context.Context, *LinkFlapRequest) (*AckResponse, error)
+	GetLinkStats(context.Context, *NodeInterfaceRequest) (*LinkStatsResponse, error)
 	// Node actions
 	CanRunConsole(context.Context, *NodeRequest) (*AckResponse, error)
 	Console(Netem_ConsoleServer) error
@@ -401,6 +501,7 @@ type NetemServer interface {
 	Capture(*NodeInterfaceRequest, Netem_CaptureServer) error
 	CopyFrom(*CopyMsg, Netem_CopyFromServer) error
 	CopyTo(Netem_CopyToServer) error
+	MoveNode(context.Context, *MoveNodeRequest) (*AckResponse, error)
 	mustEmbedUnimplementedNetemServer()
 }
 
@@ -435,18 +536,42 @@ func (UnimplementedNetemServer) GetProjectStatus(context.Context, *ProjectReques
 func (UnimplementedNetemServer) ReadNetworkFile(context.Context, *ProjectRequest) (*FileResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadNetworkFile not implemented")
 }
+func (UnimplementedNetemServer) ReadExpandedNetworkFile(context.Context, *ProjectRequest) (*FileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadExpandedNetworkFile not implemented")
+}
+func (UnimplementedNetemServer) Export(context.Context, *ExportRequest) (*FileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
 func (UnimplementedNetemServer) WriteNetworkFile(context.Context, *WNetworkRequest) (*AckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteNetworkFile not implemented")
 }
 func (UnimplementedNetemServer) Check(context.Context, *ProjectRequest) (*AckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
+func (UnimplementedNetemServer) Lint(context.Context, *ProjectRequest) (*LintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lint not implemented")
+}
 func (UnimplementedNetemServer) Reload(context.Context, *ProjectRequest) (*RunResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reload not implemented")
 }
 func (UnimplementedNetemServer) Run(context.Context, *ProjectRequest) (*RunResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Run not implemented")
 }
+func (UnimplementedNetemServer) SetLinkParams(context.Context, *LinkParamsRequest) (*AckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLinkParams not implemented")
+}
+func (UnimplementedNetemServer) LinkProfile(context.Context, *LinkProfileRequest) (*AckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LinkProfile not implemented")
+}
+func (UnimplementedNetemServer) SetLinkState(context.Context, *LinkStateRequest) (*AckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLinkState not implemented")
+}
+func (UnimplementedNetemServer) SetLinkFlap(context.Context, *LinkFlapRequest) (*AckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLinkFlap not implemented")
+}
+func (UnimplementedNetemServer) GetLinkStats(context.Context, *NodeInterfaceRequest) (*LinkStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLinkStats not implemented")
+}
 func (UnimplementedNetemServer) CanRunConsole(context.Context, *NodeRequest) (*AckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CanRunConsole not implemented")
 }
@@ -474,6 +599,9 @@ func (UnimplementedNetemServer) CopyFrom(*CopyMsg, Netem_CopyFromServer) error {
 func (UnimplementedNetemServer) CopyTo(Netem_CopyToServer) error {
 	return status.Errorf(codes.Unimplemented, "method CopyTo not implemented")
 }
+func (UnimplementedNetemServer) MoveNode(context.Context, *MoveNodeRequest) (*AckResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveNode not implemented")
+}
 func (UnimplementedNetemServer) mustEmbedUnimplementedNetemServer() {}
 
 // UnsafeNetemServer may be embedded to opt out of forward compatibility for this service.
@@ -652,6 +780,42 @@ func _Netem_ReadNetworkFile_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Netem_ReadExpandedNetworkFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetemServer).ReadExpandedNetworkFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/netem.Netem/ReadExpandedNetworkFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetemServer).ReadExpandedNetworkFile(ctx, req.(*ProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netem_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetemServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/netem.Netem/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetemServer).Export(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Netem_WriteNetworkFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WNetworkRequest)
 	if err := dec(in); err != nil {
@@ -688,6 +852,24 @@ func _Netem_Check_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Netem_Lint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetemServer).Lint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/netem.Netem/Lint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetemServer).Lint(ctx, req.(*ProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Netem_Reload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ProjectRequest)
 	if err := dec(in); err != nil {
@@ -724,6 +906,96 @@ func _Netem_Run_Handler(srv interface{}, ctx context.Context, dec func(interface
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Netem_SetLinkParams_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkParamsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetemServer).SetLinkParams(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/netem.Netem/SetLinkParams",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetemServer).SetLinkParams(ctx, req.(*LinkParamsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netem_LinkProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetemServer).LinkProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/netem.Netem/LinkProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetemServer).LinkProfile(ctx, req.(*LinkProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netem_SetLinkState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetemServer).SetLinkState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/netem.Netem/SetLinkState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetemServer).SetLinkState(ctx, req.(*LinkStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netem_SetLinkFlap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LinkFlapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetemServer).SetLinkFlap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/netem.Netem/SetLinkFlap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetemServer).SetLinkFlap(ctx, req.(*LinkFlapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Netem_GetLinkStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NodeInterfaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetemServer).GetLinkStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/netem.Netem/GetLinkStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetemServer).GetLinkStats(ctx, req.(*NodeInterfaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Netem_CanRunConsole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NodeRequest)
 	if err := dec(in); err != nil {
@@ -908,6 +1180,24 @@ func (x *netemCopyToServer) Recv() (*CopyMsg, error) {
 	return m, nil
 }
 
+func _Netem_MoveNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NetemServer).MoveNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/netem.Netem/MoveNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NetemServer).MoveNode(ctx, req.(*MoveNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Netem_ServiceDesc is the grpc.ServiceDesc for Netem service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -947,6 +1237,14 @@ var Netem_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadNetworkFile",
 			Handler:    _Netem_ReadNetworkFile_Handler,
 		},
+		{
+			MethodName: "ReadExpandedNetworkFile",
+			Handler:    _Netem_ReadExpandedNetworkFile_Handler,
+		},
+		{
+			MethodName: "Export",
+			Handler:    _Netem_Export_Handler,
+		},
 		{
 			MethodName: "WriteNetworkFile",
 			Handler:    _Netem_WriteNetworkFile_Handler,
@@ -955,6 +1253,10 @@ var Netem_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Check",
 			Handler:    _Netem_Check_Handler,
 		},
+		{
+			MethodName: "Lint",
+			Handler:    _Netem_Lint_Handler,
+		},
 		{
 			MethodName: "Reload",
 			Handler:    _Netem_Reload_Handler,
@@ -963,6 +1265,26 @@ var Netem_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Run",
 			Handler:    _Netem_Run_Handler,
 		},
+		{
+			MethodName: "SetLinkParams",
+			Handler:    _Netem_SetLinkParams_Handler,
+		},
+		{
+			MethodName: "LinkProfile",
+			Handler:    _Netem_LinkProfile_Handler,
+		},
+		{
+			MethodName: "SetLinkState",
+			Handler:    _Netem_SetLinkState_Handler,
+		},
+		{
+			MethodName: "SetLinkFlap",
+			Handler:    _Netem_SetLinkFlap_Handler,
+		},
+		{
+			MethodName: "GetLinkStats",
+			Handler:    _Netem_GetLinkStats_Handler,
+		},
 		{
 			MethodName: "CanRunConsole",
 			Handler:    _Netem_CanRunConsole_Handler,
@@ -983,6 +1305,10 @@ var Netem_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetIfState",
 			Handler:    _Netem_SetIfState_Handler,
 		},
+		{
+			MethodName: "MoveNode",
+			Handler:    _Netem_MoveNode_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
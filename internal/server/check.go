@@ -5,22 +5,88 @@ import (
 	"io/ioutil"
 	"net"
 	"os"
+	"path"
 	"regexp"
 	"strings"
 
 	"github.com/mroy31/gonetem/internal/link"
-	"gopkg.in/yaml.v2"
+	"github.com/mroy31/gonetem/internal/options"
 )
 
 var (
 	nameRE     = regexp.MustCompile(`^\w+$`)
 	switchRE   = regexp.MustCompile(`^\w{1,10}$`)
-	nodeTypeRE = regexp.MustCompile(`^docker\.\w+|ovs$`)
+	nodeTypeRE = regexp.MustCompile(`^(docker\.\w+|ovs|netns|bridge)$`)
 	peerRE     = regexp.MustCompile(`^\w+.[0-9]+$`)
 	volumeRE   = regexp.MustCompile(`^[^\0]+:[^\0]+$`)
+	kindFileRE = regexp.MustCompile(`^[\w\-\.]+$`)
+	cpusetRE   = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+	sysctlRE   = regexp.MustCompile(`^[a-z0-9_\-]+(\.[\w\-]+)+$`)
+	envRE      = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+	// sysctls of the namespaces of a container, as accepted by docker
+	nsSysctls        = []string{"kernel.msgmax", "kernel.msgmnb", "kernel.msgmni", "kernel.sem", "kernel.shmall", "kernel.shmmax", "kernel.shmmni", "kernel.shm_rmid_forced"}
+	nsSysctlPrefixes = []string{"net.", "fs.mqueue."}
 )
 
-func checkNodeConfig(name string, nConfig NodeConfig, nodes []string) error {
+// checkSysctl checks that a sysctl is valid and belongs to the
+// namespaces of the container
+func checkSysctl(key string) error {
+	if !sysctlRE.MatchString(key) {
+		return fmt.Errorf("sysctl '%s' is not valid", key)
+	}
+	if isEntryExist(nsSysctls, key) {
+		return nil
+	}
+	for _, prefix := range nsSysctlPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return nil
+		}
+	}
+	return fmt.Errorf("sysctl '%s' is not namespaced, it can not be set in a node", key)
+}
+
+func checkNodeKind(name string, kind options.NodeKind) []error {
+	var errors []error
+	if !nameRE.MatchString(name) {
+		errors = append(errors, fmt.Errorf("Kind: '%s' name is not valid", name))
+	}
+	if kind.Image == "" {
+		errors = append(errors, fmt.Errorf("Kind %s: image is required", name))
+	}
+
+	var files []string
+	for _, file := range kind.Files {
+		switch {
+		case !kindFileRE.MatchString(file.Name) || file.Name == "init.conf":
+			errors = append(errors, fmt.Errorf("Kind %s: file name '%s' is not valid", name, file.Name))
+		case isEntryExist(files, file.Name):
+			errors = append(errors, fmt.Errorf("Kind %s: file '%s' is defined several times", name, file.Name))
+		case !path.IsAbs(file.Path):
+			errors = append(errors, fmt.Errorf("Kind %s: path '%s' of file %s must be absolute", name, file.Path, file.Name))
+		}
+		files = append(files, file.Name)
+	}
+
+	if kind.Ready != nil {
+		if kind.Ready.Command == "" {
+			errors = append(errors, fmt.Errorf("Kind %s: command of the readiness probe is required", name))
+		}
+		if kind.Ready.Timeout < 0 {
+			errors = append(errors, fmt.Errorf("Kind %s: timeout of the readiness probe must be >= 0", name))
+		}
+	}
+	return errors
+}
+
+// hasContainerOptions returns true if an option of the docker nodes is set
+func hasContainerOptions(nConfig NodeConfig) bool {
+	return nConfig.Mpls || len(nConfig.Vrfs) > 0 || len(nConfig.Vrrps) > 0 || len(nConfig.Volumes) > 0 || nConfig.Image != "" ||
+		nConfig.NodeResources != (options.NodeResources{}) || nConfig.Cpuset != "" ||
+		len(nConfig.Sysctls) > 0 || len(nConfig.Env) > 0 || len(nConfig.Command) > 0 || len(nConfig.Entrypoint) > 0
+}
+
+func checkNodeConfig(name string, nConfig NodeConfig, nodes []string, kinds map[string]options.NodeKind) error {
 	if isEntryExist(nodes, name) {
 		return fmt.Errorf("Node '%s' already exist", name)
 	}
@@ -32,6 +98,34 @@ func checkNodeConfig(name string, nConfig NodeConfig, nodes []string) error {
 	if !nodeTypeRE.MatchString(nConfig.Type) {
 		return fmt.Errorf("Node: '%s' type field is not valid", nConfig.Type)
 	}
+	if strings.HasPrefix(nConfig.Type, "docker.") {
+		kind := nodeKind(nConfig.Type)
+		if _, found := options.GetNodeKind(kind, kinds); !found {
+			return fmt.Errorf("Node %s: kind '%s' is not defined", name, kind)
+		}
+	}
+
+	// netns and bridge nodes are only a network namespace, without container
+	if nConfig.Type == "netns" && hasContainerOptions(nConfig) {
+		return fmt.Errorf("Node %s: only ipv6 and the wireless options can be set on a netns node", name)
+	}
+	if nConfig.Type == "bridge" {
+		if hasContainerOptions(nConfig) || nConfig.IPv6 {
+			return fmt.Errorf("Node %s: only stp and ports can be set on a bridge node", name)
+		}
+		for ifIndex, port := range nConfig.Ports {
+			if ifIndex < 0 {
+				return fmt.Errorf("Node %s: port %d is not valid", name, ifIndex)
+			}
+			for _, vlan := range append([]int{port.Pvid}, port.Vlans...) {
+				if vlan != 0 && (vlan < link.MIN_VLAN || vlan > link.MAX_VLAN) {
+					return fmt.Errorf("Node %s: vlan %d of port %d must be between %d and %d", name, vlan, ifIndex, link.MIN_VLAN, link.MAX_VLAN)
+				}
+			}
+		}
+	} else if nConfig.Stp || len(nConfig.Ports) > 0 {
+		return fmt.Errorf("Node %s: stp and ports can only be set on a bridge node", name)
+	}
 
 	// more check on ovs node
 	if nConfig.Type == "ovs" {
@@ -42,6 +136,35 @@ func checkNodeConfig(name string, nConfig NodeConfig, nodes []string) error {
 		if nConfig.Mpls || len(nConfig.Vrfs) > 0 {
 			return fmt.Errorf("Mpls can not be enable on ovswitch")
 		}
+		if nConfig.NodeResources != (options.NodeResources{}) || nConfig.Cpuset != "" {
+			return fmt.Errorf("Switch Node %s: resource limits can not be set on ovswitch", name)
+		}
+		if len(nConfig.Sysctls) > 0 || len(nConfig.Env) > 0 || len(nConfig.Command) > 0 || len(nConfig.Entrypoint) > 0 {
+			return fmt.Errorf("Switch Node %s: sysctls, env, command and entrypoint can not be set on ovswitch", name)
+		}
+	} else {
+		// check resource limits
+		if _, err := options.GetNodeResources(nConfig.NodeResources); err != nil {
+			return fmt.Errorf("Node %s: %w", name, err)
+		}
+		if nConfig.Cpuset != "" && !cpusetRE.MatchString(nConfig.Cpuset) {
+			return fmt.Errorf("Node %s: cpuset '%s' is not valid", name, nConfig.Cpuset)
+		}
+
+		// check container options
+		for key := range nConfig.Sysctls {
+			if err := checkSysctl(key); err != nil {
+				return fmt.Errorf("Node %s: %w", name, err)
+			}
+		}
+		for key := range nConfig.Env {
+			if !envRE.MatchString(key) {
+				return fmt.Errorf("Node %s: environment variable '%s' is not valid", name, key)
+			}
+		}
+		if len(nConfig.Entrypoint) > 0 && nConfig.Entrypoint[0] == "" {
+			return fmt.Errorf("Node %s: entrypoint program is empty", name)
+		}
 	}
 
 	// check vrrp configuration
@@ -72,6 +195,31 @@ func checkNodeConfig(name string, nConfig NodeConfig, nodes []string) error {
 		}
 	}
 
+	// check wireless options
+	if nConfig.Range < 0 {
+		return fmt.Errorf("Node %s: range must be >= 0", name)
+	}
+	if nConfig.Mobility != nil {
+		if nConfig.Position == nil {
+			return fmt.Errorf("Node %s: mobility requires a position", name)
+		}
+		if err := checkMobilityConfig(*nConfig.Mobility); err != nil {
+			return fmt.Errorf("Node %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// checkIfOptions checks the MTU and the txqueuelen of an interface,
+// 0 means the default value
+func checkIfOptions(mtu, txQueueLen int) error {
+	if mtu != 0 && (mtu < link.MIN_MTU || mtu > link.MAX_MTU) {
+		return fmt.Errorf("mtu must be between %d and %d", link.MIN_MTU, link.MAX_MTU)
+	}
+	if txQueueLen < 0 {
+		return fmt.Errorf("txqueuelen must be >= 0")
+	}
 	return nil
 }
 
@@ -84,6 +232,10 @@ func checkBridgeConfig(name string, bConfig BridgeConfig, bridges []string) erro
 		return fmt.Errorf("Bridge: '%s' name field is not valid", name)
 	}
 
+	if err := checkIfOptions(bConfig.Mtu, bConfig.TxQueueLen); err != nil {
+		return fmt.Errorf("Bridge %s: %w", name, err)
+	}
+
 	ns := link.GetRootNetns()
 	defer ns.Close()
 
@@ -94,6 +246,38 @@ func checkBridgeConfig(name string, bConfig BridgeConfig, bridges []string) erro
 	return nil
 }
 
+// checkHostIfConfig checks a host interface, which must be found in the
+// root netns unless it is moved in a node of a running project
+func checkHostIfConfig(name string, hConfig HostIfConfig, hostIfs []string) error {
+	if isEntryExist(hostIfs, name) {
+		return fmt.Errorf("Host interface '%s' already exist", name)
+	}
+
+	if !nameRE.MatchString(name) {
+		return fmt.Errorf("Host interface: '%s' name field is not valid", name)
+	}
+
+	mode := hConfig.GetMode()
+	switch mode {
+	case HOSTIF_MOVE, HOSTIF_MACVLAN, HOSTIF_IPVLAN:
+	default:
+		return fmt.Errorf("Host interface %s: mode '%s' is not valid (move, macvlan or ipvlan)", name, mode)
+	}
+
+	if hConfig.Host == "" {
+		return fmt.Errorf("Host interface %s: host field is required", name)
+	}
+
+	ns := link.GetRootNetns()
+	defer ns.Close()
+
+	if !link.IsLinkExist(hConfig.Host, ns) && !(mode == HOSTIF_MOVE && isHostIfMoved(hConfig.Host)) {
+		return fmt.Errorf("Host interface %s: host interface %s not found", name, hConfig.Host)
+	}
+
+	return nil
+}
+
 func isEntryExist(nodes []string, node string) bool {
 	for _, n := range nodes {
 		if node == n {
@@ -122,12 +306,161 @@ func isPeerValid(nodes, peers []string, peer string) error {
 	return nil
 }
 
+type percentParam struct {
+	name  string
+	value float64
+}
+
+func checkLinkParams(params LinkParams, prjPath string) []error {
+	var errors []error
+
+	// check netem parameters
+	if params.Delay < 0 {
+		errors = append(errors, fmt.Errorf("Link delay must be >= 0, in ms or with a unit (us, ms, s)"))
+	}
+	if params.Jitter < 0 {
+		errors = append(errors, fmt.Errorf("Link jitter must be >= 0, in ms or with a unit (us, ms, s)"))
+	}
+	if params.Loss < 0 {
+		errors = append(errors, fmt.Errorf("Link loss must be >= 0 and specified in percent"))
+	}
+	if params.Jitter > 0 && params.Delay == 0 {
+		errors = append(errors, fmt.Errorf("You must set delay with jitter"))
+	}
+	if params.Loss > 100 {
+		errors = append(errors, fmt.Errorf("Link loss must be =< 100 and specified in percent"))
+	}
+
+	// check other netem parameters, given in percent
+	percents := []percentParam{
+		{name: "loss_correlation", value: params.LossCorrelation},
+		{name: "delay_correlation", value: params.DelayCorrelation},
+		{name: "duplicate", value: params.Duplicate},
+		{name: "duplicate_correlation", value: params.DuplicateCorrelation},
+		{name: "corrupt", value: params.Corrupt},
+		{name: "corrupt_correlation", value: params.CorruptCorrelation},
+		{name: "reorder", value: params.Reorder},
+		{name: "reorder_correlation", value: params.ReorderCorrelation},
+	}
+	if ge := params.LossGEModel; ge != nil {
+		percents = append(percents, []percentParam{
+			{name: "loss_gemodel/p", value: ge.P},
+			{name: "loss_gemodel/r", value: ge.R},
+			{name: "loss_gemodel/bad_loss", value: ge.BadLoss},
+			{name: "loss_gemodel/good_loss", value: ge.GoodLoss},
+		}...)
+	}
+	if st := params.LossStateModel; st != nil {
+		percents = append(percents, []percentParam{
+			{name: "loss_state/p13", value: st.P13},
+			{name: "loss_state/p31", value: st.P31},
+			{name: "loss_state/p32", value: st.P32},
+			{name: "loss_state/p23", value: st.P23},
+			{name: "loss_state/p14", value: st.P14},
+		}...)
+	}
+	for _, per := range percents {
+		if per.value < 0 || per.value > 100 {
+			errors = append(errors, fmt.Errorf("Link %s must be between 0 and 100 and specified in percent", per.name))
+		}
+	}
+
+	if params.DelayCorrelation > 0 && params.Jitter == 0 {
+		errors = append(errors, fmt.Errorf("You must set jitter with delay_correlation"))
+	}
+	if params.LossCorrelation > 0 && params.Loss == 0 {
+		errors = append(errors, fmt.Errorf("You must set loss with loss_correlation"))
+	}
+	if params.DuplicateCorrelation > 0 && params.Duplicate == 0 {
+		errors = append(errors, fmt.Errorf("You must set duplicate with duplicate_correlation"))
+	}
+	if params.CorruptCorrelation > 0 && params.Corrupt == 0 {
+		errors = append(errors, fmt.Errorf("You must set corrupt with corrupt_correlation"))
+	}
+	if params.ReorderCorrelation > 0 && params.Reorder == 0 {
+		errors = append(errors, fmt.Errorf("You must set reorder with reorder_correlation"))
+	}
+	if params.Gap < 0 {
+		errors = append(errors, fmt.Errorf("Link gap must be >= 0 and specified in packets"))
+	}
+	if (params.Reorder > 0 || params.Gap > 0) && params.Delay == 0 {
+		errors = append(errors, fmt.Errorf("Delay must be > 0 when reorder or gap is configured"))
+	}
+
+	// check distribution table
+	if params.Distribution != "" {
+		if !nameRE.MatchString(params.Distribution) {
+			errors = append(errors, fmt.Errorf("Link distribution '%s' is not a valid name", params.Distribution))
+		} else if _, err := loadDistTable(prjPath, params.Distribution); err != nil {
+			errors = append(errors, err)
+		}
+		if params.Jitter == 0 {
+			errors = append(errors, fmt.Errorf("You must set jitter with distribution"))
+		}
+	}
+
+	// check loss models
+	if params.LossGEModel != nil && params.LossStateModel != nil {
+		errors = append(errors, fmt.Errorf("Only one loss model can be set, loss_gemodel or loss_state"))
+	}
+	if (params.LossGEModel != nil || params.LossStateModel != nil) && params.Loss > 0 {
+		errors = append(errors, fmt.Errorf("Link loss can not be set with a loss model"))
+	}
+	if (params.LossGEModel != nil || params.LossStateModel != nil) && params.LossCorrelation > 0 {
+		errors = append(errors, fmt.Errorf("Link loss_correlation can not be set with a loss model"))
+	}
+
+	// check tbf parameters
+	if params.Rate < 0 {
+		errors = append(errors, fmt.Errorf("Link rate must be >= 0, in kbps or with a unit (bit, kbit, mbit, gbit, tbit)"))
+	} else if params.Rate > 0 && params.Rate < 8 {
+		// the kernel uses rates in bytes/s
+		errors = append(errors, fmt.Errorf("Link rate must be at least 8bit"))
+	}
+	if params.Rate > 0 && params.Delay == 0 {
+		errors = append(errors, fmt.Errorf("Delay must be > 0 when Link rate is configured"))
+	}
+
+	// check queue parameters
+	if params.Queue != nil {
+		errors = append(errors, checkQueueConfig(*params.Queue, params.Rate)...)
+	}
+
+	return errors
+}
+
+func checkLinkConfig(lConfig LinkConfig, prjPath string) []error {
+	errors := checkLinkParams(lConfig.LinkParams, prjPath)
+
+	// check per-direction parameters
+	directions := []struct {
+		name   string
+		params *LinkParams
+	}{
+		{name: peer1ToPeer2Key, params: lConfig.Peer1ToPeer2},
+		{name: peer2ToPeer1Key, params: lConfig.Peer2ToPeer1},
+	}
+	for _, dir := range directions {
+		if dir.params == nil {
+			continue
+		}
+		for _, err := range checkLinkParams(*dir.params, prjPath) {
+			errors = append(errors, fmt.Errorf("Link %s-%s (%s): %w", lConfig.Peer1, lConfig.Peer2, dir.name, err))
+		}
+	}
+
+	return errors
+}
+
+// CheckTopology checks the network file filepath. Errors are located
+// in the network files and sorted by position
 func CheckTopology(filepath string) (*NetemTopology, []error) {
 	var errors []error
 	var nodes []string
 	var bridges []string
+	var hostIfs []string
+	var segments []string
 	var peers []string
-	var topology NetemTopology
 
 	data, err := ioutil.ReadFile(filepath)
 	if err != nil {
@@ -135,79 +468,150 @@ func CheckTopology(filepath string) (*NetemTopology, []error) {
 		return nil, errors
 	}
 
-	err = yaml.Unmarshal(data, &topology)
+	topology, err := expandTopology(data, path.Dir(filepath), nil, []includedFile{newIncludedFile(filepath, path.Base(filepath))})
 	if err != nil {
-		errors = append(errors, fmt.Errorf("Unable to parse topology file '%s':\n\t%w", filepath, err))
+		errors = flattenErrors(err)
+		sortErrors(errors)
 		return nil, errors
 	}
 
+	// check kinds
+	for _, name := range sortedKeys(topology.Kinds) {
+		for _, err := range checkNodeKind(name, topology.Kinds[name]) {
+			errors = append(errors, topology.withOrigin("kind:"+name, err))
+		}
+	}
+
 	// check nodes
-	for name, nConfig := range topology.Nodes {
-		if err := checkNodeConfig(name, nConfig, nodes); err != nil {
-			errors = append(errors, err)
+	for _, name := range sortedKeys(topology.Nodes) {
+		if err := checkNodeConfig(name, topology.Nodes[name], nodes, topology.Kinds); err != nil {
+			errors = append(errors, topology.withOrigin("node:"+name, err))
 		}
 		nodes = append(nodes, name)
 	}
 
 	// check links
-	for _, link := range topology.Links {
-		if err := isPeerValid(nodes, peers, link.Peer1); err != nil {
-			errors = append(errors, err)
-			continue
+	for idx, link := range topology.Links {
+		for _, err := range checkTopologyLink(link, nodes, &peers, path.Dir(filepath)) {
+			errors = append(errors, topology.withOrigin(fmt.Sprintf("link:%d", idx), err))
 		}
-		if err := isPeerValid(nodes, peers, link.Peer2); err != nil {
-			errors = append(errors, err)
-			continue
+	}
+
+	// check bridges
+	for _, bName := range sortedKeys(topology.Bridges) {
+		bConfig := topology.Bridges[bName]
+		if err := checkBridgeConfig(bName, bConfig, bridges); err != nil {
+			errors = append(errors, topology.withOrigin("bridge:"+bName, err))
 		}
 
-		if link.Peer1 == link.Peer2 {
-			errors = append(errors, fmt.Errorf("A link can not have the same peer"))
+		for _, peer := range bConfig.Interfaces {
+			if err := isPeerValid(nodes, peers, peer); err != nil {
+				errors = append(errors, topology.withOrigin("bridge:"+bName, err))
+				continue
+			}
+			peers = append(peers, peer)
 		}
 
-		peers = append(peers, link.Peer1, link.Peer2)
+		bridges = append(bridges, bName)
+	}
 
-		// check netem parameters
-		if link.Delay < 0 {
-			errors = append(errors, fmt.Errorf("Link delay must be >= 0 and specified in ms"))
-		}
-		if link.Jitter < 0 {
-			errors = append(errors, fmt.Errorf("Link jitter must be >= 0 and specified in ms"))
-		}
-		if link.Loss < 0 {
-			errors = append(errors, fmt.Errorf("Link loss must be >= 0 and specified in percent"))
+	// check host interfaces, the interface of a bridge or a moved one can
+	// not be used twice, several children can share the same parent
+	exclusive := make(map[string]string)
+	children := make(map[string]string)
+	for _, bName := range sortedKeys(topology.Bridges) {
+		exclusive[topology.Bridges[bName].Host] = "bridge " + bName
+	}
+	for _, hName := range sortedKeys(topology.HostIfs) {
+		hConfig := topology.HostIfs[hName]
+		if err := checkHostIfConfig(hName, hConfig, hostIfs); err != nil {
+			errors = append(errors, topology.withOrigin("hostif:"+hName, err))
 		}
-		if link.Jitter > 0 && link.Delay == 0 {
-			errors = append(errors, fmt.Errorf("You must set delay with jitter"))
+		hostIfs = append(hostIfs, hName)
+
+		user, found := exclusive[hConfig.Host]
+		if !found && hConfig.GetMode() == HOSTIF_MOVE {
+			user, found = children[hConfig.Host]
 		}
-		if link.Loss > 100 {
-			errors = append(errors, fmt.Errorf("Link loss must be =< 100 and specified in percent"))
+		switch {
+		case found:
+			errors = append(errors, topology.withOrigin("hostif:"+hName, fmt.Errorf(
+				"Host interface %s: %s is already used by %s", hName, hConfig.Host, user)))
+		case hConfig.GetMode() == HOSTIF_MOVE:
+			exclusive[hConfig.Host] = "host interface " + hName
+		default:
+			children[hConfig.Host] = "host interface " + hName
 		}
 
-		// check tbf parameters
-		if link.Rate < 0 {
-			errors = append(errors, fmt.Errorf("Link rate must be >= 0 and specified in kbps"))
+		if err := isPeerValid(nodes, peers, hConfig.Interface); err != nil {
+			errors = append(errors, topology.withOrigin("hostif:"+hName, err))
+			continue
 		}
-		if link.Rate > 0 && link.Delay == 0 {
-			errors = append(errors, fmt.Errorf("Delay must be > 0 when Link rate is configured"))
+		peers = append(peers, hConfig.Interface)
+
+		node := strings.Split(hConfig.Interface, ".")[0]
+		if topology.Nodes[node].Type == "ovs" {
+			errors = append(errors, topology.withOrigin("hostif:"+hName, fmt.Errorf(
+				"Host interface %s: node %s is an ovs switch, use a bridge node", hName, node)))
 		}
 	}
 
-	// check bridges
-	for bName, bConfig := range topology.Bridges {
-		if err := checkBridgeConfig(bName, bConfig, bridges); err != nil {
-			errors = append(errors, err)
-		}
+	// check segments
+	for _, sName := range sortedKeys(topology.Segments) {
+		sConfig := topology.Segments[sName]
+		segErrors := checkSegmentConfig(sName, sConfig, segments, path.Dir(filepath))
 
-		for _, peer := range bConfig.Interfaces {
-			if err := isPeerValid(nodes, peers, peer); err != nil {
-				errors = append(errors, err)
+		for _, member := range sConfig.Members {
+			if err := isPeerValid(nodes, peers, member.Peer); err != nil {
+				segErrors = append(segErrors, err)
 				continue
 			}
-			peers = append(peers, peer)
+			peers = append(peers, member.Peer)
+
+			node := strings.Split(member.Peer, ".")[0]
+			if sConfig.Wireless != nil && topology.Nodes[node].Position == nil {
+				segErrors = append(segErrors, fmt.Errorf("Segment %s: node %s must have a position", sName, node))
+			}
+		}
+		for _, err := range segErrors {
+			errors = append(errors, topology.withOrigin("segment:"+sName, err))
 		}
 
-		bridges = append(bridges, bName)
+		segments = append(segments, sName)
 	}
 
-	return &topology, errors
+	sortErrors(errors)
+	return topology, errors
+}
+
+func checkTopologyLink(link LinkConfig, nodes []string, peers *[]string, prjPath string) []error {
+	if err := isPeerValid(nodes, *peers, link.Peer1); err != nil {
+		return []error{err}
+	}
+	if err := isPeerValid(nodes, *peers, link.Peer2); err != nil {
+		return []error{err}
+	}
+
+	var errors []error
+	if link.Peer1 == link.Peer2 {
+		errors = append(errors, fmt.Errorf("A link can not have the same peer"))
+	}
+
+	*peers = append(*peers, link.Peer1, link.Peer2)
+
+	errors = append(errors, checkLinkConfig(link, prjPath)...)
+	if err := checkIfOptions(link.Mtu, link.TxQueueLen); err != nil {
+		errors = append(errors, fmt.Errorf("Link %s-%s: %w", link.Peer1, link.Peer2, err))
+	}
+	if link.Flap != nil {
+		if err := checkLinkFlap(*link.Flap); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if link.Profile != nil {
+		if _, _, err := checkLinkProfile(prjPath, link.Profile.Name, link); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return errors
 }
@@ -0,0 +1,350 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/mroy31/gonetem/internal/link"
+)
+
+const (
+	EXPORT_DOT  = "dot"
+	EXPORT_SVG  = "svg"
+	EXPORT_JSON = "json"
+)
+
+// style of the nodes in the diagram, by kind
+var dotNodeStyles = map[string]string{
+	"router": `shape=ellipse, style=filled, fillcolor="#9ecae1"`,
+	"host":   `shape=box, style="rounded,filled", fillcolor="#c7e9c0"`,
+	"server": `shape=box3d, style=filled, fillcolor="#fdd0a2"`,
+	"ovs":    `shape=box, style=filled, fillcolor="#dadaeb"`,
+	"netns":  `shape=box, style="rounded,filled,dashed", fillcolor="#c7e9c0"`,
+	"bridge": `shape=box, style="filled,dashed", fillcolor="#dadaeb"`,
+}
+
+type exportInterface struct {
+	Peer  string `json:"peer"`
+	State string `json:"state,omitempty"` // up or down, when the project runs
+}
+
+type exportNode struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Kind    string `json:"kind"`
+	Running *bool  `json:"running,omitempty"`
+}
+
+type exportLink struct {
+	Peer1  exportInterface `json:"peer1"`
+	Peer2  exportInterface `json:"peer2"`
+	Params string          `json:"params,omitempty"`
+}
+
+type exportBridge struct {
+	Name       string            `json:"name"`
+	Host       string            `json:"host"`
+	Interfaces []exportInterface `json:"interfaces"`
+}
+
+type exportHostIf struct {
+	Name      string          `json:"name"`
+	Host      string          `json:"host"`
+	Mode      string          `json:"mode"`
+	Interface exportInterface `json:"interface"`
+}
+
+type exportSegment struct {
+	Name     string            `json:"name"`
+	Wireless bool              `json:"wireless,omitempty"`
+	Members  []exportInterface `json:"members"`
+}
+
+// topologyExport is the diagram of a topology
+type topologyExport struct {
+	Name     string          `json:"name"`
+	Nodes    []exportNode    `json:"nodes"`
+	Links    []exportLink    `json:"links"`
+	Bridges  []exportBridge  `json:"bridges"`
+	HostIfs  []exportHostIf  `json:"hostifs"`
+	Segments []exportSegment `json:"segments"`
+}
+
+// paramsSummary returns the impairments of params, like
+// "delay 10ms jitter 1ms loss 2% rate 10mbit"
+func paramsSummary(params LinkParams) string {
+	items := make([]string, 0)
+	if params.Delay > 0 {
+		items = append(items, "delay "+params.Delay.String())
+	}
+	if params.Jitter > 0 {
+		items = append(items, "jitter "+params.Jitter.String())
+	}
+	if params.Loss > 0 {
+		items = append(items, "loss "+strconv.FormatFloat(params.Loss, 'f', -1, 64)+"%")
+	}
+	if params.LossGEModel != nil {
+		items = append(items, "loss gemodel")
+	}
+	if params.LossStateModel != nil {
+		items = append(items, "loss state")
+	}
+	if params.Duplicate > 0 {
+		items = append(items, "duplicate "+strconv.FormatFloat(params.Duplicate, 'f', -1, 64)+"%")
+	}
+	if params.Corrupt > 0 {
+		items = append(items, "corrupt "+strconv.FormatFloat(params.Corrupt, 'f', -1, 64)+"%")
+	}
+	if params.Reorder > 0 {
+		items = append(items, "reorder "+strconv.FormatFloat(params.Reorder, 'f', -1, 64)+"%")
+	}
+	if params.Rate > 0 {
+		items = append(items, "rate "+params.Rate.String())
+	}
+	if params.Queue != nil {
+		items = append(items, params.Queue.Type)
+	}
+	return strings.Join(items, " ")
+}
+
+// linkSummary returns the impairments of a link, for each direction
+// when they differ
+func linkSummary(lConfig LinkConfig) string {
+	params1, params2 := paramsSummary(lConfig.Peer1Params()), paramsSummary(lConfig.Peer2Params())
+	if params1 == params2 {
+		return params1
+	}
+
+	items := make([]string, 0)
+	if params1 != "" {
+		items = append(items, "> "+params1)
+	}
+	if params2 != "" {
+		items = append(items, "< "+params2)
+	}
+	return strings.Join(items, "\n")
+}
+
+func nodeKind(nodeType string) string {
+	return strings.TrimPrefix(nodeType, "docker.")
+}
+
+// newTopologyExport creates the diagram of topology, state returns the
+// state of an interface, an empty string if it is unknown
+func newTopologyExport(name string, topology *NetemTopology, state func(string) string) *topologyExport {
+	export := &topologyExport{
+		Name:     name,
+		Nodes:    make([]exportNode, 0),
+		Links:    make([]exportLink, 0),
+		Bridges:  make([]exportBridge, 0),
+		HostIfs:  make([]exportHostIf, 0),
+		Segments: make([]exportSegment, 0),
+	}
+	exportIf := func(peer string) exportInterface {
+		return exportInterface{Peer: peer, State: state(peer)}
+	}
+
+	for _, name := range sortedKeys(topology.Nodes) {
+		nType := topology.Nodes[name].Type
+		export.Nodes = append(export.Nodes, exportNode{Name: name, Type: nType, Kind: nodeKind(nType)})
+	}
+	for _, lConfig := range topology.Links {
+		export.Links = append(export.Links, exportLink{
+			Peer1:  exportIf(lConfig.Peer1),
+			Peer2:  exportIf(lConfig.Peer2),
+			Params: linkSummary(lConfig),
+		})
+	}
+	for _, name := range sortedKeys(topology.Bridges) {
+		bConfig := topology.Bridges[name]
+		bridge := exportBridge{Name: name, Host: bConfig.Host, Interfaces: make([]exportInterface, 0)}
+		for _, peer := range bConfig.Interfaces {
+			bridge.Interfaces = append(bridge.Interfaces, exportIf(peer))
+		}
+		export.Bridges = append(export.Bridges, bridge)
+	}
+	for _, name := range sortedKeys(topology.HostIfs) {
+		hConfig := topology.HostIfs[name]
+		export.HostIfs = append(export.HostIfs, exportHostIf{
+			Name:      name,
+			Host:      hConfig.Host,
+			Mode:      hConfig.GetMode(),
+			Interface: exportIf(hConfig.Interface),
+		})
+	}
+	for _, name := range sortedKeys(topology.Segments) {
+		sConfig := topology.Segments[name]
+		segment := exportSegment{Name: name, Wireless: sConfig.Wireless != nil, Members: make([]exportInterface, 0)}
+		for _, member := range sConfig.Members {
+			segment.Members = append(segment.Members, exportIf(member.Peer))
+		}
+		export.Segments = append(export.Segments, segment)
+	}
+
+	return export
+}
+
+func dotQuote(value string) string {
+	return strconv.Quote(value)
+}
+
+// dotEdge writes the edge between a node interface and other
+func dotEdge(buffer *bytes.Buffer, ifc exportInterface, other string, attrs []string) {
+	split := strings.Split(ifc.Peer, ".")
+	if len(split) != 2 {
+		return
+	}
+	attrs = append(attrs, "taillabel="+dotQuote(split[1]))
+	if ifc.State == "down" {
+		attrs = append(attrs, "color=red", "style=dashed")
+	}
+	fmt.Fprintf(buffer, "  %s -- %s [%s];\n", dotQuote(split[0]), dotQuote(other), strings.Join(attrs, ", "))
+}
+
+// Dot returns the diagram in the Graphviz DOT language
+func (e *topologyExport) Dot() []byte {
+	buffer := new(bytes.Buffer)
+	fmt.Fprintf(buffer, "graph %s {\n", dotQuote(e.Name))
+	buffer.WriteString("  graph [overlap=false, splines=true];\n")
+	buffer.WriteString("  node [fontname=\"Helvetica\"];\n")
+	buffer.WriteString("  edge [fontname=\"Helvetica\", fontsize=10];\n")
+
+	for _, node := range e.Nodes {
+		style, found := dotNodeStyles[node.Kind]
+		if !found {
+			style = "shape=box"
+		}
+		fmt.Fprintf(buffer, "  %s [%s, label=%s];\n", dotQuote(node.Name), style, dotQuote(node.Name+"\n"+node.Kind))
+	}
+	for _, bridge := range e.Bridges {
+		fmt.Fprintf(buffer, "  %s [shape=note, label=%s];\n", dotQuote("bridge:"+bridge.Name), dotQuote(bridge.Name+"\nhost "+bridge.Host))
+	}
+	for _, hostIf := range e.HostIfs {
+		fmt.Fprintf(buffer, "  %s [shape=note, label=%s];\n", dotQuote("hostif:"+hostIf.Name), dotQuote(hostIf.Name+"\n"+hostIf.Mode+" "+hostIf.Host))
+	}
+	for _, segment := range e.Segments {
+		style := "shape=ellipse, style=dashed"
+		if segment.Wireless {
+			style = "shape=doublecircle, style=dashed"
+		}
+		fmt.Fprintf(buffer, "  %s [%s, label=%s];\n", dotQuote("segment:"+segment.Name), style, dotQuote(segment.Name))
+	}
+
+	for _, l := range e.Links {
+		split1, split2 := strings.Split(l.Peer1.Peer, "."), strings.Split(l.Peer2.Peer, ".")
+		if len(split1) != 2 || len(split2) != 2 {
+			continue
+		}
+		attrs := []string{"taillabel=" + dotQuote(split1[1]), "headlabel=" + dotQuote(split2[1])}
+		if l.Params != "" {
+			attrs = append(attrs, "label="+dotQuote(l.Params))
+		}
+		if l.Peer1.State == "down" || l.Peer2.State == "down" {
+			attrs = append(attrs, "color=red", "style=dashed")
+		}
+		fmt.Fprintf(buffer, "  %s -- %s [%s];\n", dotQuote(split1[0]), dotQuote(split2[0]), strings.Join(attrs, ", "))
+	}
+	for _, bridge := range e.Bridges {
+		for _, ifc := range bridge.Interfaces {
+			dotEdge(buffer, ifc, "bridge:"+bridge.Name, []string{})
+		}
+	}
+	for _, hostIf := range e.HostIfs {
+		dotEdge(buffer, hostIf.Interface, "hostif:"+hostIf.Name, []string{"style=bold"})
+	}
+	for _, segment := range e.Segments {
+		for _, ifc := range segment.Members {
+			dotEdge(buffer, ifc, "segment:"+segment.Name, []string{"style=dotted"})
+		}
+	}
+
+	buffer.WriteString("}\n")
+	return buffer.Bytes()
+}
+
+// Svg renders the diagram with the dot command of Graphviz
+func (e *topologyExport) Svg() ([]byte, error) {
+	dotPath, err := exec.LookPath("dot")
+	if err != nil {
+		return nil, fmt.Errorf("Graphviz is required to export in svg: %w", err)
+	}
+
+	cmd := exec.Command(dotPath, "-Tsvg")
+	cmd.Stdin = bytes.NewReader(e.Dot())
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("Unable to render svg: %v %s", err, stderr.String())
+	}
+	return output, nil
+}
+
+// Export returns the diagram of the topology, titled name, in format (dot,
+// svg or json). When the project runs, the state of the interfaces and the
+// current parameters of the links are given
+func (t *NetemTopologyManager) Export(name, format string) ([]byte, error) {
+	topology := t.topology
+	if !t.running || topology == nil {
+		data, err := t.ReadNetworkFile()
+		if err != nil {
+			return nil, err
+		}
+		if topology, err = ExpandTopology(data, t.path); err != nil {
+			return nil, fmt.Errorf("Unable to parse network file: %w", err)
+		}
+	}
+
+	state := func(peer string) string { return "" }
+	if t.running {
+		// links may have been modified since the load of the topology
+		current := *topology
+		current.Links = make([]LinkConfig, len(t.links))
+		for idx, l := range t.links {
+			current.Links[idx] = l.getConfig()
+		}
+		topology = &current
+
+		state = func(peer string) string {
+			split := strings.Split(peer, ".")
+			node := t.GetNode(split[0])
+			if node == nil || len(split) != 2 {
+				return ""
+			}
+			ifIndex, _ := strconv.Atoi(split[1])
+			ifState, found := node.GetInterfacesState()[node.GetInterfaceName(ifIndex)]
+			switch {
+			case !found:
+				return ""
+			case ifState == link.IFSTATE_DOWN:
+				return "down"
+			}
+			return "up"
+		}
+	}
+
+	export := newTopologyExport(name, topology, state)
+	if t.running {
+		for idx := range export.Nodes {
+			running := false
+			if node := t.GetNode(export.Nodes[idx].Name); node != nil {
+				running = node.IsRunning()
+			}
+			export.Nodes[idx].Running = &running
+		}
+	}
+
+	switch format {
+	case EXPORT_DOT:
+		return export.Dot(), nil
+	case EXPORT_SVG:
+		return export.Svg()
+	case EXPORT_JSON:
+		return json.MarshalIndent(export, "", "  ")
+	}
+	return nil, fmt.Errorf("Export format '%s' is not valid (%s, %s or %s expected)", format, EXPORT_DOT, EXPORT_SVG, EXPORT_JSON)
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const exportNetwork = `
+nodes:
+  R1:
+    type: docker.router
+  host:
+    type: docker.host
+  sw:
+    type: ovs
+links:
+- peer1: R1.0
+  peer2: sw.0
+  delay: 10
+  rate: 10mbit
+- peer1: host.0
+  peer2: sw.1
+  peer1_to_peer2:
+    loss: 2
+bridges:
+  br:
+    host: eth0
+    interfaces:
+    - R1.1`
+
+func TestExport_Topology(t *testing.T) {
+	tests := []struct {
+		desc     string
+		state    map[string]string
+		expected []string
+	}{
+		{
+			desc: "Export: nodes, links and bridges",
+			expected: []string{
+				`"R1" [shape=ellipse, style=filled, fillcolor="#9ecae1", label="R1\nrouter"];`,
+				`"sw" [shape=box, style=filled, fillcolor="#dadaeb", label="sw\novs"];`,
+				`"bridge:br" [shape=note, label="br\nhost eth0"];`,
+				`"R1" -- "sw" [taillabel="0", headlabel="0", label="delay 10ms rate 10mbit"];`,
+				`"host" -- "sw" [taillabel="0", headlabel="1", label="> loss 2%"];`,
+				`"R1" -- "bridge:br" [taillabel="1"];`,
+			},
+		},
+		{
+			desc:  "Export: interface down",
+			state: map[string]string{"R1.0": "up", "sw.0": "down", "R1.1": "down"},
+			expected: []string{
+				`"R1" -- "sw" [taillabel="0", headlabel="0", label="delay 10ms rate 10mbit", color=red, style=dashed];`,
+				`"R1" -- "bridge:br" [taillabel="1", color=red, style=dashed];`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			topology, err := ExpandTopology([]byte(exportNetwork), "/tmp")
+			if err != nil {
+				t.Fatalf("Unable to parse topology: %v", err)
+			}
+
+			export := newTopologyExport("test", topology, func(peer string) string { return tt.state[peer] })
+			dot := string(export.Dot())
+			for _, line := range tt.expected {
+				if !strings.Contains(dot, line) {
+					t.Errorf("Line '%s' not found in:\n%s", line, dot)
+				}
+			}
+
+			data, err := json.Marshal(export)
+			if err != nil {
+				t.Fatalf("Unable to encode in json: %v", err)
+			}
+			var decoded topologyExport
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Unable to decode json: %v", err)
+			}
+			if len(decoded.Nodes) != 3 || len(decoded.Links) != 2 || len(decoded.Bridges) != 1 {
+				t.Errorf("Wrong json export: %s", string(data))
+			}
+			if decoded.Links[0].Peer2.State != tt.state["sw.0"] {
+				t.Errorf("Wrong state of sw.0: '%s'", decoded.Links[0].Peer2.State)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package server
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mroy31/gonetem/internal/link"
+)
+
+const (
+	flapPeriodic = "periodic"
+	flapRandom   = "random"
+)
+
+// LinkFlapConfig defines how a link goes down/up periodically.
+// With the random mode, Up is the mean time between failures and
+// Down the mean time to repair, both exponentially distributed
+type LinkFlapConfig struct {
+	Mode string
+	Up   int // ms
+	Down int // ms
+}
+
+// linkFlapRunner sets the link down/up in its own goroutine
+type linkFlapRunner struct {
+	config LinkFlapConfig
+	link   *NetemLink
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func checkLinkFlap(fConfig LinkFlapConfig) error {
+	if fConfig.Mode != flapPeriodic && fConfig.Mode != flapRandom {
+		return fmt.Errorf("Link flap mode '%s' is not valid (%s or %s expected)", fConfig.Mode, flapPeriodic, flapRandom)
+	}
+	if fConfig.Up <= 0 || fConfig.Down <= 0 {
+		return fmt.Errorf("Link flap up and down must be > 0 and specified in ms")
+	}
+	return nil
+}
+
+func (r *linkFlapRunner) duration(state link.IfState) time.Duration {
+	mean := r.config.Up
+	if state == link.IFSTATE_DOWN {
+		mean = r.config.Down
+	}
+
+	if r.config.Mode == flapRandom {
+		return time.Duration(rand.ExpFloat64() * float64(mean) * float64(time.Millisecond))
+	}
+	return time.Duration(mean) * time.Millisecond
+}
+
+func (r *linkFlapRunner) run(t *NetemTopologyManager) {
+	defer close(r.done)
+
+	state := link.IFSTATE_UP
+	for {
+		timer := time.NewTimer(r.duration(state))
+		select {
+		case <-timer.C:
+		case <-r.stop:
+			timer.Stop()
+			// a link is always up when flapping is stopped
+			if err := t.setLinkState(r.link, link.IFSTATE_UP); err != nil {
+				t.logger.Errorf("Link flap: unable to set link up: %v", err)
+			}
+			return
+		}
+
+		if state == link.IFSTATE_UP {
+			state = link.IFSTATE_DOWN
+		} else {
+			state = link.IFSTATE_UP
+		}
+		if err := t.setLinkState(r.link, state); err != nil {
+			t.logger.Errorf("Link flap: unable to change link state: %v", err)
+		}
+	}
+}
+
+// getLinkByPeers returns the link between peer1 and peer2,
+// given with the format <node>.<ifIndex>
+func (t *NetemTopologyManager) getLinkByPeers(peer1, peer2 string) (*NetemLink, error) {
+	if peer1 == peer2 {
+		return nil, fmt.Errorf("Peers of a link must be different (%s)", peer1)
+	}
+
+	peers := make([]NetemLinkPeer, 2)
+	for idx, peer := range []string{peer1, peer2} {
+		if !peerRE.MatchString(peer) {
+			return nil, fmt.Errorf("Invalid format for peer '%s' (<node>.<ifIndex> required)", peer)
+		}
+		split := strings.Split(peer, ".")
+		ifIndex, _ := strconv.Atoi(split[1])
+		peers[idx] = NetemLinkPeer{Node: t.GetNode(split[0]), IfIndex: ifIndex}
+		if peers[idx].Node == nil {
+			return nil, fmt.Errorf("Node %s not found in the topology", split[0])
+		}
+	}
+
+	l := t.GetLink(peers[0].Node.GetName(), peers[0].IfIndex)
+	if l == nil || (l.Peer1 != peers[1] && l.Peer2 != peers[1]) {
+		return nil, fmt.Errorf("No link found between %s and %s", peer1, peer2)
+	}
+	return l, nil
+}
+
+func (t *NetemTopologyManager) setLinkState(l *NetemLink, state link.IfState) error {
+	for _, peer := range []NetemLinkPeer{l.Peer1, l.Peer2} {
+		if err := peer.Node.SetInterfaceState(peer.IfIndex, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLinkState sets both interfaces of the link between peer1 and
+// peer2 up or down. Flapping of this link is stopped if necessary
+func (t *NetemTopologyManager) SetLinkState(peer1, peer2 string, state link.IfState) error {
+	if !t.running {
+		return fmt.Errorf("Topology is not running")
+	}
+
+	l, err := t.getLinkByPeers(peer1, peer2)
+	if err != nil {
+		return err
+	}
+
+	t.stopLinkFlap(l)
+	return t.setLinkState(l, state)
+}
+
+// StartLinkFlap sets the link between peer1 and peer2
+// down/up according to fConfig
+func (t *NetemTopologyManager) StartLinkFlap(peer1, peer2 string, fConfig LinkFlapConfig) error {
+	if !t.running {
+		return fmt.Errorf("Topology is not running")
+	}
+	if err := checkLinkFlap(fConfig); err != nil {
+		return err
+	}
+
+	l, err := t.getLinkByPeers(peer1, peer2)
+	if err != nil {
+		return err
+	}
+
+	t.stopLinkFlap(l)
+	runner := &linkFlapRunner{
+		config: fConfig,
+		link:   l,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	t.flapLock.Lock()
+	t.flaps[l] = runner
+	t.flapLock.Unlock()
+
+	go runner.run(t)
+	return nil
+}
+
+// StopLinkFlap stops flapping of the link between peer1 and peer2
+func (t *NetemTopologyManager) StopLinkFlap(peer1, peer2 string) error {
+	l, err := t.getLinkByPeers(peer1, peer2)
+	if err != nil {
+		return err
+	}
+
+	if !t.stopLinkFlap(l) {
+		return fmt.Errorf("Link %s-%s is not flapping", peer1, peer2)
+	}
+	return nil
+}
+
+// stopLinkFlap stops flapping of the link and
+// returns true if the link was flapping
+func (t *NetemTopologyManager) stopLinkFlap(l *NetemLink) bool {
+	t.flapLock.Lock()
+	runner, found := t.flaps[l]
+	delete(t.flaps, l)
+	t.flapLock.Unlock()
+
+	if !found {
+		return false
+	}
+
+	close(runner.stop)
+	<-runner.done
+	return true
+}
+
+func (t *NetemTopologyManager) stopAllLinkFlaps() {
+	t.flapLock.Lock()
+	links := make([]*NetemLink, 0, len(t.flaps))
+	for l := range t.flaps {
+		links = append(links, l)
+	}
+	t.flapLock.Unlock()
+
+	for _, l := range links {
+		t.stopLinkFlap(l)
+	}
+}
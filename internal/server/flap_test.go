@@ -0,0 +1,184 @@
+package server
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mroy31/gonetem/internal/link"
+	"github.com/sirupsen/logrus"
+)
+
+// flapTestNode records the states set on its interfaces
+type flapTestNode struct {
+	INetemNode
+	name   string
+	lock   sync.Mutex
+	states []link.IfState
+}
+
+func (n *flapTestNode) GetName() string {
+	return n.name
+}
+
+func (n *flapTestNode) SetInterfaceState(ifIndex int, state link.IfState) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.states = append(n.states, state)
+	return nil
+}
+
+func (n *flapTestNode) getStates() []link.IfState {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return append([]link.IfState{}, n.states...)
+}
+
+func TestFlap_Duration(t *testing.T) {
+	tests := []struct {
+		desc     string
+		config   LinkFlapConfig
+		state    link.IfState
+		expected time.Duration
+	}{
+		{
+			desc:     "Flap: periodic up",
+			config:   LinkFlapConfig{Mode: flapPeriodic, Up: 1000, Down: 200},
+			state:    link.IFSTATE_UP,
+			expected: time.Second,
+		},
+		{
+			desc:     "Flap: periodic down",
+			config:   LinkFlapConfig{Mode: flapPeriodic, Up: 1000, Down: 200},
+			state:    link.IFSTATE_DOWN,
+			expected: 200 * time.Millisecond,
+		},
+		{
+			desc:     "Flap: random up",
+			config:   LinkFlapConfig{Mode: flapRandom, Up: 1000, Down: 200},
+			state:    link.IFSTATE_UP,
+			expected: time.Second,
+		},
+		{
+			desc:     "Flap: random down",
+			config:   LinkFlapConfig{Mode: flapRandom, Up: 1000, Down: 200},
+			state:    link.IFSTATE_DOWN,
+			expected: 200 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			runner := &linkFlapRunner{config: tt.config}
+			if tt.config.Mode == flapPeriodic {
+				if d := runner.duration(tt.state); d != tt.expected {
+					t.Errorf("Wrong duration %v != %v", d, tt.expected)
+				}
+				return
+			}
+
+			// durations are exponentially distributed around the mean
+			samples := 10000
+			total := time.Duration(0)
+			for i := 0; i < samples; i++ {
+				total += runner.duration(tt.state)
+			}
+			mean := total / time.Duration(samples)
+			if math.Abs(float64(mean-tt.expected)) > 0.1*float64(tt.expected) {
+				t.Errorf("Wrong mean duration %v != %v", mean, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFlap_StartStop(t *testing.T) {
+	tests := []struct {
+		desc          string
+		peer1         string
+		peer2         string
+		config        LinkFlapConfig
+		expectedError bool
+	}{
+		{
+			desc:   "Flap: start and stop",
+			peer1:  "R1.0",
+			peer2:  "R2.0",
+			config: LinkFlapConfig{Mode: flapPeriodic, Up: 5, Down: 5},
+		},
+		{
+			desc:   "Flap: reversed peers",
+			peer1:  "R2.0",
+			peer2:  "R1.0",
+			config: LinkFlapConfig{Mode: flapRandom, Up: 5, Down: 5},
+		},
+		{
+			desc:          "Flap: same peer",
+			peer1:         "R1.0",
+			peer2:         "R1.0",
+			config:        LinkFlapConfig{Mode: flapPeriodic, Up: 5, Down: 5},
+			expectedError: true,
+		},
+		{
+			desc:          "Flap: no link",
+			peer1:         "R1.1",
+			peer2:         "R2.0",
+			config:        LinkFlapConfig{Mode: flapPeriodic, Up: 5, Down: 5},
+			expectedError: true,
+		},
+		{
+			desc:          "Flap: wrong mode",
+			peer1:         "R1.0",
+			peer2:         "R2.0",
+			config:        LinkFlapConfig{Mode: "always", Up: 5, Down: 5},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			r1, r2 := &flapTestNode{name: "R1"}, &flapTestNode{name: "R2"}
+			l := &NetemLink{
+				Peer1:  NetemLinkPeer{Node: r1, IfIndex: 0},
+				Peer2:  NetemLinkPeer{Node: r2, IfIndex: 0},
+				Config: LinkConfig{Peer1: "R1.0", Peer2: "R2.0"},
+			}
+			topo := &NetemTopologyManager{
+				nodes:    []INetemNode{r1, r2},
+				links:    []*NetemLink{l},
+				flaps:    make(map[*NetemLink]*linkFlapRunner),
+				flapLock: &sync.Mutex{},
+				running:  true,
+				logger:   logrus.NewEntry(logrus.New()),
+			}
+
+			err := topo.StartLinkFlap(tt.peer1, tt.peer2, tt.config)
+			if err != nil {
+				if !tt.expectedError {
+					t.Errorf("StartLinkFlap returns an unexpected error: %v", err)
+				}
+				return
+			} else if tt.expectedError {
+				t.Errorf("StartLinkFlap does not return an error")
+				return
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			if err := topo.StopLinkFlap(tt.peer1, tt.peer2); err != nil {
+				t.Fatalf("StopLinkFlap returns an unexpected error: %v", err)
+			}
+			if err := topo.StopLinkFlap(tt.peer1, tt.peer2); err == nil {
+				t.Errorf("StopLinkFlap does not return an error for a stopped link")
+			}
+
+			for _, node := range []*flapTestNode{r1, r2} {
+				states := node.getStates()
+				if len(states) < 2 || states[0] != link.IFSTATE_DOWN {
+					t.Errorf("Interface of %s has not been set down: %v", node.name, states)
+				} else if states[len(states)-1] != link.IFSTATE_UP {
+					t.Errorf("Interface of %s is not up after stop: %v", node.name, states)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,528 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mroy31/gonetem/internal/options"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	GENERATOR_LINE       = "line"
+	GENERATOR_RING       = "ring"
+	GENERATOR_FULL_MESH  = "full-mesh"
+	GENERATOR_LEAF_SPINE = "leaf-spine"
+)
+
+var (
+	variableRE = regexp.MustCompile(`\$\{(\w+)\}|\$(\w+)|\$\$`)
+	braceRE    = regexp.MustCompile(`\{([^{}]*)\}`)
+	rangeRE    = regexp.MustCompile(`^(-?\d+)\.\.(-?\d+)$`)
+)
+
+// GeneratorConfig creates links between nodes according to a pattern.
+// Interfaces are allocated from the lowest index not used by the
+// other links, bridges and segments of the nodes
+type GeneratorConfig struct {
+	Pattern    string
+	Nodes      []string `yaml:",omitempty"`
+	Spines     []string `yaml:",omitempty"` // leaf-spine only
+	Leaves     []string `yaml:",omitempty"` // leaf-spine only
+	LinkParams `yaml:",inline"`
+	Mtu        int `yaml:",omitempty"`
+	TxQueueLen int `yaml:"txqueuelen,omitempty"`
+}
+
+// topologySource is the content of the network file, before expansion
+// of the variables, the brace patterns and the generators
+type topologySource struct {
+	Variables  map[string]interface{}      `yaml:",omitempty"`
+	Kinds      map[string]options.NodeKind `yaml:",omitempty"`
+	Nodes      map[string]NodeConfig
+	Links      []LinkConfig
+	Bridges    map[string]BridgeConfig
+	HostIfs    map[string]HostIfConfig `yaml:"hostifs,omitempty"`
+	Segments   map[string]SegmentConfig
+	Generators []GeneratorConfig `yaml:",omitempty"`
+	Include    []IncludeConfig   `yaml:",omitempty"`
+}
+
+// expandBraces returns the strings generated by the patterns {a..b},
+// a range of integers, and {x,y,z}, a list, found in value
+func expandBraces(value string) ([]string, error) {
+	loc := braceRE.FindStringSubmatchIndex(value)
+	if loc == nil {
+		if strings.ContainsAny(value, "{}") {
+			return nil, fmt.Errorf("'%s': unbalanced braces", value)
+		}
+		return []string{value}, nil
+	}
+
+	prefix, content, suffix := value[:loc[0]], value[loc[2]:loc[3]], value[loc[1]:]
+	if strings.ContainsAny(prefix, "{}") {
+		return nil, fmt.Errorf("'%s': unbalanced braces", value)
+	}
+	items := make([]string, 0)
+	if match := rangeRE.FindStringSubmatch(content); match != nil {
+		start, _ := strconv.Atoi(match[1])
+		end, _ := strconv.Atoi(match[2])
+		// {01..10} gives 01, 02... 10
+		format := "%d"
+		if len(match[1]) > 1 && strings.HasPrefix(match[1], "0") {
+			format = fmt.Sprintf("%%0%dd", len(match[1]))
+		}
+		step := 1
+		if end < start {
+			step = -1
+		}
+		for i := start; i != end+step; i += step {
+			items = append(items, fmt.Sprintf(format, i))
+		}
+	} else if strings.Contains(content, ",") {
+		items = strings.Split(content, ",")
+	} else {
+		return nil, fmt.Errorf("'%s': '{%s}' is not a range or a list", value, content)
+	}
+
+	suffixes, err := expandBraces(suffix)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, 0, len(items)*len(suffixes))
+	for _, item := range items {
+		for _, s := range suffixes {
+			values = append(values, prefix+item+s)
+		}
+	}
+	return values, nil
+}
+
+func expandList(values []string) ([]string, error) {
+	expanded := make([]string, 0, len(values))
+	for _, value := range values {
+		items, err := expandBraces(value)
+		if err != nil {
+			return nil, err
+		}
+		expanded = append(expanded, items...)
+	}
+	return expanded, nil
+}
+
+// substituteVariables replaces $name and ${name} in the scalars of data
+// by the value of the variable name defined in the variables section or
+// in overrides, $$ is replaced by $. Comments and multi-line scalars
+// are kept as is
+func substituteVariables(data []byte, overrides map[string]interface{}) ([]byte, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &doc); err != nil {
+		return nil, yamlErrors(data, err)
+	}
+
+	variables := make(map[string]interface{})
+	if len(doc.Content) > 0 {
+		if node := yamlMapValue(doc.Content[0], "variables"); node != nil {
+			if err := node.Decode(&variables); err != nil {
+				return nil, &TopologyError{Location{Line: node.Line, Column: node.Column}, fmt.Errorf("Unable to parse variables: %w", err)}
+			}
+		}
+	}
+	for name, value := range overrides {
+		variables[name] = value
+	}
+
+	// scalars are replaced from the end of data, so the offsets
+	// of the previous ones are not modified
+	scalars := yamlScalars(&doc, nil)
+	result := append([]byte{}, data...)
+	for idx := len(scalars) - 1; idx >= 0; idx-- {
+		start, end, found := scalarSpan(data, scalars[idx])
+		if !found {
+			continue
+		}
+
+		raw := data[start:end]
+		value := make([]byte, 0, len(raw))
+		last := 0
+		for _, loc := range variableRE.FindAllSubmatchIndex(raw, -1) {
+			value = append(value, raw[last:loc[0]]...)
+			last = loc[1]
+
+			name := ""
+			switch {
+			case loc[2] >= 0:
+				name = string(raw[loc[2]:loc[3]])
+			case loc[4] >= 0:
+				name = string(raw[loc[4]:loc[5]])
+			default:
+				value = append(value, '$')
+				continue
+			}
+			variable, found := variables[name]
+			if !found {
+				// the error gives the position of the variable
+				offset := start + loc[0]
+				line := bytes.Count(data[:offset], []byte("\n")) + 1
+				column := offset - bytes.LastIndexByte(data[:offset], '\n')
+				return nil, &TopologyError{Location{Line: line, Column: column}, fmt.Errorf("Variable '%s' is not defined", name)}
+			}
+			value = append(value, []byte(fmt.Sprint(variable))...)
+		}
+		value = append(value, raw[last:]...)
+
+		result = append(result[:start], append(value, result[end:]...)...)
+	}
+	return result, nil
+}
+
+// yamlScalars appends to scalars the scalar nodes of node
+// in the order of the document
+func yamlScalars(node *yamlv3.Node, scalars []*yamlv3.Node) []*yamlv3.Node {
+	if node.Kind == yamlv3.ScalarNode {
+		return append(scalars, node)
+	}
+	for _, child := range node.Content {
+		scalars = yamlScalars(child, scalars)
+	}
+	return scalars
+}
+
+// scalarSpan returns the offsets in data of the text of a single
+// line scalar, without quotes
+func scalarSpan(data []byte, node *yamlv3.Node) (int, int, bool) {
+	if !strings.Contains(node.Value, "$") || strings.Contains(node.Value, "\n") ||
+		node.Style&(yamlv3.LiteralStyle|yamlv3.FoldedStyle) != 0 {
+		return 0, 0, false
+	}
+
+	// line and column of the node are counted in characters
+	start := 0
+	for line := 1; line < node.Line; line++ {
+		start += bytes.IndexByte(data[start:], '\n') + 1
+	}
+	for column := 1; column < node.Column && start < len(data) && data[start] != '\n'; column++ {
+		_, size := utf8.DecodeRune(data[start:])
+		start += size
+	}
+	end := bytes.IndexByte(data[start:], '\n')
+	if end < 0 {
+		end = len(data)
+	} else {
+		end += start
+	}
+	// the node can start with an anchor or a tag
+	text := data[start:end]
+
+	switch {
+	case node.Style&yamlv3.SingleQuotedStyle != 0, node.Style&yamlv3.DoubleQuotedStyle != 0:
+		quote := byte('"')
+		if node.Style&yamlv3.SingleQuotedStyle != 0 {
+			quote = '\''
+		}
+		open := bytes.IndexByte(text, quote)
+		if open < 0 {
+			return 0, 0, false
+		}
+		for i := open + 1; i < len(text); i++ {
+			switch {
+			case quote == '"' && text[i] == '\\':
+				i++
+			case quote == '\'' && text[i] == '\'' && i+1 < len(text) && text[i+1] == '\'':
+				i++
+			case text[i] == quote:
+				return start + open + 1, start + i, true
+			}
+		}
+		// the closing quote is on another line
+		return 0, 0, false
+	}
+
+	idx := bytes.Index(text, []byte(node.Value))
+	if idx < 0 {
+		return 0, 0, false
+	}
+	return start + idx, start + idx + len(node.Value), true
+}
+
+// usedInterfaces returns the interfaces of each node connected to
+// a link, a bridge or a segment
+func usedInterfaces(topology *NetemTopology) map[string]map[int]bool {
+	used := make(map[string]map[int]bool)
+	add := func(peer string) {
+		split := strings.Split(peer, ".")
+		if len(split) != 2 {
+			return
+		}
+		ifIndex, err := strconv.Atoi(split[1])
+		if err != nil {
+			return
+		}
+		if used[split[0]] == nil {
+			used[split[0]] = make(map[int]bool)
+		}
+		used[split[0]][ifIndex] = true
+	}
+
+	for _, lConfig := range topology.Links {
+		add(lConfig.Peer1)
+		add(lConfig.Peer2)
+	}
+	for _, bConfig := range topology.Bridges {
+		for _, peer := range bConfig.Interfaces {
+			add(peer)
+		}
+	}
+	for _, hConfig := range topology.HostIfs {
+		add(hConfig.Interface)
+	}
+	for _, sConfig := range topology.Segments {
+		for _, member := range sConfig.Members {
+			add(member.Peer)
+		}
+	}
+	return used
+}
+
+// generatorPairs returns the pairs of nodes linked by the generator
+func generatorPairs(gConfig GeneratorConfig) ([][2]string, error) {
+	nodes, err := expandList(gConfig.Nodes)
+	if err != nil {
+		return nil, err
+	}
+	pairs := make([][2]string, 0)
+
+	switch gConfig.Pattern {
+	case GENERATOR_LINE, GENERATOR_RING, GENERATOR_FULL_MESH:
+		if len(gConfig.Spines) > 0 || len(gConfig.Leaves) > 0 {
+			return nil, fmt.Errorf("spines and leaves are only valid for %s", GENERATOR_LEAF_SPINE)
+		}
+		if len(nodes) < 2 {
+			return nil, fmt.Errorf("at least 2 nodes are required")
+		}
+
+		if gConfig.Pattern == GENERATOR_FULL_MESH {
+			for i := range nodes {
+				for j := i + 1; j < len(nodes); j++ {
+					pairs = append(pairs, [2]string{nodes[i], nodes[j]})
+				}
+			}
+			break
+		}
+		for i := 0; i < len(nodes)-1; i++ {
+			pairs = append(pairs, [2]string{nodes[i], nodes[i+1]})
+		}
+		if gConfig.Pattern == GENERATOR_RING && len(nodes) > 2 {
+			pairs = append(pairs, [2]string{nodes[len(nodes)-1], nodes[0]})
+		}
+
+	case GENERATOR_LEAF_SPINE:
+		if len(nodes) > 0 {
+			return nil, fmt.Errorf("nodes is not valid for %s, use spines and leaves", GENERATOR_LEAF_SPINE)
+		}
+		spines, err := expandList(gConfig.Spines)
+		if err != nil {
+			return nil, err
+		}
+		leaves, err := expandList(gConfig.Leaves)
+		if err != nil {
+			return nil, err
+		}
+		if len(spines) == 0 || len(leaves) == 0 {
+			return nil, fmt.Errorf("spines and leaves are required")
+		}
+
+		for _, leaf := range leaves {
+			for _, spine := range spines {
+				pairs = append(pairs, [2]string{leaf, spine})
+			}
+		}
+
+	default:
+		return nil, fmt.Errorf(
+			"pattern '%s' is not valid (%s, %s, %s or %s expected)", gConfig.Pattern,
+			GENERATOR_LINE, GENERATOR_RING, GENERATOR_FULL_MESH, GENERATOR_LEAF_SPINE)
+	}
+
+	return pairs, nil
+}
+
+// ExpandTopology parses the content of a network file and returns the
+// topology where variables, brace patterns, included modules and
+// generators are expanded. Modules are searched in dir
+func ExpandTopology(data []byte, dir string) (*NetemTopology, error) {
+	chain := []includedFile{newIncludedFile(path.Join(dir, networkFilename), networkFilename)}
+	return expandTopology(data, dir, nil, chain)
+}
+
+// expandTopology expands the network file data, the last file of the
+// include chain. Errors are located in this file
+func expandTopology(data []byte, dir string, variables map[string]interface{}, chain []includedFile) (*NetemTopology, error) {
+	file := chain[len(chain)-1].name
+	data, err := substituteVariables(data, variables)
+	if err != nil {
+		return nil, inFile(file, err)
+	}
+
+	var source topologySource
+	if err := yaml.UnmarshalStrict(data, &source); err != nil {
+		return nil, inFile(file, yamlErrors(data, err))
+	}
+
+	positions := yamlPositions(data)
+	locate := func(key string) Location {
+		location := positions[key]
+		location.File = file
+		return location
+	}
+	fail := func(key string, err error) error {
+		return &TopologyError{locate(key), err}
+	}
+
+	topology := &NetemTopology{
+		Kinds:    make(map[string]options.NodeKind),
+		Nodes:    make(map[string]NodeConfig),
+		Links:    make([]LinkConfig, 0, len(source.Links)),
+		Bridges:  make(map[string]BridgeConfig),
+		HostIfs:  make(map[string]HostIfConfig),
+		Segments: make(map[string]SegmentConfig),
+		origins:  make(map[string]Location),
+	}
+
+	for name, kind := range source.Kinds {
+		topology.Kinds[name] = kind
+		topology.origins["kind:"+name] = locate("kinds/" + name)
+	}
+
+	// nodes are sorted to get the same errors at each check
+	patterns := make([]string, 0, len(source.Nodes))
+	for pattern := range source.Nodes {
+		patterns = append(patterns, pattern)
+	}
+	sort.Strings(patterns)
+	for _, pattern := range patterns {
+		key := "nodes/" + pattern
+		names, err := expandBraces(pattern)
+		if err != nil {
+			return nil, fail(key, err)
+		}
+		for _, name := range names {
+			if _, found := topology.Nodes[name]; found {
+				return nil, fail(key, fmt.Errorf("Node '%s' is defined several times", name))
+			}
+			topology.Nodes[name] = source.Nodes[pattern]
+			topology.origins["node:"+name] = locate(key)
+		}
+	}
+
+	// peers of a link are expanded together, a single peer is
+	// linked to each peer of the other side
+	for idx, lConfig := range source.Links {
+		key := fmt.Sprintf("links/%d", idx)
+		peers1, err := expandBraces(lConfig.Peer1)
+		if err != nil {
+			return nil, fail(key, err)
+		}
+		peers2, err := expandBraces(lConfig.Peer2)
+		if err != nil {
+			return nil, fail(key, err)
+		}
+
+		count := len(peers1)
+		if len(peers2) > count {
+			count = len(peers2)
+		}
+		if (len(peers1) != count && len(peers1) != 1) || (len(peers2) != count && len(peers2) != 1) {
+			return nil, fail(key, fmt.Errorf(
+				"Link %s-%s: peers expand to %d and %d interfaces",
+				lConfig.Peer1, lConfig.Peer2, len(peers1), len(peers2)))
+		}
+
+		for i := 0; i < count; i++ {
+			expanded := lConfig
+			expanded.Peer1 = peers1[i%len(peers1)]
+			expanded.Peer2 = peers2[i%len(peers2)]
+			topology.origins[fmt.Sprintf("link:%d", len(topology.Links))] = locate(key)
+			topology.Links = append(topology.Links, expanded)
+		}
+	}
+
+	for name, bConfig := range source.Bridges {
+		key := "bridges/" + name
+		if bConfig.Interfaces, err = expandList(bConfig.Interfaces); err != nil {
+			return nil, fail(key, fmt.Errorf("Bridge %s: %w", name, err))
+		}
+		topology.Bridges[name] = bConfig
+		topology.origins["bridge:"+name] = locate(key)
+	}
+
+	for name, hConfig := range source.HostIfs {
+		topology.HostIfs[name] = hConfig
+		topology.origins["hostif:"+name] = locate("hostifs/" + name)
+	}
+
+	for name, sConfig := range source.Segments {
+		key := "segments/" + name
+		members := make([]SegmentMember, 0, len(sConfig.Members))
+		for _, member := range sConfig.Members {
+			peers, err := expandBraces(member.Peer)
+			if err != nil {
+				return nil, fail(key, fmt.Errorf("Segment %s: %w", name, err))
+			}
+			for _, peer := range peers {
+				expanded := member
+				expanded.Peer = peer
+				members = append(members, expanded)
+			}
+		}
+		sConfig.Members = members
+		topology.Segments[name] = sConfig
+		topology.origins["segment:"+name] = locate(key)
+	}
+
+	if err := includeModules(topology, source.Include, chain, dir, locate); err != nil {
+		return nil, err
+	}
+
+	// generated links use the first interfaces left free
+	used := usedInterfaces(topology)
+	allocate := func(node string) string {
+		if used[node] == nil {
+			used[node] = make(map[int]bool)
+		}
+		ifIndex := 0
+		for used[node][ifIndex] {
+			ifIndex++
+		}
+		used[node][ifIndex] = true
+		return fmt.Sprintf("%s.%d", node, ifIndex)
+	}
+
+	for idx, gConfig := range source.Generators {
+		key := fmt.Sprintf("generators/%d", idx)
+		pairs, err := generatorPairs(gConfig)
+		if err != nil {
+			return nil, fail(key, fmt.Errorf("Generator %d: %w", idx, err))
+		}
+
+		for _, pair := range pairs {
+			topology.origins[fmt.Sprintf("link:%d", len(topology.Links))] = locate(key)
+			topology.Links = append(topology.Links, LinkConfig{
+				Peer1:      allocate(pair[0]),
+				Peer2:      allocate(pair[1]),
+				LinkParams: gConfig.LinkParams.copy(),
+				Mtu:        gConfig.Mtu,
+				TxQueueLen: gConfig.TxQueueLen,
+			})
+		}
+	}
+
+	return topology, nil
+}
@@ -0,0 +1,238 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerate_ExpandBraces(t *testing.T) {
+	tests := []struct {
+		desc          string
+		value         string
+		expected      []string
+		expectedError bool
+	}{
+		{
+			desc:     "Expand: no pattern",
+			value:    "R1.0",
+			expected: []string{"R1.0"},
+		},
+		{
+			desc:     "Expand: range",
+			value:    "R{1..3}.0",
+			expected: []string{"R1.0", "R2.0", "R3.0"},
+		},
+		{
+			desc:     "Expand: reverse range with zeros",
+			value:    "R{03..01}",
+			expected: []string{"R03", "R02", "R01"},
+		},
+		{
+			desc:     "Expand: list and range",
+			value:    "{a,b}{1..2}",
+			expected: []string{"a1", "a2", "b1", "b2"},
+		},
+		{
+			desc:          "Expand: unbalanced braces",
+			value:         "R{1..3",
+			expectedError: true,
+		},
+		{
+			desc:          "Expand: invalid pattern",
+			value:         "R{1}",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			values, err := expandBraces(tt.value)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Error expected but not found")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(values, tt.expected) {
+				t.Errorf("Wrong expansion: %v != %v", values, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGenerate_ExpandTopology(t *testing.T) {
+	tests := []struct {
+		desc          string
+		network       string
+		expectedNodes int
+		expectedLinks []string
+		expectedError bool
+	}{
+		{
+			desc: "Generate: ring with variable",
+			network: `
+variables:
+  count: 3
+nodes:
+  R{1..$count}:
+    type: docker.router
+links:
+- peer1: R1.0
+  peer2: R2.0
+generators:
+- pattern: ring
+  nodes:
+  - R{1..${count}}`,
+			expectedNodes: 3,
+			expectedLinks: []string{"R1.0-R2.0", "R1.1-R2.1", "R2.2-R3.0", "R3.1-R1.2"},
+		},
+		{
+			desc: "Generate: leaf-spine and zipped links",
+			network: `
+nodes:
+  S{1..2}:
+    type: docker.router
+  L{1..2}:
+    type: docker.router
+  H{1..2}:
+    type: docker.host
+links:
+- peer1: H{1..2}.0
+  peer2: L{1..2}.0
+generators:
+- pattern: leaf-spine
+  spines:
+  - S{1..2}
+  leaves:
+  - L{1..2}`,
+			expectedNodes: 6,
+			expectedLinks: []string{"H1.0-L1.0", "H2.0-L2.0", "L1.1-S1.0", "L1.2-S2.0", "L2.1-S1.1", "L2.2-S2.1"},
+		},
+		{
+			desc: "Generate: undefined variable",
+			network: `
+nodes:
+  R{1..$count}:
+    type: docker.router`,
+			expectedError: true,
+		},
+		{
+			desc: "Generate: link peers with different counts",
+			network: `
+nodes:
+  R{1..3}:
+    type: docker.router
+links:
+- peer1: R{1..2}.0
+  peer2: R{1..3}.1`,
+			expectedError: true,
+		},
+		{
+			desc: "Generate: unknown pattern",
+			network: `
+nodes:
+  R{1..3}:
+    type: docker.router
+generators:
+- pattern: star
+  nodes:
+  - R{1..3}`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			topology, err := ExpandTopology([]byte(tt.network), "")
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Error expected but not found")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if len(topology.Nodes) != tt.expectedNodes {
+				t.Errorf("Wrong number of nodes: %d != %d", len(topology.Nodes), tt.expectedNodes)
+			}
+			links := make([]string, len(topology.Links))
+			for idx, lConfig := range topology.Links {
+				links[idx] = linkName(lConfig)
+			}
+			if !reflect.DeepEqual(links, tt.expectedLinks) {
+				t.Errorf("Wrong links: %v != %v", links, tt.expectedLinks)
+			}
+		})
+	}
+}
+
+func TestGenerate_SubstituteVariables(t *testing.T) {
+	tests := []struct {
+		desc          string
+		data          string
+		overrides     map[string]interface{}
+		expected      string
+		expectedError bool
+	}{
+		{
+			desc:     "Variables: keys and values",
+			data:     "variables:\n  n: 3\n  d: 10\nnodes:\n  R{1..$n}:\n    delay: ${d}ms\n",
+			expected: "variables:\n  n: 3\n  d: 10\nnodes:\n  R{1..3}:\n    delay: 10ms\n",
+		},
+		{
+			desc:      "Variables: overrides",
+			data:      "variables:\n  d: 10\ndelay: $d\n",
+			overrides: map[string]interface{}{"d": 20},
+			expected:  "variables:\n  d: 10\ndelay: 20\n",
+		},
+		{
+			desc:     "Variables: comments are kept",
+			data:     "# delay: $d\ndelay: 10 # $d ms\n",
+			expected: "# delay: $d\ndelay: 10 # $d ms\n",
+		},
+		{
+			desc:     "Variables: escape",
+			data:     "variables:\n  d: 10\ncmd: echo $$HOME $$$d\n",
+			expected: "variables:\n  d: 10\ncmd: echo $HOME $10\n",
+		},
+		{
+			desc:     "Variables: quoted values",
+			data:     "variables:\n  d: 10\nsingle: 'it''s $d'\ndouble: \"\\\"$d\\\"\" # $d\n",
+			expected: "variables:\n  d: 10\nsingle: 'it''s 10'\ndouble: \"\\\"10\\\"\" # $d\n",
+		},
+		{
+			desc:     "Variables: multi-line values are kept",
+			data:     "cmd: |\n  echo $HOME\n",
+			expected: "cmd: |\n  echo $HOME\n",
+		},
+		{
+			desc:          "Variables: undefined variable",
+			data:          "delay: $d\n",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			data, err := substituteVariables([]byte(tt.data), tt.overrides)
+			if err != nil {
+				if !tt.expectedError {
+					t.Errorf("substituteVariables returns an unexpected error: %v", err)
+				}
+				return
+			} else if tt.expectedError {
+				t.Errorf("substituteVariables does not return an error")
+				return
+			}
+
+			if string(data) != tt.expected {
+				t.Errorf("Wrong substitution '%s' != '%s'", data, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/mroy31/gonetem/internal/link"
+	"github.com/mroy31/gonetem/internal/options"
+	"github.com/vishvananda/netns"
+)
+
+var (
+	// host interfaces moved in the nodes of the running projects, they
+	// are no longer in the root netns
+	movedHostIfs    = make(map[string]bool)
+	movedHostIfLock = &sync.Mutex{}
+)
+
+func setHostIfMoved(host string, moved bool) {
+	movedHostIfLock.Lock()
+	defer movedHostIfLock.Unlock()
+
+	if moved {
+		movedHostIfs[host] = true
+	} else {
+		delete(movedHostIfs, host)
+	}
+}
+
+func isHostIfMoved(host string) bool {
+	movedHostIfLock.Lock()
+	defer movedHostIfLock.Unlock()
+
+	return movedHostIfs[host]
+}
+
+// hostIfTmpName returns the name of a host interface while it is moved
+// from a namespace to another one
+func (t *NetemTopologyManager) hostIfTmpName(hostIf *NetemHostIf) string {
+	return fmt.Sprintf(
+		"%s%s%s.%d", options.NETEM_ID, t.prjID,
+		hostIf.Peer.Node.GetShortName(), hostIf.Peer.IfIndex)
+}
+
+// returnHostIf moves back the host interface ifName of the node in the
+// root netns, with its original name
+func (t *NetemTopologyManager) returnHostIf(hostIf *NetemHostIf, ifName string, nodeNs netns.NsHandle) error {
+	rootNs := link.GetRootNetns()
+	defer rootNs.Close()
+
+	tmpName := t.hostIfTmpName(hostIf)
+	if err := link.MoveLink(ifName, tmpName, nodeNs, rootNs); err != nil {
+		return err
+	}
+	setHostIfMoved(hostIf.Host, false)
+	return link.RenameLink(tmpName, hostIf.Host, rootNs)
+}
+
+// attachHostIf moves the host interface in its node, or creates a child of
+// it in the node, according to the mode. The node must be running
+func (t *NetemTopologyManager) attachHostIf(hostIf *NetemHostIf) error {
+	if hostIf.attached {
+		return nil
+	}
+
+	rootNs := link.GetRootNetns()
+	defer rootNs.Close()
+
+	nodeNs, err := hostIf.Peer.Node.GetNetns()
+	if err != nil {
+		return err
+	}
+	defer nodeNs.Close()
+
+	tmpName := t.hostIfTmpName(hostIf)
+	if hostIf.Mode == HOSTIF_MOVE {
+		err = link.MoveLink(hostIf.Host, tmpName, rootNs, nodeNs)
+	} else {
+		err = link.CreateChildLink(hostIf.Mode, tmpName, hostIf.Host, rootNs, nodeNs)
+	}
+	if err != nil {
+		return fmt.Errorf(
+			"Unable to attach host interface %s to %s.%d: %v",
+			hostIf.Host, hostIf.Peer.Node.GetName(), hostIf.Peer.IfIndex, err)
+	}
+
+	if hostIf.Mode == HOSTIF_MOVE {
+		setHostIfMoved(hostIf.Host, true)
+	}
+
+	if err := hostIf.Peer.Node.AddInterface(tmpName, hostIf.Peer.IfIndex, nodeNs); err != nil {
+		// the host must not lose its interface, which may be renamed
+		if hostIf.Mode == HOSTIF_MOVE {
+			ifName := tmpName
+			if !link.IsLinkExist(ifName, nodeNs) {
+				ifName = hostIf.Peer.Node.GetInterfaceName(hostIf.Peer.IfIndex)
+			}
+			if rErr := t.returnHostIf(hostIf, ifName, nodeNs); rErr != nil {
+				t.logger.Errorf("Unable to return host interface %s: %v", hostIf.Host, rErr)
+			}
+		}
+		return err
+	}
+	hostIf.attached = true
+
+	return nil
+}
+
+// detachHostIf gives back to the host an interface moved in a node, or
+// deletes the child created in the node
+func (t *NetemTopologyManager) detachHostIf(hostIf *NetemHostIf) error {
+	if !hostIf.attached {
+		return nil
+	}
+
+	node := hostIf.Peer.Node
+	if hostIf.Mode == HOSTIF_MOVE {
+		nodeNs, err := node.GetNetns()
+		if err != nil {
+			return err
+		}
+		defer nodeNs.Close()
+
+		ifName := node.GetInterfaceName(hostIf.Peer.IfIndex)
+		if err := t.returnHostIf(hostIf, ifName, nodeNs); err != nil {
+			return fmt.Errorf("Unable to return host interface %s: %v", hostIf.Host, err)
+		}
+	}
+
+	// the moved interface is no longer in the node, only the child is deleted
+	if err := node.RemoveInterface(hostIf.Peer.IfIndex); err != nil {
+		return err
+	}
+	hostIf.attached = false
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+const hostIfNodes = `
+nodes:
+  R1:
+    type: docker.router
+  host:
+    type: netns
+  sw:
+    type: ovs
+`
+
+func TestHostIf_CheckTopology(t *testing.T) {
+	tests := []struct {
+		desc          string
+		network       string
+		expectedError bool
+	}{
+		{
+			desc: "HostIf: moved interface and children",
+			network: hostIfNodes + `
+hostifs:
+  uplink:
+    host: lo
+    interface: R1.0
+  lan:
+    host: lo
+    interface: host.0
+    mode: macvlan`,
+			expectedError: true,
+		},
+		{
+			desc: "HostIf: children of the same interface",
+			network: hostIfNodes + `
+hostifs:
+  uplink:
+    host: lo
+    interface: R1.0
+    mode: ipvlan
+  lan:
+    host: lo
+    interface: host.0
+    mode: ipvlan`,
+		},
+		{
+			desc: "HostIf: interface of a bridge",
+			network: hostIfNodes + `
+bridges:
+  br:
+    host: lo
+    interfaces: [R1.1]
+hostifs:
+  uplink:
+    host: lo
+    interface: R1.0
+    mode: macvlan`,
+			expectedError: true,
+		},
+		{
+			desc: "HostIf: node interface already linked",
+			network: hostIfNodes + `
+links:
+- peer1: R1.0
+  peer2: host.0
+hostifs:
+  uplink:
+    host: lo
+    interface: R1.0`,
+			expectedError: true,
+		},
+		{
+			desc: "HostIf: ovs node",
+			network: hostIfNodes + `
+hostifs:
+  uplink:
+    host: lo
+    interface: sw.0`,
+			expectedError: true,
+		},
+		{
+			desc: "HostIf: invalid mode",
+			network: hostIfNodes + `
+hostifs:
+  uplink:
+    host: lo
+    interface: R1.0
+    mode: vlan`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("/tmp", "ntmtst")
+			if err != nil {
+				t.Fatalf("Unable to create temp folder: %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			filepath := path.Join(dir, networkFilename)
+			if err := ioutil.WriteFile(filepath, []byte(tt.network), 0644); err != nil {
+				t.Fatalf("Unable to create network file: %v", err)
+			}
+
+			_, errors := CheckTopology(filepath)
+			if len(errors) > 0 && !tt.expectedError {
+				t.Fatalf("Unexpected errors: %v", errors)
+			} else if len(errors) == 0 && tt.expectedError {
+				t.Fatalf("An error is expected")
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package server
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/mroy31/gonetem/internal/options"
+)
+
+// name of the modules of the library in errors
+const libraryPrefix = "library:"
+
+var moduleRE = regexp.MustCompile(`^[\w\-]+$`)
+
+// IncludeConfig includes the topology of another network file, from the
+// project (File) or from the library of the server (Library). The names
+// of its nodes, bridges and segments are prefixed by Prefix and
+// Interfaces maps aliases usable as peers in the including file to
+// interfaces of the module
+type IncludeConfig struct {
+	File       string                 `yaml:",omitempty"`
+	Library    string                 `yaml:",omitempty"`
+	Prefix     string                 `yaml:",omitempty"`
+	Variables  map[string]interface{} `yaml:",omitempty"`
+	Interfaces map[string]string      `yaml:",omitempty"`
+}
+
+// modulePath returns the path of the module file, the directory used to
+// search its own includes and the name of the module in errors, parent
+// is the name of the including file
+func modulePath(iConfig IncludeConfig, parent, dir string) (string, string, string, error) {
+	if (iConfig.File == "") == (iConfig.Library == "") {
+		return "", "", "", fmt.Errorf("Include: one of file or library is required")
+	}
+
+	if iConfig.Library != "" {
+		if !moduleRE.MatchString(iConfig.Library) {
+			return "", "", "", fmt.Errorf("Include: '%s' is not a valid library module", iConfig.Library)
+		}
+		libDir := options.ServerConfig.Library
+		return path.Join(libDir, iConfig.Library+".yml"), libDir, libraryPrefix + iConfig.Library + ".yml", nil
+	}
+
+	// files are relative to the including file and must stay inside
+	// its directory
+	name := path.Clean(iConfig.File)
+	if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+		return "", "", "", fmt.Errorf("Include: file '%s' is outside of the project", iConfig.File)
+	}
+	filepath := path.Join(dir, name)
+	if strings.HasPrefix(parent, libraryPrefix) {
+		name = libraryPrefix + path.Join(path.Dir(strings.TrimPrefix(parent, libraryPrefix)), name)
+	} else {
+		name = path.Join(path.Dir(parent), name)
+	}
+	return filepath, path.Dir(filepath), name, nil
+}
+
+// includedFile is a network file of the chain of includes being
+// expanded, from the project network file to the current module
+type includedFile struct {
+	path string // absolute path, used to detect cycles
+	name string // name in errors
+}
+
+// newIncludedFile returns the network file filePath, named name in errors
+func newIncludedFile(filePath, name string) includedFile {
+	if absPath, err := filepath.Abs(filePath); err == nil {
+		filePath = absPath
+	}
+	return includedFile{path: filePath, name: name}
+}
+
+// includeCycle returns the names of the files of the cycle made by
+// the inclusion of module in the last file of chain, nil if there
+// is no cycle
+func includeCycle(chain []includedFile, module includedFile) []string {
+	for idx, included := range chain {
+		if included.path == module.path {
+			cycle := make([]string, 0, len(chain)-idx+1)
+			for _, f := range chain[idx:] {
+				cycle = append(cycle, f.name)
+			}
+			return append(cycle, module.name)
+		}
+	}
+	return nil
+}
+
+func prefixPeer(prefix, peer string) string {
+	if prefix == "" || peer == "" {
+		return peer
+	}
+	return prefix + peer
+}
+
+// includeModules expands the included modules and merges them in
+// topology. Peers of topology equal to an alias of a module are
+// replaced by the mapped interface
+func includeModules(topology *NetemTopology, includes []IncludeConfig, chain []includedFile, dir string, locate func(string) Location) error {
+	file := chain[len(chain)-1].name
+	aliases := make(map[string]string)
+	for idx, iConfig := range includes {
+		key := fmt.Sprintf("include/%d", idx)
+		fail := func(err error) error {
+			return &TopologyError{locate(key), err}
+		}
+
+		filePath, moduleDir, name, err := modulePath(iConfig, file, dir)
+		if err != nil {
+			return fail(err)
+		}
+		included := newIncludedFile(filePath, name)
+		if cycle := includeCycle(chain, included); cycle != nil {
+			return fail(fmt.Errorf("Include cycle: %s", strings.Join(cycle, " -> ")))
+		}
+		modChain := append(append([]includedFile{}, chain...), included)
+		if iConfig.Prefix != "" && !nameRE.MatchString(iConfig.Prefix) {
+			return fail(fmt.Errorf("Include %s: prefix '%s' is not valid", name, iConfig.Prefix))
+		}
+
+		data, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			return fail(fmt.Errorf("Include %s: %w", name, err))
+		}
+		// errors of the module are located in its file
+		module, err := expandTopology(data, moduleDir, iConfig.Variables, modChain)
+		if err != nil {
+			return err
+		}
+
+		for kName, kind := range module.Kinds {
+			if current, found := topology.Kinds[kName]; found && !reflect.DeepEqual(current, kind) {
+				return fail(fmt.Errorf("Include %s: kind '%s' is defined differently", name, kName))
+			}
+			topology.Kinds[kName] = kind
+			topology.origins["kind:"+kName] = module.origins["kind:"+kName]
+		}
+
+		for nName, nConfig := range module.Nodes {
+			fullName := prefixPeer(iConfig.Prefix, nName)
+			if _, found := topology.Nodes[fullName]; found {
+				return fail(fmt.Errorf("Include %s: node '%s' is defined several times", name, fullName))
+			}
+			topology.Nodes[fullName] = nConfig
+			topology.origins["node:"+fullName] = module.origins["node:"+nName]
+		}
+
+		for lIdx, lConfig := range module.Links {
+			lConfig.Peer1 = prefixPeer(iConfig.Prefix, lConfig.Peer1)
+			lConfig.Peer2 = prefixPeer(iConfig.Prefix, lConfig.Peer2)
+			topology.origins[fmt.Sprintf("link:%d", len(topology.Links))] = module.origins[fmt.Sprintf("link:%d", lIdx)]
+			topology.Links = append(topology.Links, lConfig)
+		}
+
+		for bName, bConfig := range module.Bridges {
+			fullName := prefixPeer(iConfig.Prefix, bName)
+			if _, found := topology.Bridges[fullName]; found {
+				return fail(fmt.Errorf("Include %s: bridge '%s' is defined several times", name, fullName))
+			}
+			interfaces := make([]string, len(bConfig.Interfaces))
+			for i, peer := range bConfig.Interfaces {
+				interfaces[i] = prefixPeer(iConfig.Prefix, peer)
+			}
+			bConfig.Interfaces = interfaces
+			topology.Bridges[fullName] = bConfig
+			topology.origins["bridge:"+fullName] = module.origins["bridge:"+bName]
+		}
+
+		// names of host interfaces and their node interface are prefixed,
+		// the interface of the host (Host) is not, it belongs to the host
+		for hName, hConfig := range module.HostIfs {
+			fullName := prefixPeer(iConfig.Prefix, hName)
+			if _, found := topology.HostIfs[fullName]; found {
+				return fail(fmt.Errorf("Include %s: host interface '%s' is defined several times", name, fullName))
+			}
+			hConfig.Interface = prefixPeer(iConfig.Prefix, hConfig.Interface)
+			topology.HostIfs[fullName] = hConfig
+			topology.origins["hostif:"+fullName] = module.origins["hostif:"+hName]
+		}
+
+		for sName, sConfig := range module.Segments {
+			fullName := prefixPeer(iConfig.Prefix, sName)
+			if _, found := topology.Segments[fullName]; found {
+				return fail(fmt.Errorf("Include %s: segment '%s' is defined several times", name, fullName))
+			}
+			members := make([]SegmentMember, len(sConfig.Members))
+			for i, member := range sConfig.Members {
+				member.Peer = prefixPeer(iConfig.Prefix, member.Peer)
+				members[i] = member
+			}
+			sConfig.Members = members
+			topology.Segments[fullName] = sConfig
+			topology.origins["segment:"+fullName] = module.origins["segment:"+sName]
+		}
+
+		for alias, peer := range iConfig.Interfaces {
+			if !nameRE.MatchString(alias) {
+				return fail(fmt.Errorf("Include %s: interface alias '%s' is not valid", name, alias))
+			}
+			if _, found := aliases[alias]; found {
+				return fail(fmt.Errorf("Include %s: interface alias '%s' is defined several times", name, alias))
+			}
+			if !peerRE.MatchString(peer) {
+				return fail(fmt.Errorf("Include %s: invalid format for interface '%s' (<node>.<ifIndex> required)", name, peer))
+			}
+			aliases[alias] = prefixPeer(iConfig.Prefix, peer)
+		}
+	}
+
+	// aliases have no '.', they can not match an interface
+	resolve := func(peer string) string {
+		if mapped, found := aliases[peer]; found {
+			return mapped
+		}
+		return peer
+	}
+	for idx := range topology.Links {
+		topology.Links[idx].Peer1 = resolve(topology.Links[idx].Peer1)
+		topology.Links[idx].Peer2 = resolve(topology.Links[idx].Peer2)
+	}
+	for _, bConfig := range topology.Bridges {
+		for idx, peer := range bConfig.Interfaces {
+			bConfig.Interfaces[idx] = resolve(peer)
+		}
+	}
+	for hName, hConfig := range topology.HostIfs {
+		hConfig.Interface = resolve(hConfig.Interface)
+		topology.HostIfs[hName] = hConfig
+	}
+	for _, sConfig := range topology.Segments {
+		for idx, member := range sConfig.Members {
+			sConfig.Members[idx].Peer = resolve(member.Peer)
+		}
+	}
+
+	return nil
+}
+
+// withOrigin locates err at the position of the element key in the
+// network files
+func (t *NetemTopology) withOrigin(key string, err error) error {
+	if location, found := t.origins[key]; found {
+		return &TopologyError{location, err}
+	}
+	return err
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const includeModule = `
+variables:
+  delay: 1
+nodes:
+  R:
+    type: docker.router
+  host:
+    type: docker.host
+links:
+- peer1: host.0
+  peer2: R.0
+  delay: $delay`
+
+const includeUplink = `
+nodes:
+  R:
+    type: docker.router
+hostifs:
+  up:
+    host: eth0
+    interface: R.0`
+
+func TestInclude_ExpandTopology(t *testing.T) {
+	tests := []struct {
+		desc            string
+		network         string
+		expectedNodes   []string
+		expectedLinks   []string
+		expectedHostIfs map[string]HostIfConfig
+		expectedError   bool
+		expectedMessage string
+	}{
+		{
+			desc: "Include: prefix and interface alias",
+			network: `
+nodes:
+  core:
+    type: docker.router
+links:
+- peer1: core.0
+  peer2: a_uplink
+include:
+- file: modules/site.yml
+  prefix: a_
+  interfaces:
+    a_uplink: R.1
+- file: modules/site.yml
+  prefix: b_
+  variables:
+    delay: 10`,
+			expectedNodes: []string{"a_R", "a_host", "b_R", "b_host", "core"},
+			expectedLinks: []string{"core.0-a_R.1", "a_host.0-a_R.0", "b_host.0-b_R.0"},
+		},
+		{
+			desc: "Include: host interface",
+			network: `
+include:
+- file: modules/uplink.yml
+  prefix: a_`,
+			expectedNodes:   []string{"a_R"},
+			expectedLinks:   []string{},
+			expectedHostIfs: map[string]HostIfConfig{"a_up": {Host: "eth0", Interface: "a_R.0"}},
+		},
+		{
+			desc: "Include: duplicate node",
+			network: `
+include:
+- file: modules/site.yml
+- file: modules/site.yml`,
+			expectedError: true,
+		},
+		{
+			desc: "Include: file outside of the project",
+			network: `
+include:
+- file: ../site.yml`,
+			expectedError: true,
+		},
+		{
+			desc: "Include: cycle",
+			network: `
+include:
+- file: network.yml`,
+			expectedError:   true,
+			expectedMessage: "Include cycle: network.yml -> network.yml",
+		},
+		{
+			desc: "Include: indirect cycle",
+			network: `
+include:
+- file: modules/loop_a.yml`,
+			expectedError:   true,
+			expectedMessage: "Include cycle: modules/loop_a.yml -> modules/loop_b.yml -> modules/loop_a.yml",
+		},
+		{
+			desc: "Include: same module in nested includes",
+			network: `
+include:
+- file: modules/nested.yml
+- file: modules/site.yml
+  prefix: b_`,
+			expectedNodes: []string{"a_R", "a_host", "b_R", "b_host"},
+			expectedLinks: []string{"a_host.0-a_R.0", "b_host.0-b_R.0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("/tmp", "ntmtst")
+			if err != nil {
+				t.Fatalf("Unable to create temp folder: %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			if err := os.Mkdir(path.Join(dir, "modules"), 0755); err != nil {
+				t.Fatalf("Unable to create modules folder: %v", err)
+			}
+			files := map[string]string{
+				"modules/site.yml":   includeModule,
+				"modules/uplink.yml": includeUplink,
+				"modules/loop_a.yml": "include:\n- file: loop_b.yml",
+				"modules/loop_b.yml": "include:\n- file: loop_a.yml",
+				"modules/nested.yml": "include:\n- file: site.yml\n  prefix: a_",
+				"network.yml":        tt.network,
+			}
+			for name, content := range files {
+				if err := ioutil.WriteFile(path.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatalf("Unable to create file %s: %v", name, err)
+				}
+			}
+
+			topology, err := ExpandTopology([]byte(tt.network), dir)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Error expected but not found")
+				} else if !strings.Contains(err.Error(), tt.expectedMessage) {
+					t.Errorf("Wrong error: '%v' does not contain '%s'", err, tt.expectedMessage)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			nodes := make([]string, 0, len(topology.Nodes))
+			for name := range topology.Nodes {
+				nodes = append(nodes, name)
+			}
+			sort.Strings(nodes)
+			if !reflect.DeepEqual(nodes, tt.expectedNodes) {
+				t.Errorf("Wrong nodes: %v != %v", nodes, tt.expectedNodes)
+			}
+			links := make([]string, len(topology.Links))
+			for idx, lConfig := range topology.Links {
+				links[idx] = linkName(lConfig)
+			}
+			if !reflect.DeepEqual(links, tt.expectedLinks) {
+				t.Errorf("Wrong links: %v != %v", links, tt.expectedLinks)
+			}
+			if tt.expectedHostIfs != nil && !reflect.DeepEqual(topology.HostIfs, tt.expectedHostIfs) {
+				t.Errorf("Wrong host interfaces: %v != %v", topology.HostIfs, tt.expectedHostIfs)
+			}
+			if _, found := topology.Nodes["b_R"]; found {
+				if o := topology.origins["node:b_R"].String(); o != "modules/site.yml:5:3" {
+					t.Errorf("Wrong origin of node b_R: '%s'", o)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mroy31/gonetem/internal/options"
+)
+
+var (
+	ethIfRE  = regexp.MustCompile(`^eth(\d+)(\.\d+)?$`)
+	vrrpIfRE = regexp.MustCompile(`^vrrp-(\d+)$`)
+)
+
+// nodeConfigFiles returns the suffixes of the configuration files used by
+// a type of node, see DockerNode.LoadConfig, OvsProjectInstance.LoadConfig
+// and NetnsNode.LoadConfig, a bridge node has no file
+func nodeConfigFiles(nType string, kinds map[string]options.NodeKind) []string {
+	switch nType {
+	case "ovs":
+		return []string{"conf"}
+	case "netns":
+		return []string{"net.conf"}
+	case "bridge":
+		return []string{}
+	}
+
+	suffixes := []string{"init.conf"}
+	if kind, found := options.GetNodeKind(nodeKind(nType), kinds); found {
+		for _, file := range kind.Files {
+			suffixes = append(suffixes, file.Name)
+		}
+	}
+	return suffixes
+}
+
+type lintAddress struct {
+	node     string
+	ifName   string
+	ip       net.IP
+	network  *net.IPNet
+	location Location
+}
+
+func (a lintAddress) String() string {
+	return fmt.Sprintf("%s/%s", a.node, a.ifName)
+}
+
+type projectLinter struct {
+	topology  *NetemTopology
+	linked    map[string]map[int]bool
+	addresses []lintAddress
+	errors    []error
+}
+
+func (l *projectLinter) add(location Location, format string, a ...interface{}) {
+	l.errors = append(l.errors, &TopologyError{location, fmt.Errorf(format, a...)})
+}
+
+// checkInterface checks that an interface referenced by a configuration
+// file exists on the node
+func (l *projectLinter) checkInterface(node, ifName string, location Location) {
+	if match := ethIfRE.FindStringSubmatch(ifName); match != nil {
+		ifIndex, _ := strconv.Atoi(match[1])
+		if !l.linked[node][ifIndex] {
+			l.add(location, "Interface %s of node %s has no link", ifName, node)
+		}
+	} else if match := vrrpIfRE.FindStringSubmatch(ifName); match != nil {
+		ifIndex, _ := strconv.Atoi(match[1])
+		for _, vrrp := range l.topology.Nodes[node].Vrrps {
+			if vrrp.Interface == ifIndex {
+				return
+			}
+		}
+		l.add(location, "Interface %s of node %s is not declared in vrrps", ifName, node)
+	}
+}
+
+func (l *projectLinter) addAddress(node, ifName, address string, location Location) {
+	ip, network, err := net.ParseCIDR(address)
+	if err != nil {
+		l.add(location, "Address '%s' of %s/%s is not valid", address, node, ifName)
+		return
+	}
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+		return
+	}
+	l.addresses = append(l.addresses, lintAddress{node, ifName, ip, network, location})
+}
+
+// lintNodes checks the interfaces declared in the topology
+func (l *projectLinter) lintNodes() {
+	for _, name := range sortedKeys(l.topology.Nodes) {
+		nConfig := l.topology.Nodes[name]
+		if len(l.linked[name]) == 0 {
+			l.errors = append(l.errors, l.topology.withOrigin("node:"+name, fmt.Errorf("Node %s has no link", name)))
+		}
+		for _, vrrp := range nConfig.Vrrps {
+			if !l.linked[name][vrrp.Interface] {
+				l.errors = append(l.errors, l.topology.withOrigin("node:"+name, fmt.Errorf(
+					"Node %s: vrrp group %d uses interface eth%d which has no link", name, vrrp.Group, vrrp.Interface)))
+			}
+		}
+	}
+}
+
+// lintNetConfig checks a configuration file of network-config.py
+func (l *projectLinter) lintNetConfig(node string, data []byte, file string) {
+	var config struct {
+		Interfaces map[string][]string
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		l.add(Location{File: file}, "Unable to parse file: %v", err)
+		return
+	}
+
+	for _, ifName := range sortedKeys(config.Interfaces) {
+		location := Location{File: file, Line: lineOf(data, `"`+ifName+`"`)}
+		if len(config.Interfaces[ifName]) > 0 {
+			l.checkInterface(node, ifName, location)
+		}
+		for _, address := range config.Interfaces[ifName] {
+			l.addAddress(node, ifName, address, Location{File: file, Line: lineOf(data, `"`+address+`"`)})
+		}
+	}
+}
+
+// lintFrrConfig checks the interfaces and the addresses of a FRR
+// configuration file
+func (l *projectLinter) lintFrrConfig(node string, data []byte, file string) {
+	ifName := ""
+	for idx, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		location := Location{File: file, Line: idx + 1}
+
+		// an interface section ends with the first line not indented
+		if !strings.HasPrefix(line, " ") {
+			ifName = ""
+			if fields[0] == "interface" && len(fields) > 1 {
+				ifName = fields[1]
+				l.checkInterface(node, ifName, location)
+			}
+			continue
+		}
+		if ifName != "" && len(fields) > 2 && (fields[0] == "ip" || fields[0] == "ipv6") && fields[1] == "address" {
+			l.addAddress(node, ifName, fields[2], location)
+		}
+	}
+}
+
+// lintConfigs checks the configuration files saved in dir
+func (l *projectLinter) lintConfigs(dir string) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			l.add(Location{File: configDir}, "Unable to read folder: %v", err)
+		}
+		return
+	}
+
+	for _, f := range files {
+		file := path.Join(configDir, f.Name())
+		split := strings.SplitN(f.Name(), ".", 2)
+		if f.IsDir() || len(split) != 2 {
+			continue
+		}
+		node, suffix := split[0], split[1]
+
+		nConfig, found := l.topology.Nodes[node]
+		if !found {
+			l.add(Location{File: file}, "Node %s does not exist", node)
+			continue
+		}
+		if !isEntryExist(nodeConfigFiles(nConfig.Type, l.topology.Kinds), suffix) {
+			l.add(Location{File: file}, "File is not used by node %s of type %s", node, nConfig.Type)
+			continue
+		}
+
+		data, err := ioutil.ReadFile(path.Join(dir, f.Name()))
+		if err != nil {
+			l.add(Location{File: file}, "Unable to read file: %v", err)
+			continue
+		}
+		switch suffix {
+		case "net.conf":
+			l.lintNetConfig(node, data, file)
+		case "frr.conf":
+			l.lintFrrConfig(node, data, file)
+		}
+	}
+}
+
+// lintAddresses looks for duplicated addresses and for subnets used on
+// several networks or overlapping, domains gives the L2 network of each
+// interface
+func (l *projectLinter) lintAddresses(domains func(string) string) {
+	reported := make(map[string]bool)
+	report := func(key string, location Location, format string, a ...interface{}) {
+		if !reported[key] {
+			reported[key] = true
+			l.add(location, format, a...)
+		}
+	}
+
+	for i, a := range l.addresses {
+		for _, b := range l.addresses[:i] {
+			if a.ip.Equal(b.ip) {
+				report("ip:"+a.String()+b.String(), a.location, "Address %s of %s is also used by %s", a.ip, a, b)
+			}
+		}
+	}
+
+	// only the addresses of the interfaces connected by links, bridges
+	// or segments are compared
+	for i, a := range l.addresses {
+		match := ethIfRE.FindStringSubmatch(a.ifName)
+		if match == nil || match[2] != "" {
+			continue
+		}
+		if ones, bits := a.network.Mask.Size(); ones == bits {
+			continue
+		}
+		domainA := domains(a.node + "." + match[1])
+
+		for _, b := range l.addresses[:i] {
+			matchB := ethIfRE.FindStringSubmatch(b.ifName)
+			if matchB == nil || matchB[2] != "" {
+				continue
+			}
+			if ones, bits := b.network.Mask.Size(); ones == bits {
+				continue
+			}
+			domainB := domains(b.node + "." + matchB[1])
+
+			sameNetwork := a.network.String() == b.network.String()
+			switch {
+			case sameNetwork && domainA != domainB:
+				report("net:"+a.network.String()+domainA+domainB, a.location,
+					"Subnet %s of %s is also used by %s on another network", a.network, a, b)
+			case !sameNetwork && (a.network.Contains(b.ip) || b.network.Contains(a.ip)):
+				report("overlap:"+a.network.String()+b.network.String(), a.location,
+					"Subnet %s of %s overlaps subnet %s of %s", a.network, a, b.network, b)
+			}
+		}
+	}
+}
+
+// l2Domains returns a function giving, for an interface <node>.<ifIndex>,
+// an identifier of the L2 network it is connected to
+func l2Domains(topology *NetemTopology) func(string) string {
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(peer string) string {
+		p, found := parent[peer]
+		if !found || p == peer {
+			return peer
+		}
+		root := find(p)
+		parent[peer] = root
+		return root
+	}
+	union := func(a, b string) {
+		rootA, rootB := find(a), find(b)
+		if rootA != rootB {
+			parent[rootA] = rootB
+		}
+	}
+
+	for _, lConfig := range topology.Links {
+		union(lConfig.Peer1, lConfig.Peer2)
+	}
+	for name, bConfig := range topology.Bridges {
+		for _, peer := range bConfig.Interfaces {
+			union(peer, "bridge:"+name)
+		}
+	}
+	// children of the same host interface share its network
+	for _, hConfig := range topology.HostIfs {
+		union(hConfig.Interface, "host:"+hConfig.Host)
+	}
+	for name, sConfig := range topology.Segments {
+		for _, member := range sConfig.Members {
+			union(member.Peer, "segment:"+name)
+		}
+	}
+	// a switch connects all its interfaces
+	for node, interfaces := range usedInterfaces(topology) {
+		if nType := topology.Nodes[node].Type; nType != "ovs" && nType != "bridge" {
+			continue
+		}
+		for ifIndex := range interfaces {
+			union(fmt.Sprintf("%s.%d", node, ifIndex), "switch:"+node)
+		}
+	}
+
+	return find
+}
+
+// lineOf returns the first line of data containing value, 0 if not found
+func lineOf(data []byte, value string) int {
+	idx := strings.Index(string(data), value)
+	if idx < 0 {
+		return 0
+	}
+	return strings.Count(string(data[:idx]), "\n") + 1
+}
+
+// LintProject checks the topology of the project stored in prjPath with
+// the configuration files saved in the project
+func LintProject(prjPath string) []error {
+	topology, errors := CheckTopology(path.Join(prjPath, networkFilename))
+	if len(errors) > 0 {
+		return errors
+	}
+
+	l := &projectLinter{
+		topology:  topology,
+		linked:    usedInterfaces(topology),
+		addresses: make([]lintAddress, 0),
+		errors:    make([]error, 0),
+	}
+	l.lintNodes()
+	l.lintConfigs(path.Join(prjPath, configDir))
+	l.lintAddresses(l2Domains(topology))
+
+	sortErrors(l.errors)
+	return l.errors
+}
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+)
+
+const lintNetwork = `
+nodes:
+  R1:
+    type: docker.router
+    vrrps:
+    - interface: 3
+      group: 1
+      address: 192.168.1.253/24
+  R2:
+    type: docker.router
+  host:
+    type: docker.host
+  lonely:
+    type: docker.host
+  sw:
+    type: ovs
+links:
+- peer1: R1.0
+  peer2: R2.0
+- peer1: host.0
+  peer2: sw.0
+- peer1: R1.1
+  peer2: sw.1`
+
+var lintConfigs = map[string]string{
+	"R1.frr.conf": `interface eth0
+ ip address 10.0.0.1/24
+!
+interface eth1
+ ip address 192.168.1.254/24
+!
+interface eth2
+!`,
+	"R2.frr.conf": `interface eth0
+ ip address 10.0.0.1/24
+!`,
+	"host.net.conf": `{
+    "interfaces": {
+        "eth0": ["192.168.1.1/24"],
+        "eth1": ["10.0.0.5/16"]
+    },
+    "routes": []
+}`,
+	"host.frr.conf": "",
+	"old.frr.conf":  "",
+}
+
+func TestLint_Project(t *testing.T) {
+	expected := []string{
+		"configs/R1.frr.conf:7: Interface eth2 of node R1 has no link",
+		"configs/R2.frr.conf:2: Address 10.0.0.1 of R2/eth0 is also used by R1/eth0",
+		"configs/host.frr.conf: File is not used by node host of type docker.host",
+		"configs/host.net.conf:4: Interface eth1 of node host has no link",
+		"configs/host.net.conf:4: Subnet 10.0.0.0/16 of host/eth1 overlaps subnet 10.0.0.0/24 of R1/eth0",
+		"configs/old.frr.conf: Node old does not exist",
+		"network.yml:3:3: Node R1: vrrp group 1 uses interface eth3 which has no link",
+		"network.yml:13:3: Node lonely has no link",
+	}
+
+	dir, err := ioutil.TempDir("/tmp", "ntmtst")
+	if err != nil {
+		t.Fatalf("Unable to create temp folder: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(path.Join(dir, networkFilename), []byte(lintNetwork), 0644); err != nil {
+		t.Fatalf("Unable to create network file: %v", err)
+	}
+	if err := os.Mkdir(path.Join(dir, configDir), 0755); err != nil {
+		t.Fatalf("Unable to create configs folder: %v", err)
+	}
+	for name, content := range lintConfigs {
+		if err := ioutil.WriteFile(path.Join(dir, configDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Unable to create config file %s: %v", name, err)
+		}
+	}
+
+	issues := make([]string, 0)
+	for _, err := range LintProject(dir) {
+		issues = append(issues, err.Error())
+	}
+	if strings.Join(issues, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Wrong issues:\n%s\n!=\n%s", strings.Join(issues, "\n"), strings.Join(expected, "\n"))
+	}
+}
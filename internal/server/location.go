@@ -0,0 +1,182 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+var (
+	yamlLineRE    = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	yamlUnknownRE = regexp.MustCompile(`field (\S+) not found in type \S+`)
+)
+
+// Location is a position in a network file, line and column start at 1
+type Location struct {
+	File   string
+	Line   int
+	Column int
+}
+
+func (l Location) String() string {
+	switch {
+	case l.Line == 0:
+		return l.File
+	case l.Column == 0:
+		return fmt.Sprintf("%s:%d", l.File, l.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+}
+
+// TopologyError is an error found at a location of a network file
+type TopologyError struct {
+	Location
+	Err error
+}
+
+func (e *TopologyError) Error() string {
+	if e.File == "" && e.Line == 0 {
+		return e.Err.Error()
+	}
+	return e.Location.String() + ": " + e.Err.Error()
+}
+
+func (e *TopologyError) Unwrap() error {
+	return e.Err
+}
+
+// TopologyErrors is a list of errors found in a network file
+type TopologyErrors []error
+
+func (e TopologyErrors) Error() string {
+	messages := make([]string, len(e))
+	for idx, err := range e {
+		messages[idx] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// inFile sets file in the location of the errors without file
+func inFile(file string, err error) error {
+	switch e := err.(type) {
+	case *TopologyError:
+		if e.File == "" {
+			e.File = file
+		}
+		return e
+	case TopologyErrors:
+		for idx := range e {
+			e[idx] = inFile(file, e[idx])
+		}
+		return e
+	}
+	return &TopologyError{Location{File: file}, err}
+}
+
+// flattenErrors returns the errors contained in err
+func flattenErrors(err error) []error {
+	if list, ok := err.(TopologyErrors); ok {
+		return list
+	}
+	return []error{err}
+}
+
+func errorLocation(err error) Location {
+	var topoErr *TopologyError
+	if errors.As(err, &topoErr) {
+		return topoErr.Location
+	}
+	return Location{}
+}
+
+// sortErrors sorts errors by file, line and column
+func sortErrors(list []error) {
+	sort.SliceStable(list, func(i, j int) bool {
+		li, lj := errorLocation(list[i]), errorLocation(list[j])
+		switch {
+		case li.File != lj.File:
+			return li.File < lj.File
+		case li.Line != lj.Line:
+			return li.Line < lj.Line
+		case li.Column != lj.Column:
+			return li.Column < lj.Column
+		}
+		return list[i].Error() < list[j].Error()
+	})
+}
+
+func joinYamlPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+// yamlPositions returns the position of the keys and of the sequence
+// items of a yaml document. A path is made of the keys and item
+// indexes joined by '/', like links/0/peer1
+func yamlPositions(data []byte) map[string]Location {
+	positions := make(map[string]Location)
+
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &doc); err != nil || len(doc.Content) == 0 {
+		return positions
+	}
+
+	var walk func(node *yamlv3.Node, parent string)
+	walk = func(node *yamlv3.Node, parent string) {
+		switch node.Kind {
+		case yamlv3.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i]
+				path := joinYamlPath(parent, key.Value)
+				positions[path] = Location{Line: key.Line, Column: key.Column}
+				walk(node.Content[i+1], path)
+			}
+		case yamlv3.SequenceNode:
+			for idx, item := range node.Content {
+				path := joinYamlPath(parent, strconv.Itoa(idx))
+				positions[path] = Location{Line: item.Line, Column: item.Column}
+				walk(item, path)
+			}
+		}
+	}
+	walk(doc.Content[0], "")
+
+	return positions
+}
+
+// yamlErrors converts an error of the yaml decoder in a list of errors
+// located in data
+func yamlErrors(data []byte, err error) error {
+	messages := []string{err.Error()}
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		messages = typeErr.Errors
+	}
+
+	lines := strings.Split(string(data), "\n")
+	list := make(TopologyErrors, 0, len(messages))
+	for _, msg := range messages {
+		match := yamlLineRE.FindStringSubmatch(msg)
+		if match == nil {
+			list = append(list, &TopologyError{Err: errors.New(msg)})
+			continue
+		}
+
+		location := Location{}
+		location.Line, _ = strconv.Atoi(match[1])
+		if location.Line > 0 && location.Line <= len(lines) {
+			text := strings.TrimLeft(lines[location.Line-1], " -")
+			location.Column = len(lines[location.Line-1]) - len(text) + 1
+		}
+		msg = yamlUnknownRE.ReplaceAllString(match[2], "unknown key '$1'")
+		list = append(list, &TopologyError{location, errors.New(msg)})
+	}
+	return list
+}
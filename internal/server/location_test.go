@@ -0,0 +1,99 @@
+package server
+
+import (
+	"testing"
+)
+
+func TestLocation_YamlPositions(t *testing.T) {
+	data := `nodes:
+  R1:
+    type: docker.router
+links:
+- peer1: R1.0
+  peer2: R2.0
+  queue:
+    type: htb
+    classes:
+    - rate: 1mbit
+-
+  peer1: R1.1
+  peer2: R2.1
+bridges:
+  br:
+    host: eth0
+    interfaces:
+      - R1.2
+"hostifs": # quoted key
+  up: {host: eth1, interface: "R1.3"}`
+
+	tests := []struct {
+		desc     string
+		path     string
+		expected Location
+	}{
+		{desc: "Position: node", path: "nodes/R1", expected: Location{Line: 2, Column: 3}},
+		{desc: "Position: link", path: "links/0", expected: Location{Line: 5, Column: 3}},
+		{desc: "Position: nested item", path: "links/0/queue/classes/0/rate", expected: Location{Line: 10, Column: 7}},
+		{desc: "Position: item on several lines", path: "links/1/peer2", expected: Location{Line: 13, Column: 3}},
+		{desc: "Position: scalar item", path: "bridges/br/interfaces/0", expected: Location{Line: 18, Column: 9}},
+		{desc: "Position: quoted key", path: "hostifs/up", expected: Location{Line: 20, Column: 3}},
+		{desc: "Position: flow mapping", path: "hostifs/up/interface", expected: Location{Line: 20, Column: 20}},
+	}
+
+	positions := yamlPositions([]byte(data))
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if positions[tt.path] != tt.expected {
+				t.Errorf("Wrong position: %v != %v", positions[tt.path], tt.expected)
+			}
+		})
+	}
+}
+
+func TestLocation_ExpandErrors(t *testing.T) {
+	tests := []struct {
+		desc     string
+		network  string
+		expected string
+	}{
+		{
+			desc: "Errors: unknown key",
+			network: `nodes:
+  R1:
+    type: docker.router
+links:
+- peer1: R1.0
+  peer2: R1.1
+  jiter: 10`,
+			expected: "network.yml:7:3: unknown key 'jiter'",
+		},
+		{
+			desc: "Errors: undefined variable",
+			network: `nodes:
+  R1:
+    type: $kind`,
+			expected: "network.yml:3:11: Variable 'kind' is not defined",
+		},
+		{
+			desc: "Errors: invalid pattern",
+			network: `nodes:
+  R1:
+    type: docker.router
+  R{1}:
+    type: docker.router`,
+			expected: "network.yml:4:3: 'R{1}': '{1}' is not a range or a list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			_, err := ExpandTopology([]byte(tt.network), "")
+			if err == nil {
+				t.Fatalf("Error expected but not found")
+			}
+			if err.Error() != tt.expected {
+				t.Errorf("Wrong error: '%v' != '%s'", err, tt.expected)
+			}
+		})
+	}
+}
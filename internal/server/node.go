@@ -9,6 +9,8 @@ import (
 	"github.com/moby/term"
 	"github.com/mroy31/gonetem/internal/docker"
 	"github.com/mroy31/gonetem/internal/link"
+	"github.com/mroy31/gonetem/internal/nsnode"
+	"github.com/mroy31/gonetem/internal/options"
 	"github.com/mroy31/gonetem/internal/ovs"
 	"github.com/vishvananda/netns"
 )
@@ -23,6 +25,7 @@ type INetemNode interface {
 	GetNetns() (netns.NsHandle, error)
 	GetInterfaceName(ifIndex int) string
 	AddInterface(ifName string, ifIndex int, ns netns.NsHandle) error
+	RemoveInterface(ifIndex int) error
 	LoadConfig(confPath string) ([]string, error)
 	CanRunConsole() error
 	Console(shell bool, in io.ReadCloser, out io.Writer, resizeCh chan term.Winsize) error
@@ -80,6 +83,19 @@ func (nIdGen *NodeIdentifierGenerator) GetId(name string) (string, error) {
 	return "", fmt.Errorf("Unable to generate a short id for node %s: all attempts fail", name)
 }
 
+// Release makes id available for another node
+func (nIdGen *NodeIdentifierGenerator) Release(id string) {
+	nIdGen.lock.Lock()
+	defer nIdGen.lock.Unlock()
+
+	for idx, nId := range nIdGen.usedIds {
+		if nId == id {
+			nIdGen.usedIds = append(nIdGen.usedIds[:idx], nIdGen.usedIds[idx+1:]...)
+			return
+		}
+	}
+}
+
 func (nIdGen *NodeIdentifierGenerator) Close() {
 	nIdGen.lock.Lock()
 	defer nIdGen.lock.Unlock()
@@ -87,21 +103,28 @@ func (nIdGen *NodeIdentifierGenerator) Close() {
 	nIdGen.usedIds = make([]string, 0)
 }
 
-func CreateNode(prjID string, name string, shortName string, config NodeConfig) (INetemNode, error) {
+func CreateNode(prjID string, name string, shortName string, config NodeConfig, kinds map[string]options.NodeKind) (INetemNode, error) {
 	// first test if it is a docker node
 	re := regexp.MustCompile(`^docker.(\w+)$`)
 	groups := re.FindStringSubmatch(config.Type)
 	if len(groups) == 2 {
 		// Create docker node
 		options := docker.DockerNodeOptions{
-			Name:      name,
-			ShortName: shortName,
-			ImgName:   config.Image,
-			Type:      groups[1],
-			Ipv6:      config.IPv6,
-			Mpls:      config.Mpls,
-			Vrfs:      config.Vrfs,
-			Volumes:   config.Volumes,
+			Name:       name,
+			ShortName:  shortName,
+			ImgName:    config.Image,
+			Type:       groups[1],
+			Ipv6:       config.IPv6,
+			Mpls:       config.Mpls,
+			Vrfs:       config.Vrfs,
+			Volumes:    config.Volumes,
+			Resources:  config.NodeResources,
+			Cpuset:     config.Cpuset,
+			Sysctls:    config.Sysctls,
+			Env:        config.Env,
+			Command:    config.Command,
+			Entrypoint: config.Entrypoint,
+			Kinds:      kinds,
 		}
 		for _, group := range config.Vrrps {
 			options.Vrrps = append(options.Vrrps, docker.VrrpOptions{
@@ -119,5 +142,17 @@ func CreateNode(prjID string, name string, shortName string, config NodeConfig)
 		return ovs.NewOvsNode(prjID, name, shortName)
 	}
 
+	// then test if it is a namespace only host or a linux bridge
+	if config.Type == "netns" {
+		return nsnode.NewNetnsNode(prjID, name, shortName, config.IPv6)
+	}
+	if config.Type == "bridge" {
+		ports := make(map[int]nsnode.BridgePort)
+		for ifIndex, port := range config.Ports {
+			ports[ifIndex] = nsnode.BridgePort{Pvid: port.Pvid, Vlans: port.Vlans}
+		}
+		return nsnode.NewBridgeNode(prjID, name, shortName, config.Stp, ports)
+	}
+
 	return nil, fmt.Errorf("Unknown node type '%s'", config.Type)
 }
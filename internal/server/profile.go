@@ -0,0 +1,396 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	profileDir = "profiles"
+	// size of a packet in a mahimahi trace, in bytes
+	mahimahiPacketSize = 1500
+	// duration of the steps built from a mahimahi trace, in ms
+	mahimahiInterval = 1000
+	// delay of the steps built from a mahimahi trace when the link
+	// has no delay, the tbf qdisc requires a latency
+	mahimahiDelay = Duration(time.Millisecond)
+)
+
+type LinkProfileConfig struct {
+	Name string
+	Loop bool `yaml:",omitempty"`
+}
+
+// ProfileStep defines the parameters applied to a link during Duration ms
+type ProfileStep struct {
+	Duration     int
+	LinkParams   `yaml:",inline"`
+	Peer1ToPeer2 *LinkParams `yaml:"peer1_to_peer2,omitempty"`
+	Peer2ToPeer1 *LinkParams `yaml:"peer2_to_peer1,omitempty"`
+}
+
+type LinkProfile struct {
+	Steps []ProfileStep
+	// steps modify only the rate of the link (mahimahi trace)
+	rateOnly bool
+}
+
+type profileAction int
+
+const (
+	profilePause profileAction = iota
+	profileResume
+	profileStop
+)
+
+// linkProfileRunner applies the steps of a profile on a link
+// in its own goroutine
+type linkProfileRunner struct {
+	name    string
+	link    *NetemLink
+	configs []LinkConfig
+	steps   []ProfileStep
+	loop    bool
+	actions chan profileAction
+	done    chan struct{}
+}
+
+// loadLinkProfile reads the profile name stored in the project. It can be
+// a yaml file <name>.yml or a mahimahi trace <name>.trace, in this case
+// steps change only the rate of the link
+func loadLinkProfile(prjPath, name string) (*LinkProfile, error) {
+	filepath := path.Join(prjPath, profileDir, name+".yml")
+	if _, err := os.Stat(filepath); err == nil {
+		data, err := ioutil.ReadFile(filepath)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read profile '%s': %w", name, err)
+		}
+
+		var profile LinkProfile
+		if err := yaml.UnmarshalStrict(data, &profile); err != nil {
+			return nil, fmt.Errorf("Unable to parse profile '%s':\n\t%w", name, err)
+		}
+		return &profile, nil
+	}
+
+	filepath = path.Join(prjPath, profileDir, name+".trace")
+	if _, err := os.Stat(filepath); err == nil {
+		return readMahimahiTrace(filepath)
+	}
+
+	return nil, fmt.Errorf("Profile '%s' not found", name)
+}
+
+// readMahimahiTrace converts a mahimahi trace, where each line is the
+// timestamp in ms of a packet delivery opportunity, in rate steps
+func readMahimahiTrace(filepath string) (*LinkProfile, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to open trace: %w", err)
+	}
+	defer file.Close()
+
+	// count delivery opportunities in each interval
+	counts := make([]int, 0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		timestamp, err := strconv.Atoi(line)
+		if err != nil || timestamp < 0 {
+			return nil, fmt.Errorf("Trace %s: wrong timestamp '%s'", path.Base(filepath), line)
+		}
+		idx := timestamp / mahimahiInterval
+		for len(counts) <= idx {
+			counts = append(counts, 0)
+		}
+		counts[idx]++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Unable to read trace: %w", err)
+	}
+	if len(counts) == 0 {
+		return nil, fmt.Errorf("Trace %s is empty", path.Base(filepath))
+	}
+
+	profile := &LinkProfile{
+		Steps:    make([]ProfileStep, len(counts)),
+		rateOnly: true,
+	}
+	for idx, count := range counts {
+		rate := Bitrate(count * mahimahiPacketSize * 8 * 1000 / mahimahiInterval)
+		if rate == 0 {
+			// no delivery opportunity, use a minimal rate of 1kbit
+			rate = 1000
+		}
+		profile.Steps[idx] = ProfileStep{
+			Duration:   mahimahiInterval,
+			LinkParams: LinkParams{Rate: rate},
+		}
+	}
+	return profile, nil
+}
+
+// stepConfigs returns the link configuration to apply for each step
+// of the profile
+func (p *LinkProfile) stepConfigs(lConfig LinkConfig) []LinkConfig {
+	configs := make([]LinkConfig, len(p.Steps))
+	for idx, step := range p.Steps {
+		if p.rateOnly {
+			// other parameters of the link are kept, in both directions
+			configs[idx] = LinkConfig{
+				Peer1:        lConfig.Peer1,
+				Peer2:        lConfig.Peer2,
+				LinkParams:   traceParams(lConfig.LinkParams, step.Rate),
+				Peer1ToPeer2: withRate(lConfig.Peer1ToPeer2, step.Rate),
+				Peer2ToPeer1: withRate(lConfig.Peer2ToPeer1, step.Rate),
+			}
+			continue
+		}
+
+		configs[idx] = LinkConfig{
+			Peer1:        lConfig.Peer1,
+			Peer2:        lConfig.Peer2,
+			LinkParams:   step.LinkParams,
+			Peer1ToPeer2: step.Peer1ToPeer2,
+			Peer2ToPeer1: step.Peer2ToPeer1,
+		}
+	}
+	return configs
+}
+
+// traceParams returns a copy of params where the rate is replaced,
+// with a minimal delay if params has none
+func traceParams(params LinkParams, rate Bitrate) LinkParams {
+	newParams := params.copy()
+	newParams.Rate = rate
+	if newParams.Delay == 0 {
+		newParams.Delay = mahimahiDelay
+	}
+	return newParams
+}
+
+// withRate returns the trace parameters of params, nil if params is nil
+func withRate(params *LinkParams, rate Bitrate) *LinkParams {
+	if params == nil {
+		return nil
+	}
+	newParams := traceParams(*params, rate)
+	return &newParams
+}
+
+// checkLinkProfile loads the profile name and checks that each step
+// is valid for the link lConfig
+func checkLinkProfile(prjPath, name string, lConfig LinkConfig) ([]ProfileStep, []LinkConfig, error) {
+	profile, err := loadLinkProfile(prjPath, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configs := profile.stepConfigs(lConfig)
+	for idx, step := range profile.Steps {
+		if step.Duration <= 0 {
+			return nil, nil, fmt.Errorf("Profile '%s': step %d has no duration", name, idx)
+		}
+		if errors := checkLinkConfig(configs[idx], prjPath); len(errors) > 0 {
+			msg := ""
+			for _, err := range errors {
+				msg += "\n\t" + err.Error()
+			}
+			return nil, nil, fmt.Errorf("Profile '%s': step %d is not valid:%s\n", name, idx, msg)
+		}
+	}
+	if len(profile.Steps) == 0 {
+		return nil, nil, fmt.Errorf("Profile '%s' has no step", name)
+	}
+
+	return profile.Steps, configs, nil
+}
+
+func (r *linkProfileRunner) run(t *NetemTopologyManager) {
+	defer close(r.done)
+
+	idx := 0
+	for {
+		r.link.lock.Lock()
+		err := t.applyLinkConfig(r.link, r.configs[idx])
+		r.link.lock.Unlock()
+		if err != nil {
+			t.logger.Errorf("Profile %s: unable to apply step %d: %v", r.name, idx, err)
+		}
+
+		remaining := time.Duration(r.steps[idx].Duration) * time.Millisecond
+		start := time.Now()
+		timer := time.NewTimer(remaining)
+		paused := false
+
+	wait:
+		for {
+			select {
+			case <-timer.C:
+				break wait
+			case action := <-r.actions:
+				switch action {
+				case profilePause:
+					if paused {
+						continue
+					}
+					if timer.Stop() {
+						remaining -= time.Since(start)
+					} else {
+						// the timer has expired, step ends at resume
+						<-timer.C
+						remaining = 0
+					}
+					paused = true
+				case profileResume:
+					if paused {
+						start = time.Now()
+						timer.Reset(remaining)
+						paused = false
+					}
+				case profileStop:
+					timer.Stop()
+					return
+				}
+			}
+		}
+
+		idx++
+		if idx == len(r.steps) {
+			if !r.loop {
+				// restore the configuration of the link
+				if err := t.restoreLinkConfig(r.link); err != nil {
+					t.logger.Errorf("Profile %s: unable to restore link: %v", r.name, err)
+				}
+
+				t.profileLock.Lock()
+				if t.profiles[r.link] == r {
+					delete(t.profiles, r.link)
+				}
+				t.profileLock.Unlock()
+				return
+			}
+			idx = 0
+		}
+	}
+}
+
+// restoreLinkConfig applies again the configuration
+// of the link at the end of a profile
+func (t *NetemTopologyManager) restoreLinkConfig(l *NetemLink) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return t.applyLinkConfig(l, l.Config)
+}
+
+// StartLinkProfile replays the profile name on the link
+// connected to the interface <nodeName>.<ifIndex>
+func (t *NetemTopologyManager) StartLinkProfile(nodeName string, ifIndex int, name string, loop bool) error {
+	if !t.running {
+		return fmt.Errorf("Topology is not running")
+	}
+
+	l := t.GetLink(nodeName, ifIndex)
+	if l == nil {
+		return fmt.Errorf("No link found for interface %s.%d", nodeName, ifIndex)
+	}
+
+	steps, configs, err := checkLinkProfile(t.path, name, l.getConfig())
+	if err != nil {
+		return err
+	}
+
+	// stop the current profile if necessary
+	t.stopLinkProfile(l)
+
+	runner := &linkProfileRunner{
+		name:    name,
+		link:    l,
+		configs: configs,
+		steps:   steps,
+		loop:    loop,
+		actions: make(chan profileAction),
+		done:    make(chan struct{}),
+	}
+	t.profileLock.Lock()
+	t.profiles[l] = runner
+	t.profileLock.Unlock()
+
+	go runner.run(t)
+	return nil
+}
+
+// SetLinkProfileAction pauses, resumes or stops the profile running
+// on the link connected to the interface <nodeName>.<ifIndex>
+func (t *NetemTopologyManager) SetLinkProfileAction(nodeName string, ifIndex int, action profileAction) error {
+	l := t.GetLink(nodeName, ifIndex)
+	if l == nil {
+		return fmt.Errorf("No link found for interface %s.%d", nodeName, ifIndex)
+	}
+
+	if action == profileStop {
+		if !t.stopLinkProfile(l) {
+			return fmt.Errorf("No profile is running on link %s-%s", l.Config.Peer1, l.Config.Peer2)
+		}
+		return t.restoreLinkConfig(l)
+	}
+
+	t.profileLock.Lock()
+	runner, found := t.profiles[l]
+	t.profileLock.Unlock()
+	if !found {
+		return fmt.Errorf("No profile is running on link %s-%s", l.Config.Peer1, l.Config.Peer2)
+	}
+
+	select {
+	case runner.actions <- action:
+	case <-runner.done:
+		return fmt.Errorf("Profile %s is finished", runner.name)
+	}
+	return nil
+}
+
+// stopLinkProfile stops the profile running on the link
+// and returns true if a profile was running
+func (t *NetemTopologyManager) stopLinkProfile(l *NetemLink) bool {
+	t.profileLock.Lock()
+	runner, found := t.profiles[l]
+	delete(t.profiles, l)
+	t.profileLock.Unlock()
+
+	if !found {
+		return false
+	}
+
+	select {
+	case runner.actions <- profileStop:
+	case <-runner.done:
+	}
+	<-runner.done
+	return true
+}
+
+func (t *NetemTopologyManager) stopAllLinkProfiles() {
+	t.profileLock.Lock()
+	links := make([]*NetemLink, 0, len(t.profiles))
+	for l := range t.profiles {
+		links = append(links, l)
+	}
+	t.profileLock.Unlock()
+
+	for _, l := range links {
+		t.stopLinkProfile(l)
+	}
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProfile_Load(t *testing.T) {
+	tests := []struct {
+		desc          string
+		filename      string
+		content       string
+		rates         []Bitrate
+		expectedError bool
+	}{
+		{
+			desc:     "Profile: load yaml profile",
+			filename: "test.yml",
+			content: `
+steps:
+- duration: 1000
+  delay: 10
+  rate: 1000
+- duration: 2000
+  delay: 10
+  rate: 500`,
+			rates: []Bitrate{1000000, 500000},
+		},
+		{
+			desc:     "Profile: load mahimahi trace",
+			filename: "test.trace",
+			content:  "0\n0\n500\n2500\n",
+			rates:    []Bitrate{36000, 1000, 12000},
+		},
+		{
+			desc:          "Profile: wrong key in yaml profile",
+			filename:      "test.yml",
+			content:       "steps:\n- duration: 1000\n  jiter: 10\n",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("/tmp", "ntmtst")
+			if err != nil {
+				t.Fatalf("Unable to create temp folder: %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			if err := os.Mkdir(path.Join(dir, profileDir), 0755); err != nil {
+				t.Fatalf("Unable to create profile folder: %v", err)
+			}
+			filepath := path.Join(dir, profileDir, tt.filename)
+			if err := ioutil.WriteFile(filepath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("Unable to create profile file: %v", err)
+			}
+
+			profile, err := loadLinkProfile(dir, "test")
+			if err != nil {
+				if !tt.expectedError {
+					t.Errorf("loadLinkProfile returns an unexpected error: %v", err)
+				}
+				return
+			} else if tt.expectedError {
+				t.Errorf("loadLinkProfile does not return an error")
+				return
+			}
+
+			if len(profile.Steps) != len(tt.rates) {
+				t.Fatalf("Wrong number of steps %d != %d", len(profile.Steps), len(tt.rates))
+			}
+			for idx, step := range profile.Steps {
+				if step.Rate != tt.rates[idx] {
+					t.Errorf("Step %d has wrong rate %d != %d", idx, step.Rate, tt.rates[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestProfile_StepConfigs(t *testing.T) {
+	delay := Duration(10 * time.Millisecond)
+	tests := []struct {
+		desc     string
+		profile  LinkProfile
+		lConfig  LinkConfig
+		expected LinkConfig
+	}{
+		{
+			desc:    "Profile: yaml step replaces link parameters",
+			profile: LinkProfile{Steps: []ProfileStep{{Duration: 1000, LinkParams: LinkParams{Rate: 1000000}}}},
+			lConfig: LinkConfig{
+				Peer1:        "R1.0",
+				Peer2:        "R2.0",
+				LinkParams:   LinkParams{Delay: delay},
+				Peer1ToPeer2: &LinkParams{Loss: 5},
+			},
+			expected: LinkConfig{Peer1: "R1.0", Peer2: "R2.0", LinkParams: LinkParams{Rate: 1000000}},
+		},
+		{
+			desc: "Profile: trace step keeps link parameters",
+			profile: LinkProfile{
+				Steps:    []ProfileStep{{Duration: 1000, LinkParams: LinkParams{Rate: 1000000}}},
+				rateOnly: true,
+			},
+			lConfig: LinkConfig{
+				Peer1:        "R1.0",
+				Peer2:        "R2.0",
+				LinkParams:   LinkParams{Delay: delay},
+				Peer1ToPeer2: &LinkParams{Loss: 5, Rate: 2000000},
+			},
+			expected: LinkConfig{
+				Peer1:        "R1.0",
+				Peer2:        "R2.0",
+				LinkParams:   LinkParams{Delay: delay, Rate: 1000000},
+				Peer1ToPeer2: &LinkParams{Loss: 5, Rate: 1000000, Delay: mahimahiDelay},
+			},
+		},
+		{
+			desc: "Profile: trace step on a link without delay",
+			profile: LinkProfile{
+				Steps:    []ProfileStep{{Duration: 1000, LinkParams: LinkParams{Rate: 1000000}}},
+				rateOnly: true,
+			},
+			lConfig: LinkConfig{
+				Peer1:        "R1.0",
+				Peer2:        "R2.0",
+				LinkParams:   LinkParams{Loss: 1},
+				Peer1ToPeer2: &LinkParams{Loss: 5, Rate: 2000000},
+			},
+			expected: LinkConfig{
+				Peer1:        "R1.0",
+				Peer2:        "R2.0",
+				LinkParams:   LinkParams{Loss: 1, Delay: mahimahiDelay, Rate: 1000000},
+				Peer1ToPeer2: &LinkParams{Loss: 5, Rate: 1000000, Delay: mahimahiDelay},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			configs := tt.profile.stepConfigs(tt.lConfig)
+			if len(configs) != 1 {
+				t.Fatalf("Wrong number of configs %d != 1", len(configs))
+			}
+			if !reflect.DeepEqual(configs[0], tt.expected) {
+				t.Errorf("Wrong step config %v != %v", configs[0], tt.expected)
+			}
+			if tt.lConfig.Peer1ToPeer2.Rate == tt.expected.LinkParams.Rate {
+				t.Errorf("Link config has been modified")
+			}
+		})
+	}
+}
+
+func TestProfile_CheckTraceWithoutDelay(t *testing.T) {
+	dir, err := ioutil.TempDir("/tmp", "ntmtst")
+	if err != nil {
+		t.Fatalf("Unable to create temp folder: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if err := os.Mkdir(path.Join(dir, profileDir), 0755); err != nil {
+		t.Fatalf("Unable to create profile folder: %v", err)
+	}
+	if err := ioutil.WriteFile(path.Join(dir, profileDir, "test.trace"), []byte("0\n500\n1500\n"), 0644); err != nil {
+		t.Fatalf("Unable to create profile file: %v", err)
+	}
+
+	lConfig := LinkConfig{Peer1: "R1.0", Peer2: "R2.0", LinkParams: LinkParams{Loss: 1}}
+	if _, _, err := checkLinkProfile(dir, "test", lConfig); err != nil {
+		t.Errorf("checkLinkProfile returns an unexpected error: %v", err)
+	}
+}
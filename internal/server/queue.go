@@ -0,0 +1,175 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mroy31/gonetem/internal/link"
+)
+
+// HtbClassConfig defines a class of the htb queue.
+// Packets with the DSCP value are sent in this class
+type HtbClassConfig struct {
+	Rate Bitrate
+	Ceil Bitrate `yaml:",omitempty"` // default rate
+	Prio int     `yaml:",omitempty"`
+	Dscp *int    `yaml:",omitempty"`
+}
+
+// QueueConfig defines the queueing discipline used by the rate limiter
+// of a link
+type QueueConfig struct {
+	Type     string
+	Limit    int      `yaml:",omitempty"` // packets, bytes for red
+	Target   Duration `yaml:",omitempty"`
+	Interval Duration `yaml:",omitempty"`
+	Flows    int      `yaml:",omitempty"`
+	Ecn      bool     `yaml:",omitempty"`
+	// red parameters
+	Min         int     `yaml:",omitempty"` // bytes
+	Max         int     `yaml:",omitempty"` // bytes
+	Avpkt       int     `yaml:",omitempty"` // bytes
+	Burst       int     `yaml:",omitempty"` // packets
+	Probability float64 `yaml:",omitempty"` // percent
+	// htb parameters
+	Classes []HtbClassConfig `yaml:",omitempty"`
+	Default int              `yaml:",omitempty"` // index of the default class
+}
+
+func (q QueueConfig) copy() QueueConfig {
+	classes := q.Classes
+	if classes != nil {
+		q.Classes = make([]HtbClassConfig, len(classes))
+		for idx, class := range classes {
+			if class.Dscp != nil {
+				dscp := *class.Dscp
+				class.Dscp = &dscp
+			}
+			q.Classes[idx] = class
+		}
+	}
+	return q
+}
+
+func (q QueueConfig) queueParams() link.QueueParams {
+	params := link.QueueParams{
+		Type:         q.Type,
+		Limit:        q.Limit,
+		Target:       time.Duration(q.Target),
+		Interval:     time.Duration(q.Interval),
+		Flows:        q.Flows,
+		Ecn:          q.Ecn,
+		Min:          q.Min,
+		Max:          q.Max,
+		Avpkt:        q.Avpkt,
+		Burst:        q.Burst,
+		Probability:  q.Probability,
+		Classes:      make([]link.HtbClass, len(q.Classes)),
+		DefaultClass: q.Default,
+	}
+	for idx, class := range q.Classes {
+		params.Classes[idx] = link.HtbClass{
+			Rate: uint64(class.Rate),
+			Ceil: uint64(class.Ceil),
+			Prio: class.Prio,
+			Dscp: class.Dscp,
+		}
+	}
+	return params
+}
+
+// checkQueueConfig checks the queue of a link limited to rate
+func checkQueueConfig(q QueueConfig, rate Bitrate) []error {
+	errors := make([]error, 0)
+
+	switch q.Type {
+	case link.QUEUE_PFIFO, link.QUEUE_FQ_CODEL, link.QUEUE_RED, link.QUEUE_HTB:
+	default:
+		errors = append(errors, fmt.Errorf(
+			"Queue type '%s' is not valid (%s, %s, %s or %s expected)",
+			q.Type, link.QUEUE_PFIFO, link.QUEUE_FQ_CODEL, link.QUEUE_RED, link.QUEUE_HTB))
+		return errors
+	}
+	if rate == 0 {
+		errors = append(errors, fmt.Errorf("Queue %s requires a rate on the link", q.Type))
+	}
+
+	if q.Limit < 0 || q.Target < 0 || q.Interval < 0 || q.Flows < 0 ||
+		q.Min < 0 || q.Max < 0 || q.Avpkt < 0 || q.Burst < 0 {
+		errors = append(errors, fmt.Errorf("Queue %s: parameters must be >= 0", q.Type))
+	}
+	if q.Type != link.QUEUE_FQ_CODEL && (q.Target > 0 || q.Interval > 0 || q.Flows > 0) {
+		errors = append(errors, fmt.Errorf("Queue %s: target, interval and flows are only valid for %s", q.Type, link.QUEUE_FQ_CODEL))
+	}
+	if q.Type != link.QUEUE_RED && (q.Min > 0 || q.Max > 0 || q.Avpkt > 0 || q.Burst > 0 || q.Probability > 0) {
+		errors = append(errors, fmt.Errorf("Queue %s: min, max, avpkt, burst and probability are only valid for %s", q.Type, link.QUEUE_RED))
+	}
+	if q.Ecn && q.Type != link.QUEUE_FQ_CODEL && q.Type != link.QUEUE_RED {
+		errors = append(errors, fmt.Errorf("Queue %s: ecn is only valid for %s and %s", q.Type, link.QUEUE_FQ_CODEL, link.QUEUE_RED))
+	}
+	if q.Type != link.QUEUE_HTB && (len(q.Classes) > 0 || q.Default != 0) {
+		errors = append(errors, fmt.Errorf("Queue %s: classes and default are only valid for %s", q.Type, link.QUEUE_HTB))
+	}
+
+	switch q.Type {
+	case link.QUEUE_PFIFO:
+		if q.Limit == 0 {
+			errors = append(errors, fmt.Errorf("Queue %s: limit is required", q.Type))
+		}
+
+	case link.QUEUE_RED:
+		if q.Limit == 0 {
+			errors = append(errors, fmt.Errorf("Queue %s: limit (in bytes) is required", q.Type))
+		}
+		qmax := q.Max
+		if qmax == 0 {
+			qmax = q.Limit / 4
+		}
+		qmin := q.Min
+		if qmin == 0 {
+			qmin = qmax / 3
+		}
+		if qmin >= qmax || qmax > q.Limit {
+			errors = append(errors, fmt.Errorf("Queue %s: min < max <= limit is required", q.Type))
+		}
+		if q.Probability < 0 || q.Probability > 100 {
+			errors = append(errors, fmt.Errorf("Queue %s: probability must be between 0 and 100", q.Type))
+		}
+
+	case link.QUEUE_HTB:
+		if len(q.Classes) == 0 {
+			errors = append(errors, fmt.Errorf("Queue %s: at least one class is required", q.Type))
+		}
+		if q.Default < 0 || (len(q.Classes) > 0 && q.Default >= len(q.Classes)) {
+			errors = append(errors, fmt.Errorf("Queue %s: default class %d does not exist", q.Type, q.Default))
+		}
+
+		total := Bitrate(0)
+		dscps := make(map[int]bool)
+		for idx, class := range q.Classes {
+			total += class.Rate
+			if class.Rate <= 0 {
+				errors = append(errors, fmt.Errorf("Queue %s: rate of class %d must be > 0", q.Type, idx))
+			}
+			if class.Ceil != 0 && (class.Ceil < class.Rate || class.Ceil > rate) {
+				errors = append(errors, fmt.Errorf("Queue %s: ceil of class %d must be between its rate and the link rate", q.Type, idx))
+			}
+			if class.Prio < 0 || class.Prio > 7 {
+				errors = append(errors, fmt.Errorf("Queue %s: prio of class %d must be between 0 and 7", q.Type, idx))
+			}
+			if class.Dscp != nil {
+				if *class.Dscp < 0 || *class.Dscp > 63 {
+					errors = append(errors, fmt.Errorf("Queue %s: dscp of class %d must be between 0 and 63", q.Type, idx))
+				} else if dscps[*class.Dscp] {
+					errors = append(errors, fmt.Errorf("Queue %s: dscp %d is used by several classes", q.Type, *class.Dscp))
+				}
+				dscps[*class.Dscp] = true
+			}
+		}
+		if rate > 0 && total > rate {
+			errors = append(errors, fmt.Errorf("Queue %s: sum of class rates (%s) exceeds the link rate (%s)", q.Type, total, rate))
+		}
+	}
+
+	return errors
+}
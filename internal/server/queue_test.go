@@ -0,0 +1,72 @@
+package server
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestQueue_Check(t *testing.T) {
+	tests := []struct {
+		desc          string
+		rate          Bitrate // in kbps, like in the network file
+		content       string
+		expectedError bool
+	}{
+		{
+			desc:    "Queue: valid fq_codel",
+			rate:    1000,
+			content: "type: fq_codel\ntarget: 5\ninterval: 100\necn: true",
+		},
+		{
+			desc:          "Queue: unknown type",
+			rate:          1000,
+			content:       "type: sfq",
+			expectedError: true,
+		},
+		{
+			desc:          "Queue: queue without rate",
+			content:       "type: pfifo\nlimit: 100",
+			expectedError: true,
+		},
+		{
+			desc:          "Queue: red with min > max",
+			rate:          1000,
+			content:       "type: red\nlimit: 100000\nmin: 30000\nmax: 20000",
+			expectedError: true,
+		},
+		{
+			desc:    "Queue: valid htb",
+			rate:    1000,
+			content: "type: htb\ndefault: 1\nclasses:\n- rate: 200\n  dscp: 46\n- rate: 800\n  ceil: 1000",
+		},
+		{
+			desc:          "Queue: htb classes exceed link rate",
+			rate:          1000,
+			content:       "type: htb\nclasses:\n- rate: 600\n- rate: 600",
+			expectedError: true,
+		},
+		{
+			desc:          "Queue: htb default class not found",
+			rate:          1000,
+			content:       "type: htb\ndefault: 2\nclasses:\n- rate: 600",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var q QueueConfig
+			if err := yaml.UnmarshalStrict([]byte(tt.content), &q); err != nil {
+				t.Fatalf("Unable to parse queue: %v", err)
+			}
+
+			errors := checkQueueConfig(q, tt.rate*1000)
+			if tt.expectedError && len(errors) == 0 {
+				t.Errorf("Check succeeds but an error is expected")
+			} else if !tt.expectedError && len(errors) > 0 {
+				t.Errorf("Check fails: %v", errors)
+			}
+		})
+	}
+}
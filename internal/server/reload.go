@@ -0,0 +1,682 @@
+package server
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mroy31/gonetem/internal/link"
+	"github.com/mroy31/gonetem/internal/options"
+	"github.com/mroy31/gonetem/internal/proto"
+)
+
+const (
+	CHANGE_ADDED    = "added"
+	CHANGE_REMOVED  = "removed"
+	CHANGE_MODIFIED = "modified"
+
+	CHANGE_NODE    = "node"
+	CHANGE_LINK    = "link"
+	CHANGE_BRIDGE  = "bridge"
+	CHANGE_HOSTIF  = "hostif"
+	CHANGE_SEGMENT = "segment"
+)
+
+// TopologyChange is an element of the topology modified by a reload
+type TopologyChange struct {
+	Action string
+	Kind   string
+	Name   string
+}
+
+func (c TopologyChange) String() string {
+	return fmt.Sprintf("%s %s %s", c.Kind, c.Name, c.Action)
+}
+
+// reloadPlan lists the operations required to go from a topology to
+// another one. Removals are done before additions, so a modified element
+// which can not be updated in place is in both lists
+type reloadPlan struct {
+	changes        []TopologyChange
+	removeNodes    []string
+	addNodes       []string
+	moveNodes      []string // nodes with new wireless options only
+	removeLinks    []LinkConfig
+	addLinks       []LinkConfig
+	updateLinks    []LinkConfig
+	removeBridges  []string
+	addBridges     []string
+	removeHostIfs  []string
+	addHostIfs     []string
+	removeSegments []string
+	addSegments    []string
+}
+
+func linkName(lConfig LinkConfig) string {
+	return lConfig.Peer1 + "-" + lConfig.Peer2
+}
+
+func peerNodeName(peer string) string {
+	return strings.Split(peer, ".")[0]
+}
+
+func sortedKeys(m interface{}) []string {
+	keys := make([]string, 0)
+	for _, key := range reflect.ValueOf(m).MapKeys() {
+		keys = append(keys, key.String())
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// wirelessOnly returns true if nodes configurations differ only by
+// their wireless options
+func wirelessOnly(old, cur NodeConfig) bool {
+	old.Position, old.Range, old.Mobility = nil, 0, nil
+	cur.Position, cur.Range, cur.Mobility = nil, 0, nil
+	return reflect.DeepEqual(old, cur)
+}
+
+func planReload(old, cur *NetemTopology) reloadPlan {
+	plan := reloadPlan{changes: make([]TopologyChange, 0)}
+	addChange := func(action, kind, name string) {
+		plan.changes = append(plan.changes, TopologyChange{action, kind, name})
+	}
+
+	// nodes, interfaces of recreated nodes must be connected again
+	recreated := make(map[string]bool)
+	moved := make(map[string]bool)
+	for _, name := range sortedKeys(old.Nodes) {
+		if _, found := cur.Nodes[name]; !found {
+			plan.removeNodes = append(plan.removeNodes, name)
+			recreated[name] = true
+			addChange(CHANGE_REMOVED, CHANGE_NODE, name)
+		}
+	}
+	for _, name := range sortedKeys(cur.Nodes) {
+		nConfig := cur.Nodes[name]
+		oldConfig, found := old.Nodes[name]
+		// nodes are recreated when the definition of their kind changes
+		kind := nodeKind(nConfig.Type)
+		sameKind := reflect.DeepEqual(old.Kinds[kind], cur.Kinds[kind])
+		switch {
+		case !found:
+			plan.addNodes = append(plan.addNodes, name)
+			addChange(CHANGE_ADDED, CHANGE_NODE, name)
+		case sameKind && reflect.DeepEqual(oldConfig, nConfig):
+		case sameKind && wirelessOnly(oldConfig, nConfig):
+			plan.moveNodes = append(plan.moveNodes, name)
+			moved[name] = true
+			addChange(CHANGE_MODIFIED, CHANGE_NODE, name)
+		default:
+			plan.removeNodes = append(plan.removeNodes, name)
+			plan.addNodes = append(plan.addNodes, name)
+			recreated[name] = true
+			addChange(CHANGE_MODIFIED, CHANGE_NODE, name)
+		}
+	}
+	isRecreated := func(peers ...string) bool {
+		for _, peer := range peers {
+			if recreated[peerNodeName(peer)] {
+				return true
+			}
+		}
+		return false
+	}
+
+	// links
+	oldLinks := make(map[string]LinkConfig)
+	for _, lConfig := range old.Links {
+		oldLinks[linkName(lConfig)] = lConfig
+	}
+	newLinks := make(map[string]LinkConfig)
+	for _, lConfig := range cur.Links {
+		newLinks[linkName(lConfig)] = lConfig
+	}
+	for _, name := range sortedKeys(oldLinks) {
+		if _, found := newLinks[name]; !found {
+			plan.removeLinks = append(plan.removeLinks, oldLinks[name])
+			addChange(CHANGE_REMOVED, CHANGE_LINK, name)
+		}
+	}
+	for _, name := range sortedKeys(newLinks) {
+		lConfig := newLinks[name]
+		oldConfig, found := oldLinks[name]
+		switch {
+		case !found:
+			plan.addLinks = append(plan.addLinks, lConfig)
+			addChange(CHANGE_ADDED, CHANGE_LINK, name)
+		case isRecreated(lConfig.Peer1, lConfig.Peer2):
+			plan.removeLinks = append(plan.removeLinks, oldConfig)
+			plan.addLinks = append(plan.addLinks, lConfig)
+			if !reflect.DeepEqual(oldConfig, lConfig) {
+				addChange(CHANGE_MODIFIED, CHANGE_LINK, name)
+			}
+		case reflect.DeepEqual(oldConfig, lConfig):
+		case oldConfig.Mtu != lConfig.Mtu || oldConfig.TxQueueLen != lConfig.TxQueueLen:
+			// interface options can not be modified in place
+			plan.removeLinks = append(plan.removeLinks, oldConfig)
+			plan.addLinks = append(plan.addLinks, lConfig)
+			addChange(CHANGE_MODIFIED, CHANGE_LINK, name)
+		default:
+			plan.updateLinks = append(plan.updateLinks, lConfig)
+			addChange(CHANGE_MODIFIED, CHANGE_LINK, name)
+		}
+	}
+
+	// bridges
+	for _, name := range sortedKeys(old.Bridges) {
+		if _, found := cur.Bridges[name]; !found {
+			plan.removeBridges = append(plan.removeBridges, name)
+			addChange(CHANGE_REMOVED, CHANGE_BRIDGE, name)
+		}
+	}
+	for _, name := range sortedKeys(cur.Bridges) {
+		bConfig := cur.Bridges[name]
+		oldConfig, found := old.Bridges[name]
+		switch {
+		case !found:
+			plan.addBridges = append(plan.addBridges, name)
+			addChange(CHANGE_ADDED, CHANGE_BRIDGE, name)
+		case !reflect.DeepEqual(oldConfig, bConfig):
+			plan.removeBridges = append(plan.removeBridges, name)
+			plan.addBridges = append(plan.addBridges, name)
+			addChange(CHANGE_MODIFIED, CHANGE_BRIDGE, name)
+		case isRecreated(bConfig.Interfaces...):
+			plan.removeBridges = append(plan.removeBridges, name)
+			plan.addBridges = append(plan.addBridges, name)
+		}
+	}
+
+	// host interfaces
+	for _, name := range sortedKeys(old.HostIfs) {
+		if _, found := cur.HostIfs[name]; !found {
+			plan.removeHostIfs = append(plan.removeHostIfs, name)
+			addChange(CHANGE_REMOVED, CHANGE_HOSTIF, name)
+		}
+	}
+	for _, name := range sortedKeys(cur.HostIfs) {
+		hConfig := cur.HostIfs[name]
+		oldConfig, found := old.HostIfs[name]
+		switch {
+		case !found:
+			plan.addHostIfs = append(plan.addHostIfs, name)
+			addChange(CHANGE_ADDED, CHANGE_HOSTIF, name)
+		case !reflect.DeepEqual(oldConfig, hConfig):
+			plan.removeHostIfs = append(plan.removeHostIfs, name)
+			plan.addHostIfs = append(plan.addHostIfs, name)
+			addChange(CHANGE_MODIFIED, CHANGE_HOSTIF, name)
+		case isRecreated(hConfig.Interface):
+			plan.removeHostIfs = append(plan.removeHostIfs, name)
+			plan.addHostIfs = append(plan.addHostIfs, name)
+		}
+	}
+
+	// segments, wireless ones depend on the options of their members
+	for _, name := range sortedKeys(old.Segments) {
+		if _, found := cur.Segments[name]; !found {
+			plan.removeSegments = append(plan.removeSegments, name)
+			addChange(CHANGE_REMOVED, CHANGE_SEGMENT, name)
+		}
+	}
+	for _, name := range sortedKeys(cur.Segments) {
+		sConfig := cur.Segments[name]
+		oldConfig, found := old.Segments[name]
+
+		members := make([]string, len(sConfig.Members))
+		hasMoved := false
+		for idx, member := range sConfig.Members {
+			members[idx] = member.Peer
+			hasMoved = hasMoved || moved[peerNodeName(member.Peer)]
+		}
+
+		switch {
+		case !found:
+			plan.addSegments = append(plan.addSegments, name)
+			addChange(CHANGE_ADDED, CHANGE_SEGMENT, name)
+		case !reflect.DeepEqual(oldConfig, sConfig):
+			plan.removeSegments = append(plan.removeSegments, name)
+			plan.addSegments = append(plan.addSegments, name)
+			addChange(CHANGE_MODIFIED, CHANGE_SEGMENT, name)
+		case isRecreated(members...) || (sConfig.Wireless != nil && hasMoved):
+			plan.removeSegments = append(plan.removeSegments, name)
+			plan.addSegments = append(plan.addSegments, name)
+		}
+	}
+
+	return plan
+}
+
+func (t *NetemTopologyManager) removeNode(name string) error {
+	node := t.GetNode(name)
+	if node == nil {
+		return nil
+	}
+
+	if err := node.Close(); err != nil {
+		return fmt.Errorf("Unable to close node %s: %w", name, err)
+	}
+
+	for idx, n := range t.nodes {
+		if n == node {
+			t.nodes = append(t.nodes[:idx], t.nodes[idx+1:]...)
+			break
+		}
+	}
+	t.IdGenerator.Release(node.GetShortName())
+
+	t.moveLock.Lock()
+	delete(t.positions, name)
+	delete(t.mobility, name)
+	t.moveLock.Unlock()
+
+	return nil
+}
+
+func (t *NetemTopologyManager) addNode(name string, nConfig NodeConfig, kinds map[string]options.NodeKind) error {
+	shortName, err := t.IdGenerator.GetId(name)
+	if err != nil {
+		return err
+	}
+	node, err := CreateNode(t.prjID, name, shortName, nConfig, kinds)
+	if err != nil {
+		if node != nil {
+			node.Close()
+		}
+		return fmt.Errorf("Unable to create node %s: %w", name, err)
+	}
+	t.nodes = append(t.nodes, node)
+	t.setNodeWireless(name, nConfig)
+
+	if t.running {
+		if err := node.Start(); err != nil {
+			return fmt.Errorf("Unable to start node %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// setNodeWireless records the position and the mobility of a node
+func (t *NetemTopologyManager) setNodeWireless(name string, nConfig NodeConfig) {
+	t.moveLock.Lock()
+	defer t.moveLock.Unlock()
+
+	delete(t.positions, name)
+	delete(t.mobility, name)
+	if nConfig.Position != nil {
+		t.positions[name] = *nConfig.Position
+	}
+	if nConfig.Mobility != nil {
+		t.mobility[name] = *nConfig.Mobility
+	}
+}
+
+// removePeers deletes the interfaces connected to a link, a bridge or
+// a segment. Nothing has to be done if the topology does not run
+func (t *NetemTopologyManager) removePeers(peers ...NetemLinkPeer) error {
+	if !t.running {
+		return nil
+	}
+
+	for _, peer := range peers {
+		if err := peer.Node.RemoveInterface(peer.IfIndex); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) removeLink(lConfig LinkConfig) error {
+	l, err := t.getLinkByPeers(lConfig.Peer1, lConfig.Peer2)
+	if err != nil {
+		return err
+	}
+
+	t.stopLinkProfile(l)
+	t.stopLinkFlap(l)
+	if err := t.removePeers(l.Peer1, l.Peer2); err != nil {
+		return fmt.Errorf("Unable to remove link %s: %w", linkName(lConfig), err)
+	}
+
+	for idx, other := range t.links {
+		if other == l {
+			t.links = append(t.links[:idx], t.links[idx+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// startLinkRunners starts the profile and the flapping of a link
+func (t *NetemTopologyManager) startLinkRunners(l *NetemLink) error {
+	if !t.running {
+		return nil
+	}
+
+	if l.Config.Profile != nil {
+		err := t.StartLinkProfile(
+			l.Peer1.Node.GetName(), l.Peer1.IfIndex,
+			l.Config.Profile.Name, l.Config.Profile.Loop)
+		if err != nil {
+			return err
+		}
+	}
+	if l.Config.Flap != nil {
+		return t.StartLinkFlap(l.Config.Peer1, l.Config.Peer2, *l.Config.Flap)
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) addLink(lConfig LinkConfig) error {
+	l := t.loadLink(lConfig)
+	t.links = append(t.links, l)
+
+	if t.running {
+		if err := t.setupLink(l); err != nil {
+			return err
+		}
+	}
+	return t.startLinkRunners(l)
+}
+
+func (t *NetemTopologyManager) updateLink(lConfig LinkConfig) error {
+	l, err := t.getLinkByPeers(lConfig.Peer1, lConfig.Peer2)
+	if err != nil {
+		return err
+	}
+
+	// profiles and flapping are restarted with the new configuration
+	t.stopLinkProfile(l)
+	t.stopLinkFlap(l)
+	l.lock.Lock()
+	if t.running {
+		if err := t.applyLinkConfig(l, lConfig); err != nil {
+			l.lock.Unlock()
+			return err
+		}
+	}
+	l.Config = lConfig
+	l.lock.Unlock()
+
+	return t.startLinkRunners(l)
+}
+
+func (t *NetemTopologyManager) getBridge(name string) *NetemBridge {
+	for _, br := range t.bridges {
+		if br.ConfigName == name {
+			return br
+		}
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) removeBridge(name string) error {
+	br := t.getBridge(name)
+	if br == nil {
+		return nil
+	}
+
+	if t.running {
+		if err := t.removePeers(br.Peers...); err != nil {
+			return fmt.Errorf("Unable to remove bridge %s: %w", name, err)
+		}
+
+		rootNs := link.GetRootNetns()
+		defer rootNs.Close()
+		if err := link.DeleteLink(br.Name, rootNs); err != nil {
+			return fmt.Errorf("Unable to remove bridge %s: %w", name, err)
+		}
+	}
+
+	for idx, other := range t.bridges {
+		if other == br {
+			t.bridges = append(t.bridges[:idx], t.bridges[idx+1:]...)
+			break
+		}
+	}
+	t.IdGenerator.Release(br.ShortName)
+	return nil
+}
+
+func (t *NetemTopologyManager) addBridge(name string, bConfig BridgeConfig) error {
+	br, err := t.loadBridge(name, bConfig)
+	if err != nil {
+		return err
+	}
+	t.bridges = append(t.bridges, br)
+
+	if t.running {
+		return t.setupBridge(br)
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) getHostIf(name string) *NetemHostIf {
+	for _, hostIf := range t.hostIfs {
+		if hostIf.ConfigName == name {
+			return hostIf
+		}
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) removeHostIf(name string) error {
+	hostIf := t.getHostIf(name)
+	if hostIf == nil {
+		return nil
+	}
+
+	if err := t.detachHostIf(hostIf); err != nil {
+		return fmt.Errorf("Unable to remove host interface %s: %w", name, err)
+	}
+
+	for idx, other := range t.hostIfs {
+		if other == hostIf {
+			t.hostIfs = append(t.hostIfs[:idx], t.hostIfs[idx+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) addHostIf(name string, hConfig HostIfConfig) error {
+	hostIf := t.loadHostIf(name, hConfig)
+	t.hostIfs = append(t.hostIfs, hostIf)
+
+	if t.running && hostIf.Peer.Node.IsRunning() {
+		return t.attachHostIf(hostIf)
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) getSegment(name string) *NetemSegment {
+	for _, segment := range t.segments {
+		if segment.Name == name {
+			return segment
+		}
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) removeSegment(name string) error {
+	segment := t.getSegment(name)
+	if segment == nil {
+		return nil
+	}
+
+	if t.running {
+		if err := t.removePeers(segment.Members...); err != nil {
+			return fmt.Errorf("Unable to remove segment %s: %w", name, err)
+		}
+		if err := t.closeSegment(segment); err != nil {
+			return fmt.Errorf("Unable to remove segment %s: %w", name, err)
+		}
+	}
+
+	for idx, other := range t.segments {
+		if other == segment {
+			t.segments = append(t.segments[:idx], t.segments[idx+1:]...)
+			break
+		}
+	}
+	t.IdGenerator.Release(segment.ShortName)
+	return nil
+}
+
+func (t *NetemTopologyManager) addSegment(name string, sConfig SegmentConfig, nodes map[string]NodeConfig) error {
+	segment, err := t.loadSegment(name, sConfig, nodes)
+	if err != nil {
+		return err
+	}
+	t.segments = append(t.segments, segment)
+
+	if t.running {
+		return t.setupSegment(segment)
+	}
+	return nil
+}
+
+// Reload applies the differences between the loaded topology and the
+// network file: only the modified nodes, links, bridges, host interfaces
+// and segments are recreated, link parameters are modified in place when
+// possible. If a step fails, the topology is recreated from the network
+// file to not keep a topology partially modified
+func (t *NetemTopologyManager) Reload() ([]*proto.RunResponse_NodeMessages, []TopologyChange, error) {
+	topology, errors := CheckTopology(path.Join(t.path, networkFilename))
+	if len(errors) > 0 {
+		msg := ""
+		for _, err := range errors {
+			msg += "\n\t" + err.Error()
+		}
+		return nil, nil, fmt.Errorf("Topology is not valid:%s\n", msg)
+	}
+
+	old := t.topology
+	if old == nil {
+		old = &NetemTopology{}
+	}
+	plan := planReload(old, topology)
+
+	nodeMessages, err := t.applyReload(topology, plan)
+	if err != nil {
+		t.logger.Warnf("Reload failed, recreate the topology: %v", err)
+		messages, rErr := t.recreate()
+		if rErr != nil {
+			return messages, plan.changes, fmt.Errorf("Unable to recreate topology after a failed reload (%v): %w", err, rErr)
+		}
+		return messages, plan.changes, nil
+	}
+	return nodeMessages, plan.changes, nil
+}
+
+// applyReload executes the steps of plan to go to topology
+func (t *NetemTopologyManager) applyReload(topology *NetemTopology, plan reloadPlan) ([]*proto.RunResponse_NodeMessages, error) {
+	var nodeMessages []*proto.RunResponse_NodeMessages
+
+	// mobility runners update the segments, they are stopped during
+	// the reload then restarted from the current positions
+	t.stopAllNodeMobility()
+
+	// 1 - remove elements, interfaces are deleted before the nodes
+	for _, name := range plan.removeSegments {
+		if err := t.removeSegment(name); err != nil {
+			return nodeMessages, err
+		}
+	}
+	for _, name := range plan.removeBridges {
+		if err := t.removeBridge(name); err != nil {
+			return nodeMessages, err
+		}
+	}
+	for _, name := range plan.removeHostIfs {
+		if err := t.removeHostIf(name); err != nil {
+			return nodeMessages, err
+		}
+	}
+	for _, lConfig := range plan.removeLinks {
+		if err := t.removeLink(lConfig); err != nil {
+			return nodeMessages, err
+		}
+	}
+	for _, name := range plan.removeNodes {
+		if err := t.removeNode(name); err != nil {
+			return nodeMessages, err
+		}
+	}
+
+	// 2 - add nodes and update nodes moved in wireless segments
+	for _, name := range plan.addNodes {
+		if err := t.addNode(name, topology.Nodes[name], topology.Kinds); err != nil {
+			return nodeMessages, err
+		}
+	}
+	for _, name := range plan.moveNodes {
+		t.setNodeWireless(name, topology.Nodes[name])
+	}
+
+	// 3 - add and update links, bridges, host interfaces and segments
+	for _, lConfig := range plan.updateLinks {
+		if err := t.updateLink(lConfig); err != nil {
+			return nodeMessages, err
+		}
+	}
+	for _, lConfig := range plan.addLinks {
+		if err := t.addLink(lConfig); err != nil {
+			return nodeMessages, err
+		}
+	}
+	for _, name := range plan.addBridges {
+		if err := t.addBridge(name, topology.Bridges[name]); err != nil {
+			return nodeMessages, err
+		}
+	}
+	for _, name := range plan.addHostIfs {
+		if err := t.addHostIf(name, topology.HostIfs[name]); err != nil {
+			return nodeMessages, err
+		}
+	}
+	for _, name := range plan.addSegments {
+		if err := t.addSegment(name, topology.Segments[name], topology.Nodes); err != nil {
+			return nodeMessages, err
+		}
+	}
+	t.topology = topology
+
+	if !t.running {
+		return nodeMessages, nil
+	}
+
+	// 4 - load configs of new nodes and restart mobility
+	configPath := path.Join(t.path, configDir)
+	for _, name := range plan.addNodes {
+		messages, err := t.GetNode(name).LoadConfig(configPath)
+		nodeMessages = append(nodeMessages, &proto.RunResponse_NodeMessages{
+			Name:     name,
+			Messages: messages,
+		})
+		if err != nil {
+			return nodeMessages, err
+		}
+	}
+	return nodeMessages, t.startAllNodeMobility()
+}
+
+// recreate closes the topology then loads it from the network file,
+// it runs again if it was running
+func (t *NetemTopologyManager) recreate() ([]*proto.RunResponse_NodeMessages, error) {
+	running := t.running
+	if err := t.Close(); err != nil {
+		return nil, err
+	}
+	t.running = false
+
+	if err := t.Load(); err != nil {
+		return nil, err
+	}
+	if !running {
+		return nil, nil
+	}
+	return t.Run()
+}
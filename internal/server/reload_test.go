@@ -0,0 +1,156 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+const reloadBaseNetwork = `
+nodes:
+  R1:
+    type: docker.router
+  R2:
+    type: docker.router
+  host:
+    type: docker.host
+links:
+- peer1: R1.0
+  peer2: R2.0
+  delay: 10
+- peer1: host.0
+  peer2: R1.1
+bridges:
+  br:
+    host: eth0
+    interfaces:
+    - R2.1`
+
+func TestReload_Plan(t *testing.T) {
+	tests := []struct {
+		desc            string
+		network         string
+		expectedChanges []TopologyChange
+		expectedRemoved int // number of removed links
+		expectedAdded   int // number of added links
+		expectedUpdated int // number of links modified in place
+	}{
+		{
+			desc:            "Reload: no change",
+			network:         reloadBaseNetwork,
+			expectedChanges: []TopologyChange{},
+		},
+		{
+			desc: "Reload: add link and modify link parameters",
+			network: `
+nodes:
+  R1:
+    type: docker.router
+  R2:
+    type: docker.router
+  host:
+    type: docker.host
+links:
+- peer1: R1.0
+  peer2: R2.0
+  delay: 20
+- peer1: host.0
+  peer2: R1.1
+- peer1: host.1
+  peer2: R2.2
+bridges:
+  br:
+    host: eth0
+    interfaces:
+    - R2.1`,
+			expectedChanges: []TopologyChange{
+				{CHANGE_MODIFIED, CHANGE_LINK, "R1.0-R2.0"},
+				{CHANGE_ADDED, CHANGE_LINK, "host.1-R2.2"},
+			},
+			expectedAdded:   1,
+			expectedUpdated: 1,
+		},
+		{
+			desc: "Reload: modify node",
+			network: `
+nodes:
+  R1:
+    type: docker.router
+  R2:
+    type: docker.router
+    ipv6: true
+  host:
+    type: docker.host
+links:
+- peer1: R1.0
+  peer2: R2.0
+  delay: 10
+- peer1: host.0
+  peer2: R1.1
+bridges:
+  br:
+    host: eth0
+    interfaces:
+    - R2.1`,
+			expectedChanges: []TopologyChange{
+				{CHANGE_MODIFIED, CHANGE_NODE, "R2"},
+			},
+			// link R1-R2 is recreated
+			expectedRemoved: 1,
+			expectedAdded:   1,
+		},
+		{
+			desc: "Reload: remove node",
+			network: `
+nodes:
+  R1:
+    type: docker.router
+  R2:
+    type: docker.router
+links:
+- peer1: R1.0
+  peer2: R2.0
+  delay: 10
+  mtu: 9000
+bridges:
+  br:
+    host: eth0
+    interfaces:
+    - R2.1`,
+			expectedChanges: []TopologyChange{
+				{CHANGE_REMOVED, CHANGE_NODE, "host"},
+				{CHANGE_REMOVED, CHANGE_LINK, "host.0-R1.1"},
+				{CHANGE_MODIFIED, CHANGE_LINK, "R1.0-R2.0"},
+			},
+			expectedRemoved: 2,
+			expectedAdded:   1,
+		},
+	}
+
+	var old NetemTopology
+	if err := yaml.Unmarshal([]byte(reloadBaseNetwork), &old); err != nil {
+		t.Fatalf("Unable to parse base network: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var cur NetemTopology
+			if err := yaml.Unmarshal([]byte(tt.network), &cur); err != nil {
+				t.Fatalf("Unable to parse network: %v", err)
+			}
+
+			plan := planReload(&old, &cur)
+			if !reflect.DeepEqual(plan.changes, tt.expectedChanges) {
+				t.Errorf("Wrong changes: %v != %v", plan.changes, tt.expectedChanges)
+			}
+			if len(plan.removeLinks) != tt.expectedRemoved ||
+				len(plan.addLinks) != tt.expectedAdded ||
+				len(plan.updateLinks) != tt.expectedUpdated {
+				t.Errorf(
+					"Wrong link operations: %d/%d/%d removed/added/updated",
+					len(plan.removeLinks), len(plan.addLinks), len(plan.updateLinks))
+			}
+		})
+	}
+}
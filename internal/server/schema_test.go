@@ -0,0 +1,127 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/mroy31/gonetem/internal/options"
+)
+
+const schemaPath = "../../conf/network.schema.json"
+
+// yamlKeys returns the keys of the yaml mapping decoded in the struct
+// typ, with the default names of yaml.v2 and the inline fields
+func yamlKeys(typ reflect.Type) []string {
+	keys := make([]string, 0)
+	for idx := 0; idx < typ.NumField(); idx++ {
+		field := typ.Field(idx)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("yaml"), ",")
+		if tag[0] == "-" {
+			continue
+		}
+		inline := false
+		for _, flag := range tag[1:] {
+			inline = inline || flag == "inline"
+		}
+		if inline {
+			keys = append(keys, yamlKeys(field.Type)...)
+			continue
+		}
+
+		name := tag[0]
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		keys = append(keys, name)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// schemaKeys returns the keys allowed by the schema object found at
+// path, the names of propertyNames if present, the properties otherwise
+func schemaKeys(t *testing.T, schema map[string]interface{}, path []string) []string {
+	object := schema
+	for _, elt := range path {
+		next, ok := object[elt].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Schema object %s not found", strings.Join(path, "/"))
+		}
+		object = next
+	}
+
+	keys := make([]string, 0)
+	if names, ok := object["propertyNames"].(map[string]interface{}); ok {
+		for _, name := range names["enum"].([]interface{}) {
+			keys = append(keys, name.(string))
+		}
+	} else {
+		for name := range object["properties"].(map[string]interface{}) {
+			keys = append(keys, name)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestSchema_YamlKeys(t *testing.T) {
+	data, err := ioutil.ReadFile(schemaPath)
+	if err != nil {
+		t.Fatalf("Unable to read schema: %v", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Unable to parse schema: %v", err)
+	}
+
+	tests := []struct {
+		desc   string
+		config interface{}
+		path   []string
+	}{
+		{desc: "Schema: topology", config: topologySource{}, path: []string{}},
+		{desc: "Schema: kind", config: options.NodeKind{}, path: []string{"definitions", "kind"}},
+		{desc: "Schema: kind file", config: options.NodeKindFile{}, path: []string{"definitions", "kind", "properties", "files", "items"}},
+		{desc: "Schema: kind probe", config: options.NodeKindProbe{}, path: []string{"definitions", "kind", "properties", "ready"}},
+		{desc: "Schema: node", config: NodeConfig{}, path: []string{"definitions", "node"}},
+		{desc: "Schema: vrrp", config: VrrpOptions{}, path: []string{"definitions", "node", "properties", "vrrps", "items"}},
+		{desc: "Schema: switch port", config: BridgePortConfig{}, path: []string{"definitions", "node", "properties", "ports", "additionalProperties"}},
+		{desc: "Schema: position", config: Position{}, path: []string{"definitions", "position"}},
+		{desc: "Schema: mobility", config: MobilityConfig{}, path: []string{"definitions", "node", "properties", "mobility"}},
+		{desc: "Schema: waypoint", config: Waypoint{}, path: []string{"definitions", "node", "properties", "mobility", "properties", "waypoints", "items"}},
+		{desc: "Schema: queue", config: QueueConfig{}, path: []string{"definitions", "queue"}},
+		{desc: "Schema: htb class", config: HtbClassConfig{}, path: []string{"definitions", "queue", "properties", "classes", "items"}},
+		{desc: "Schema: link params", config: LinkParams{}, path: []string{"definitions", "linkParams"}},
+		{desc: "Schema: gemodel", config: LossGEModel{}, path: []string{"definitions", "linkParams", "properties", "loss_gemodel"}},
+		{desc: "Schema: loss state", config: LossStateModel{}, path: []string{"definitions", "linkParams", "properties", "loss_state"}},
+		{desc: "Schema: direction params", config: LinkParams{}, path: []string{"definitions", "directionParams"}},
+		{desc: "Schema: link", config: LinkConfig{}, path: []string{"definitions", "link"}},
+		{desc: "Schema: profile", config: LinkProfileConfig{}, path: []string{"definitions", "link", "properties", "profile"}},
+		{desc: "Schema: flap", config: LinkFlapConfig{}, path: []string{"definitions", "link", "properties", "flap"}},
+		{desc: "Schema: bridge", config: BridgeConfig{}, path: []string{"definitions", "bridge"}},
+		{desc: "Schema: hostif", config: HostIfConfig{}, path: []string{"definitions", "hostif"}},
+		{desc: "Schema: segment", config: SegmentConfig{}, path: []string{"definitions", "segment"}},
+		{desc: "Schema: segment member", config: SegmentMember{}, path: []string{"definitions", "segment", "properties", "members", "items"}},
+		{desc: "Schema: wireless", config: WirelessConfig{}, path: []string{"definitions", "segment", "properties", "wireless"}},
+		{desc: "Schema: generator", config: GeneratorConfig{}, path: []string{"definitions", "generator"}},
+		{desc: "Schema: include", config: IncludeConfig{}, path: []string{"definitions", "include"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			keys := yamlKeys(reflect.TypeOf(tt.config))
+			expected := schemaKeys(t, schema, tt.path)
+			if !reflect.DeepEqual(keys, expected) {
+				t.Errorf("Yaml keys and schema differ:\n%v\n!=\n%v", keys, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/mroy31/gonetem/internal/link"
+	"github.com/mroy31/gonetem/internal/options"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	// name of the bridge in the namespace of a segment
+	segmentHubName = "hub"
+)
+
+// SegmentMember is a node interface connected to a segment. Parameters
+// are applied to the traffic sent and received by this interface
+type SegmentMember struct {
+	Peer       string
+	LinkParams `yaml:",inline"`
+}
+
+// SegmentConfig defines a shared medium between several node interfaces
+type SegmentConfig struct {
+	Members []SegmentMember
+	// options of the member interfaces
+	Mtu        int `yaml:",omitempty"`
+	TxQueueLen int `yaml:"txqueuelen,omitempty"`
+	// impairments between members depend on their positions
+	Wireless *WirelessConfig `yaml:",omitempty"`
+}
+
+// NetemSegment is a broadcast domain built with a hub, i.e. a linux
+// bridge without MAC learning, in a dedicated namespace
+type NetemSegment struct {
+	Name       string
+	ShortName  string
+	Netns      string
+	Members    []NetemLinkPeer
+	Params     []LinkParams
+	Mtu        int
+	TxQueueLen int
+	Wireless   *wirelessSegment
+}
+
+func checkSegmentConfig(name string, sConfig SegmentConfig, segments []string, prjPath string) []error {
+	errors := make([]error, 0)
+
+	if isEntryExist(segments, name) {
+		errors = append(errors, fmt.Errorf("Segment '%s' already exist", name))
+	}
+	if !nameRE.MatchString(name) {
+		errors = append(errors, fmt.Errorf("Segment: '%s' name field is not valid", name))
+	}
+	if len(sConfig.Members) < 2 {
+		errors = append(errors, fmt.Errorf("Segment %s: at least 2 members are required", name))
+	}
+	if err := checkIfOptions(sConfig.Mtu, sConfig.TxQueueLen); err != nil {
+		errors = append(errors, fmt.Errorf("Segment %s: %w", name, err))
+	}
+
+	for _, member := range sConfig.Members {
+		for _, err := range checkLinkParams(member.LinkParams, prjPath) {
+			errors = append(errors, fmt.Errorf("Segment %s (%s): %w", name, member.Peer, err))
+		}
+	}
+	if sConfig.Wireless != nil {
+		for _, err := range checkWirelessConfig(*sConfig.Wireless) {
+			errors = append(errors, fmt.Errorf("Segment %s: %w", name, err))
+		}
+	}
+
+	return errors
+}
+
+func (t *NetemTopologyManager) loadSegment(name string, sConfig SegmentConfig, nodes map[string]NodeConfig) (*NetemSegment, error) {
+	shortName, err := t.IdGenerator.GetId(name)
+	if err != nil {
+		return nil, err
+	}
+
+	segment := &NetemSegment{
+		Name:      name,
+		ShortName: shortName,
+		Netns:     options.NETEM_ID + t.prjID + "." + shortName,
+		Members:   make([]NetemLinkPeer, len(sConfig.Members)),
+		Params:    make([]LinkParams, len(sConfig.Members)),
+	}
+	segment.Mtu, segment.TxQueueLen = ifOptions(sConfig.Mtu, sConfig.TxQueueLen)
+	if sConfig.Wireless != nil {
+		segment.Wireless = &wirelessSegment{
+			Config:     *sConfig.Wireless,
+			Ranges:     make([]float64, len(sConfig.Members)),
+			Macs:       make([]net.HardwareAddr, len(sConfig.Members)),
+			HubIfNames: make([]string, len(sConfig.Members)),
+		}
+	}
+
+	for idx, member := range sConfig.Members {
+		peer := strings.Split(member.Peer, ".")
+		peerIdx, _ := strconv.Atoi(peer[1])
+
+		segment.Members[idx] = NetemLinkPeer{
+			Node:    t.GetNode(peer[0]),
+			IfIndex: peerIdx,
+		}
+		segment.Params[idx] = member.LinkParams
+		if segment.Wireless != nil {
+			segment.Wireless.Ranges[idx] = nodes[peer[0]].Range
+		}
+	}
+
+	return segment, nil
+}
+
+func (t *NetemTopologyManager) setupSegment(segment *NetemSegment) error {
+	segmentNs, err := link.CreateNetns(segment.Netns)
+	if err != nil {
+		return err
+	}
+	defer segmentNs.Close()
+
+	hub, err := link.CreateHub(segmentHubName, segmentNs)
+	if err != nil {
+		return err
+	}
+
+	for idx := range segment.Members {
+		if err := t.setupSegmentMember(segment, segmentNs, hub, idx); err != nil {
+			return err
+		}
+	}
+
+	if segment.Wireless != nil {
+		return t.setupWirelessPorts(segment, segmentNs)
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) setupSegmentMember(segment *NetemSegment, segmentNs netns.NsHandle, hub *netlink.Bridge, idx int) error {
+	peer, params := segment.Members[idx], segment.Params[idx]
+	peerNetns, err := peer.Node.GetNetns()
+	if err != nil {
+		return err
+	}
+	defer peerNetns.Close()
+
+	// names of hub ports are only unique in the segment namespace
+	hubIfName := fmt.Sprintf("%s.%d", peer.Node.GetShortName(), peer.IfIndex)
+	peerIfName := fmt.Sprintf("%s%s.%d", t.prjID, peer.Node.GetShortName(), peer.IfIndex)
+	_, err = link.CreateVethLink(
+		hubIfName, segmentNs,
+		peerIfName, peerNetns,
+		segment.Mtu, segment.TxQueueLen,
+	)
+	if err != nil {
+		return fmt.Errorf(
+			"Unable to create link %s-%s.%d: %v",
+			segment.Name, peer.Node.GetName(), peer.IfIndex, err,
+		)
+	}
+
+	if err := link.SetInterfaceState(hubIfName, segmentNs, link.IFSTATE_UP); err != nil {
+		return err
+	}
+	if err := link.AttachToBridge(hub, hubIfName, segmentNs); err != nil {
+		return err
+	}
+
+	// impairments are applied on both sides to delay/drop the traffic
+	// sent by the member and the traffic received from the segment.
+	// In a wireless segment, hub ports have the impairments between
+	// members so only the traffic sent by the member is impaired
+	if segment.Wireless != nil {
+		mac, err := link.GetInterfaceMac(peerIfName, peerNetns)
+		if err != nil {
+			return err
+		}
+		segment.Wireless.Macs[idx] = mac
+		segment.Wireless.HubIfNames[idx] = hubIfName
+	} else if err := t.createLinkQdiscs(hubIfName, segmentNs, params, segment.Mtu); err != nil {
+		return err
+	}
+	if err := t.createLinkQdiscs(peerIfName, peerNetns, params, segment.Mtu); err != nil {
+		return err
+	}
+
+	return peer.Node.AddInterface(peerIfName, peer.IfIndex, peerNetns)
+}
+
+func (t *NetemTopologyManager) closeSegment(segment *NetemSegment) error {
+	// interfaces of the hub are removed with the namespace
+	return link.DeleteNetns(segment.Netns)
+}
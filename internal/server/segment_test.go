@@ -0,0 +1,128 @@
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+const segmentNodes = `
+nodes:
+  R1:
+    type: docker.router
+  host:
+    type: docker.host
+`
+
+func TestSegment_CheckTopology(t *testing.T) {
+	tests := []struct {
+		desc          string
+		network       string
+		expectedError bool
+	}{
+		{
+			desc: "Segment: valid segment",
+			network: segmentNodes + `
+segments:
+  lan:
+    members:
+    - peer: R1.0
+      delay: 10
+    - peer: host.0
+    mtu: 9000`,
+		},
+		{
+			desc: "Segment: one member",
+			network: segmentNodes + `
+segments:
+  lan:
+    members:
+    - peer: R1.0`,
+			expectedError: true,
+		},
+		{
+			desc: "Segment: member already linked",
+			network: segmentNodes + `
+links:
+- peer1: R1.0
+  peer2: host.0
+segments:
+  lan:
+    members:
+    - peer: R1.0
+    - peer: host.1`,
+			expectedError: true,
+		},
+		{
+			desc: "Segment: member used by a host interface",
+			network: segmentNodes + `
+hostifs:
+  uplink:
+    host: lo
+    interface: R1.0
+segments:
+  lan:
+    members:
+    - peer: R1.0
+    - peer: host.0`,
+			expectedError: true,
+		},
+		{
+			desc: "Segment: invalid member parameters",
+			network: segmentNodes + `
+segments:
+  lan:
+    members:
+    - peer: R1.0
+      loss: 200
+    - peer: host.0`,
+			expectedError: true,
+		},
+		{
+			desc: "Segment: invalid mtu",
+			network: segmentNodes + `
+segments:
+  lan:
+    members:
+    - peer: R1.0
+    - peer: host.0
+    mtu: 10`,
+			expectedError: true,
+		},
+		{
+			desc: "Segment: wireless without positions",
+			network: segmentNodes + `
+segments:
+  lan:
+    members:
+    - peer: R1.0
+    - peer: host.0
+    wireless:
+      range: 100`,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			dir, err := ioutil.TempDir("/tmp", "ntmtst")
+			if err != nil {
+				t.Fatalf("Unable to create temp folder: %v", err)
+			}
+			defer os.RemoveAll(dir)
+
+			filepath := path.Join(dir, networkFilename)
+			if err := ioutil.WriteFile(filepath, []byte(tt.network), 0644); err != nil {
+				t.Fatalf("Unable to create network file: %v", err)
+			}
+
+			_, errors := CheckTopology(filepath)
+			if len(errors) > 0 && !tt.expectedError {
+				t.Fatalf("Unexpected errors: %v", errors)
+			} else if len(errors) == 0 && tt.expectedError {
+				t.Fatalf("An error is expected")
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"path"
 	"regexp"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/moby/term"
@@ -73,15 +74,24 @@ func (s *netemServer) PullImages(empty *empty.Empty, stream proto.Netem_PullImag
 		options.IMG_SERVER,
 	}
 
+	images := make([]string, 0)
+	for _, imgT := range imageTypes {
+		images = append(images, options.GetDockerImageId(imgT))
+	}
+	// images of the kinds declared in the server config
+	for _, name := range sortedKeys(options.ServerConfig.Kinds) {
+		if image := options.ServerConfig.Kinds[name].Image; image != "" && !isEntryExist(images, image) {
+			images = append(images, image)
+		}
+	}
+
 	client, err := docker.NewDockerClient()
 	if err != nil {
 		return err
 	}
 	defer client.Close()
 
-	for _, imgT := range imageTypes {
-		imgID := options.GetDockerImageId(imgT)
-
+	for _, imgID := range images {
 		stream.Send(&proto.PullSrvMsg{
 			Code:  proto.PullSrvMsg_START,
 			Image: imgID,
@@ -224,6 +234,48 @@ func (s *netemServer) ReadNetworkFile(ctx context.Context, request *proto.Projec
 	}, nil
 }
 
+func (s *netemServer) ReadExpandedNetworkFile(ctx context.Context, request *proto.ProjectRequest) (*proto.FileResponse, error) {
+	project := GetProject(request.GetId())
+	if project == nil {
+		return nil, &ProjectNotFoundError{request.GetId()}
+	}
+
+	data, err := project.Topology.ReadExpandedNetworkFile()
+	if err != nil {
+		return &proto.FileResponse{
+			Status: &proto.Status{
+				Code:  proto.StatusCode_ERROR,
+				Error: err.Error(),
+			},
+		}, nil
+	}
+	return &proto.FileResponse{
+		Status: &proto.Status{Code: proto.StatusCode_OK},
+		Data:   data,
+	}, nil
+}
+
+func (s *netemServer) Export(ctx context.Context, request *proto.ExportRequest) (*proto.FileResponse, error) {
+	project := GetProject(request.GetPrjId())
+	if project == nil {
+		return nil, &ProjectNotFoundError{request.GetPrjId()}
+	}
+
+	data, err := project.Topology.Export(project.Name, request.GetFormat())
+	if err != nil {
+		return &proto.FileResponse{
+			Status: &proto.Status{
+				Code:  proto.StatusCode_ERROR,
+				Error: err.Error(),
+			},
+		}, nil
+	}
+	return &proto.FileResponse{
+		Status: &proto.Status{Code: proto.StatusCode_OK},
+		Data:   data,
+	}, nil
+}
+
 func (s *netemServer) WriteNetworkFile(ctx context.Context, request *proto.WNetworkRequest) (*proto.AckResponse, error) {
 	project := GetProject(request.GetId())
 	if project == nil {
@@ -262,15 +314,23 @@ func (s *netemServer) Reload(ctx context.Context, request *proto.ProjectRequest)
 		return nil, &ProjectNotFoundError{request.GetId()}
 	}
 
-	nodeMessages, err := project.Topology.Reload()
+	nodeMessages, changes, err := project.Topology.Reload()
 	if err != nil {
 		return nil, err
 	}
 
-	return &proto.RunResponse{
+	response := &proto.RunResponse{
 		Status:       &proto.Status{Code: proto.StatusCode_OK},
 		NodeMessages: nodeMessages,
-	}, nil
+	}
+	for _, change := range changes {
+		response.Changes = append(response.Changes, &proto.RunResponse_Change{
+			Action: change.Action,
+			Kind:   change.Kind,
+			Name:   change.Name,
+		})
+	}
+	return response, nil
 }
 
 func (s *netemServer) Start(ctx context.Context, request *proto.NodeRequest) (*proto.AckResponse, error) {
@@ -341,6 +401,22 @@ func (s *netemServer) Check(ctx context.Context, request *proto.ProjectRequest)
 	}, nil
 }
 
+func (s *netemServer) Lint(ctx context.Context, request *proto.ProjectRequest) (*proto.LintResponse, error) {
+	project := GetProject(request.GetId())
+	if project == nil {
+		return nil, &ProjectNotFoundError{request.GetId()}
+	}
+
+	issues := make([]string, 0)
+	for _, err := range project.Topology.Lint() {
+		issues = append(issues, err.Error())
+	}
+	return &proto.LintResponse{
+		Status: &proto.Status{Code: proto.StatusCode_OK},
+		Issues: issues,
+	}, nil
+}
+
 func (s *netemServer) SetIfState(ctx context.Context, request *proto.NodeIfStateRequest) (*proto.AckResponse, error) {
 	project := GetProject(request.GetPrjId())
 	if project == nil {
@@ -369,6 +445,186 @@ func (s *netemServer) SetIfState(ctx context.Context, request *proto.NodeIfState
 	}, nil
 }
 
+func (s *netemServer) SetLinkParams(ctx context.Context, request *proto.LinkParamsRequest) (*proto.AckResponse, error) {
+	project := GetProject(request.GetPrjId())
+	if project == nil {
+		return nil, &ProjectNotFoundError{request.GetPrjId()}
+	}
+
+	if err := project.Topology.SetLinkParams(
+		request.GetNode(), int(request.GetIfIndex()), request.GetParams()); err != nil {
+		return &proto.AckResponse{
+			Status: &proto.Status{
+				Code:  proto.StatusCode_ERROR,
+				Error: err.Error(),
+			},
+		}, nil
+	}
+
+	return &proto.AckResponse{
+		Status: &proto.Status{Code: proto.StatusCode_OK},
+	}, nil
+}
+
+func (s *netemServer) LinkProfile(ctx context.Context, request *proto.LinkProfileRequest) (*proto.AckResponse, error) {
+	project := GetProject(request.GetPrjId())
+	if project == nil {
+		return nil, &ProjectNotFoundError{request.GetPrjId()}
+	}
+
+	var err error
+	node, ifIndex := request.GetNode(), int(request.GetIfIndex())
+	switch request.GetAction() {
+	case proto.LinkProfileRequest_START:
+		err = project.Topology.StartLinkProfile(node, ifIndex, request.GetProfile(), request.GetLoop())
+	case proto.LinkProfileRequest_PAUSE:
+		err = project.Topology.SetLinkProfileAction(node, ifIndex, profilePause)
+	case proto.LinkProfileRequest_RESUME:
+		err = project.Topology.SetLinkProfileAction(node, ifIndex, profileResume)
+	case proto.LinkProfileRequest_STOP:
+		err = project.Topology.SetLinkProfileAction(node, ifIndex, profileStop)
+	}
+
+	if err != nil {
+		return &proto.AckResponse{
+			Status: &proto.Status{
+				Code:  proto.StatusCode_ERROR,
+				Error: err.Error(),
+			},
+		}, nil
+	}
+
+	return &proto.AckResponse{
+		Status: &proto.Status{Code: proto.StatusCode_OK},
+	}, nil
+}
+
+func (s *netemServer) SetLinkState(ctx context.Context, request *proto.LinkStateRequest) (*proto.AckResponse, error) {
+	project := GetProject(request.GetPrjId())
+	if project == nil {
+		return nil, &ProjectNotFoundError{request.GetPrjId()}
+	}
+
+	var state link.IfState
+	switch request.GetState() {
+	case proto.IfState_DOWN:
+		state = link.IFSTATE_DOWN
+	case proto.IfState_UP:
+		state = link.IFSTATE_UP
+	}
+
+	if err := project.Topology.SetLinkState(request.GetPeer1(), request.GetPeer2(), state); err != nil {
+		return &proto.AckResponse{
+			Status: &proto.Status{
+				Code:  proto.StatusCode_ERROR,
+				Error: err.Error(),
+			},
+		}, nil
+	}
+
+	return &proto.AckResponse{
+		Status: &proto.Status{Code: proto.StatusCode_OK},
+	}, nil
+}
+
+func (s *netemServer) SetLinkFlap(ctx context.Context, request *proto.LinkFlapRequest) (*proto.AckResponse, error) {
+	project := GetProject(request.GetPrjId())
+	if project == nil {
+		return nil, &ProjectNotFoundError{request.GetPrjId()}
+	}
+
+	var err error
+	switch request.GetMode() {
+	case proto.LinkFlapRequest_STOP:
+		err = project.Topology.StopLinkFlap(request.GetPeer1(), request.GetPeer2())
+	case proto.LinkFlapRequest_PERIODIC, proto.LinkFlapRequest_RANDOM:
+		mode := flapPeriodic
+		if request.GetMode() == proto.LinkFlapRequest_RANDOM {
+			mode = flapRandom
+		}
+		err = project.Topology.StartLinkFlap(request.GetPeer1(), request.GetPeer2(), LinkFlapConfig{
+			Mode: mode,
+			Up:   int(request.GetUp()),
+			Down: int(request.GetDown()),
+		})
+	}
+
+	if err != nil {
+		return &proto.AckResponse{
+			Status: &proto.Status{
+				Code:  proto.StatusCode_ERROR,
+				Error: err.Error(),
+			},
+		}, nil
+	}
+
+	return &proto.AckResponse{
+		Status: &proto.Status{Code: proto.StatusCode_OK},
+	}, nil
+}
+
+func formatHandle(handle uint32) string {
+	if handle == 0xffffffff {
+		return "root"
+	}
+	return fmt.Sprintf("%x:%x", handle>>16, handle&0xffff)
+}
+
+func (s *netemServer) GetLinkStats(ctx context.Context, request *proto.NodeInterfaceRequest) (*proto.LinkStatsResponse, error) {
+	project := GetProject(request.GetPrjId())
+	if project == nil {
+		return nil, &ProjectNotFoundError{request.GetPrjId()}
+	}
+
+	timestamp := time.Now().UnixNano()
+	stats, err := project.Topology.GetLinkStats(request.GetNode(), int(request.GetIfIndex()))
+	if err != nil {
+		return &proto.LinkStatsResponse{
+			Status: &proto.Status{
+				Code:  proto.StatusCode_ERROR,
+				Error: err.Error(),
+			},
+		}, nil
+	}
+
+	response := &proto.LinkStatsResponse{
+		Status:    &proto.Status{Code: proto.StatusCode_OK},
+		Timestamp: timestamp,
+	}
+	for _, peerStats := range stats {
+		ifStats := &proto.LinkStatsResponse_IfStats{
+			Node:      peerStats.Peer.Node.GetName(),
+			IfIndex:   int32(peerStats.Peer.IfIndex),
+			Remote:    peerStats.Remote,
+			RxBytes:   peerStats.Interface.RxBytes,
+			RxPackets: peerStats.Interface.RxPackets,
+			RxErrors:  peerStats.Interface.RxErrors,
+			RxDropped: peerStats.Interface.RxDropped,
+			TxBytes:   peerStats.Interface.TxBytes,
+			TxPackets: peerStats.Interface.TxPackets,
+			TxErrors:  peerStats.Interface.TxErrors,
+			TxDropped: peerStats.Interface.TxDropped,
+		}
+		for _, qdisc := range peerStats.Qdiscs {
+			ifStats.Qdiscs = append(ifStats.Qdiscs, &proto.LinkStatsResponse_QdiscStats{
+				Kind:       qdisc.Kind,
+				Handle:     formatHandle(qdisc.Handle),
+				Parent:     formatHandle(qdisc.Parent),
+				Bytes:      qdisc.Bytes,
+				Packets:    qdisc.Packets,
+				Backlog:    qdisc.Backlog,
+				Qlen:       qdisc.Qlen,
+				Drops:      qdisc.Drops,
+				Overlimits: qdisc.Overlimits,
+				Requeues:   qdisc.Requeues,
+			})
+		}
+		response.Interfaces = append(response.Interfaces, ifStats)
+	}
+
+	return response, nil
+}
+
 func (s *netemServer) Capture(request *proto.NodeInterfaceRequest, stream proto.Netem_CaptureServer) error {
 	project := GetProject(request.GetPrjId())
 	if project == nil {
@@ -710,6 +966,29 @@ func (s *netemServer) CopyTo(stream proto.Netem_CopyToServer) error {
 	}
 }
 
+func (s *netemServer) MoveNode(ctx context.Context, request *proto.MoveNodeRequest) (*proto.AckResponse, error) {
+	project := GetProject(request.GetPrjId())
+	if project == nil {
+		return nil, &ProjectNotFoundError{request.GetPrjId()}
+	}
+
+	err := project.Topology.MoveNode(
+		request.GetNode(), request.GetX(), request.GetY(),
+		time.Duration(request.GetDuration())*time.Millisecond)
+	if err != nil {
+		return &proto.AckResponse{
+			Status: &proto.Status{
+				Code:  proto.StatusCode_ERROR,
+				Error: err.Error(),
+			},
+		}, nil
+	}
+
+	return &proto.AckResponse{
+		Status: &proto.Status{Code: proto.StatusCode_OK},
+	}, nil
+}
+
 func (s *netemServer) Close() error {
 	var ids []string
 	for _, project := range GetAllProjects() {
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/mroy31/gonetem/internal/link"
+)
+
+// LinkPeerStats contains the counters of the interface of a link peer
+// and of the qdiscs attached to it
+type LinkPeerStats struct {
+	Peer      NetemLinkPeer
+	Remote    string // other peer of the link or name of the bridge
+	Interface *link.IfStats
+	Qdiscs    []link.QdiscStats
+}
+
+func formatPeer(peer NetemLinkPeer) string {
+	return fmt.Sprintf("%s.%d", peer.Node.GetName(), peer.IfIndex)
+}
+
+func getPeerStats(peer NetemLinkPeer, remote string) (*LinkPeerStats, error) {
+	ns, err := peer.Node.GetNetns()
+	if err != nil {
+		return nil, err
+	}
+	defer ns.Close()
+
+	ifName := peer.Node.GetInterfaceName(peer.IfIndex)
+	ifStats, err := link.GetIfStats(ifName, ns)
+	if err != nil {
+		return nil, err
+	}
+	qdiscs, err := link.GetQdiscStats(ifName, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkPeerStats{
+		Peer:      peer,
+		Remote:    remote,
+		Interface: ifStats,
+		Qdiscs:    qdiscs,
+	}, nil
+}
+
+// linkStatsPeer is a peer whose counters are requested
+type linkStatsPeer struct {
+	peer   NetemLinkPeer
+	remote string
+}
+
+// getStatsPeers returns the peers of each link, bridge and segment
+// or only the peer <nodeName>.<ifIndex> if nodeName is not empty.
+// Interfaces of stopped nodes do not exist, their peers are skipped
+func (t *NetemTopologyManager) getStatsPeers(nodeName string, ifIndex int) ([]linkStatsPeer, error) {
+	peers := make([]linkStatsPeer, 0)
+	for _, l := range t.links {
+		peers = append(peers,
+			linkStatsPeer{l.Peer1, formatPeer(l.Peer2)},
+			linkStatsPeer{l.Peer2, formatPeer(l.Peer1)})
+	}
+	for _, br := range t.bridges {
+		for _, peer := range br.Peers {
+			peers = append(peers, linkStatsPeer{peer, br.Name})
+		}
+	}
+	for _, segment := range t.segments {
+		for _, peer := range segment.Members {
+			peers = append(peers, linkStatsPeer{peer, segment.Name})
+		}
+	}
+	if nodeName == "" {
+		running := make([]linkStatsPeer, 0, len(peers))
+		for _, p := range peers {
+			if p.peer.Node.IsRunning() {
+				running = append(running, p)
+			}
+		}
+		return running, nil
+	}
+
+	for _, p := range peers {
+		if p.peer.Node.GetName() == nodeName && p.peer.IfIndex == ifIndex {
+			if !p.peer.Node.IsRunning() {
+				return nil, fmt.Errorf("Node %s is not running", nodeName)
+			}
+			return []linkStatsPeer{p}, nil
+		}
+	}
+	return nil, fmt.Errorf("No link found for interface %s.%d", nodeName, ifIndex)
+}
+
+// GetLinkStats returns the counters of each link, bridge and segment
+// peer. If nodeName is not empty, only the peer <nodeName>.<ifIndex>
+// is returned
+func (t *NetemTopologyManager) GetLinkStats(nodeName string, ifIndex int) ([]*LinkPeerStats, error) {
+	if !t.running {
+		return nil, fmt.Errorf("Topology is not running")
+	}
+
+	peers, err := t.getStatsPeers(nodeName, ifIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*LinkPeerStats, 0, len(peers))
+	for _, p := range peers {
+		peerStats, err := getPeerStats(p.peer, p.remote)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to get stats of %s: %w", formatPeer(p.peer), err)
+		}
+		stats = append(stats, peerStats)
+	}
+	return stats, nil
+}
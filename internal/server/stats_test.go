@@ -0,0 +1,125 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+)
+
+// statsTestNode is a node known only by its name and its state
+type statsTestNode struct {
+	INetemNode
+	name    string
+	stopped bool
+}
+
+func (n *statsTestNode) GetName() string {
+	return n.name
+}
+
+func (n *statsTestNode) IsRunning() bool {
+	return !n.stopped
+}
+
+func TestStats_GetStatsPeers(t *testing.T) {
+	r1, r2, host := &statsTestNode{name: "R1"}, &statsTestNode{name: "R2"}, &statsTestNode{name: "host"}
+	r3 := &statsTestNode{name: "R3", stopped: true}
+	topo := &NetemTopologyManager{
+		links: []*NetemLink{
+			{Peer1: NetemLinkPeer{Node: r1, IfIndex: 0}, Peer2: NetemLinkPeer{Node: r2, IfIndex: 1}},
+			{Peer1: NetemLinkPeer{Node: r1, IfIndex: 2}, Peer2: NetemLinkPeer{Node: r3, IfIndex: 0}},
+		},
+		bridges: []*NetemBridge{
+			{Name: "br0", Peers: []NetemLinkPeer{{Node: host, IfIndex: 0}}},
+		},
+		segments: []*NetemSegment{
+			{Name: "lan", Members: []NetemLinkPeer{{Node: r1, IfIndex: 1}, {Node: r2, IfIndex: 0}}},
+		},
+	}
+
+	tests := []struct {
+		desc          string
+		nodeName      string
+		ifIndex       int
+		expected      map[string]string
+		expectedError bool
+	}{
+		{
+			desc: "Stats: all peers",
+			expected: map[string]string{
+				"R1.0":   "R2.1",
+				"R2.1":   "R1.0",
+				"R1.2":   "R3.0",
+				"host.0": "br0",
+				"R1.1":   "lan",
+				"R2.0":   "lan",
+			},
+		},
+		{
+			desc:     "Stats: link peer",
+			nodeName: "R2",
+			ifIndex:  1,
+			expected: map[string]string{"R2.1": "R1.0"},
+		},
+		{
+			desc:     "Stats: segment member",
+			nodeName: "R1",
+			ifIndex:  1,
+			expected: map[string]string{"R1.1": "lan"},
+		},
+		{
+			desc:          "Stats: peer of a stopped node",
+			nodeName:      "R3",
+			ifIndex:       0,
+			expectedError: true,
+		},
+		{
+			desc:          "Stats: unknown interface",
+			nodeName:      "host",
+			ifIndex:       1,
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			peers, err := topo.getStatsPeers(tt.nodeName, tt.ifIndex)
+			if err != nil {
+				if !tt.expectedError {
+					t.Errorf("getStatsPeers returns an unexpected error: %v", err)
+				}
+				return
+			} else if tt.expectedError {
+				t.Errorf("getStatsPeers does not return an error")
+				return
+			}
+
+			result := make(map[string]string)
+			for _, p := range peers {
+				result[formatPeer(p.peer)] = p.remote
+			}
+			if len(peers) != len(tt.expected) || !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Wrong peers %v != %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStats_FormatHandle(t *testing.T) {
+	tests := []struct {
+		desc     string
+		handle   uint32
+		expected string
+	}{
+		{desc: "Stats: root handle", handle: 0xffffffff, expected: "root"},
+		{desc: "Stats: major handle", handle: 0x10000, expected: "1:0"},
+		{desc: "Stats: class handle", handle: 0xa0001, expected: "a:1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if handle := formatHandle(tt.handle); handle != tt.expected {
+				t.Errorf("Wrong handle %s != %s", handle, tt.expected)
+			}
+		})
+	}
+}
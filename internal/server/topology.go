@@ -1,29 +1,39 @@
 package server
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/mroy31/gonetem/internal/link"
 	"github.com/mroy31/gonetem/internal/options"
 	"github.com/mroy31/gonetem/internal/ovs"
 	"github.com/mroy31/gonetem/internal/proto"
 	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netns"
 	"golang.org/x/sync/errgroup"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
 )
 
 const (
 	networkFilename = "network.yml"
 	configDir       = "configs"
+	distDir         = "distributions"
+	peer1ToPeer2Key = "peer1_to_peer2"
+	peer2ToPeer1Key = "peer2_to_peer1"
 )
 
 var (
-	mutex = &sync.Mutex{}
+	mutex      = &sync.Mutex{}
+	paramKeyRE = regexp.MustCompile(`^\w+$`)
 )
 
 type VrrpOptions struct {
@@ -40,26 +50,225 @@ type NodeConfig struct {
 	Vrrps   []VrrpOptions
 	Volumes []string
 	Image   string
+	// resource limits and cpus the container is pinned to, like 0-3,6
+	options.NodeResources `yaml:",inline"`
+	Cpuset                string `yaml:",omitempty"`
+	// sysctls and environment of the container, command and entrypoint
+	// replace the ones of the image
+	Sysctls    map[string]string `yaml:",omitempty"`
+	Env        map[string]string `yaml:",omitempty"`
+	Command    []string          `yaml:",omitempty"`
+	Entrypoint []string          `yaml:",omitempty"`
+	// options of the bridge nodes, a port is given by its index
+	Stp   bool                     `yaml:",omitempty"`
+	Ports map[int]BridgePortConfig `yaml:",omitempty"`
+	// position and radio range used by wireless segments
+	Position *Position       `yaml:",omitempty"`
+	Range    float64         `yaml:",omitempty"`
+	Mobility *MobilityConfig `yaml:",omitempty"`
+}
+
+// BridgePortConfig is the VLAN configuration of a port of a bridge node,
+// VLAN filtering is enabled on the bridge when ports are configured
+type BridgePortConfig struct {
+	Pvid  int   `yaml:",omitempty"` // untagged VLAN, 1 by default
+	Vlans []int `yaml:",omitempty"` // tagged VLANs
+}
+
+// LossGEModel is the Gilbert-Elliott loss model, values are in percent
+type LossGEModel struct {
+	P        float64
+	R        float64 `yaml:",omitempty"`          // default 100-P
+	BadLoss  float64 `yaml:"bad_loss,omitempty"`  // default 100
+	GoodLoss float64 `yaml:"good_loss,omitempty"` // default 0
+}
+
+// LossStateModel is the 4-state Markov loss model, values are in percent
+type LossStateModel struct {
+	P13 float64
+	P31 float64 `yaml:",omitempty"` // default 100-P13
+	P32 float64 `yaml:",omitempty"`
+	P23 float64 `yaml:",omitempty"` // default 100
+	P14 float64 `yaml:",omitempty"`
+}
+
+type LinkParams struct {
+	Loss                 float64         `yaml:",omitempty"`                      // percent
+	LossCorrelation      float64         `yaml:"loss_correlation,omitempty"`      // percent
+	LossGEModel          *LossGEModel    `yaml:"loss_gemodel,omitempty"`          // percent
+	LossStateModel       *LossStateModel `yaml:"loss_state,omitempty"`            // percent
+	Delay                Duration        `yaml:",omitempty"`                      // ms if no unit
+	DelayCorrelation     float64         `yaml:"delay_correlation,omitempty"`     // percent
+	Jitter               Duration        `yaml:",omitempty"`                      // ms if no unit
+	Duplicate            float64         `yaml:",omitempty"`                      // percent
+	DuplicateCorrelation float64         `yaml:"duplicate_correlation,omitempty"` // percent
+	Corrupt              float64         `yaml:",omitempty"`                      // percent
+	CorruptCorrelation   float64         `yaml:"corrupt_correlation,omitempty"`   // percent
+	Reorder              float64         `yaml:",omitempty"`                      // percent
+	ReorderCorrelation   float64         `yaml:"reorder_correlation,omitempty"`   // percent
+	Gap                  int             `yaml:",omitempty"`                      // packets
+	Distribution         string          `yaml:",omitempty"`                      // jitter distribution table
+	Rate                 Bitrate         `yaml:",omitempty"`                      // kbps if no unit
+	Queue                *QueueConfig    `yaml:",omitempty"`                      // queue of the rate limiter
+}
+
+// copy returns a deep copy of the parameters
+func (p LinkParams) copy() LinkParams {
+	if p.LossGEModel != nil {
+		ge := *p.LossGEModel
+		p.LossGEModel = &ge
+	}
+	if p.LossStateModel != nil {
+		st := *p.LossStateModel
+		p.LossStateModel = &st
+	}
+	if p.Queue != nil {
+		q := p.Queue.copy()
+		p.Queue = &q
+	}
+	return p
+}
+
+// hasNetem returns true if a netem qdisc is required for these parameters
+func (p LinkParams) hasNetem() bool {
+	return p.Delay > 0 || p.Loss > 0 || p.Duplicate > 0 || p.Corrupt > 0 ||
+		p.Reorder > 0 || p.LossGEModel != nil || p.LossStateModel != nil
+}
+
+func (p LinkParams) netemParams() link.NetemParams {
+	params := link.NetemParams{
+		Delay:         time.Duration(p.Delay),
+		Jitter:        time.Duration(p.Jitter),
+		DelayCorr:     p.DelayCorrelation,
+		Loss:          p.Loss,
+		LossCorr:      p.LossCorrelation,
+		Duplicate:     p.Duplicate,
+		DuplicateCorr: p.DuplicateCorrelation,
+		Corrupt:       p.Corrupt,
+		CorruptCorr:   p.CorruptCorrelation,
+		Reorder:       p.Reorder,
+		ReorderCorr:   p.ReorderCorrelation,
+		Gap:           p.Gap,
+	}
+
+	// loss models use the same default values as iproute2
+	if ge := p.LossGEModel; ge != nil {
+		params.LossGE = &link.NetemGEModel{
+			P:        ge.P,
+			R:        ge.R,
+			BadLoss:  ge.BadLoss,
+			GoodLoss: ge.GoodLoss,
+		}
+		if ge.R == 0 {
+			params.LossGE.R = 100 - ge.P
+		}
+		if ge.BadLoss == 0 {
+			params.LossGE.BadLoss = 100
+		}
+	} else if st := p.LossStateModel; st != nil {
+		params.LossState = &link.NetemStateModel{
+			P13: st.P13,
+			P31: st.P31,
+			P32: st.P32,
+			P23: st.P23,
+			P14: st.P14,
+		}
+		if st.P31 == 0 {
+			params.LossState.P31 = 100 - st.P13
+		}
+		if st.P23 == 0 {
+			params.LossState.P23 = 100
+		}
+	}
+
+	return params
 }
 
 type LinkConfig struct {
-	Peer1  string
-	Peer2  string
-	Loss   float64 // percent
-	Delay  int     // ms
-	Jitter int     // ms
-	Rate   int     // kbps
+	Peer1      string
+	Peer2      string
+	LinkParams `yaml:",inline"`
+	// optional parameters for one direction of the link,
+	// they replace the parameters above for this direction
+	Peer1ToPeer2 *LinkParams `yaml:"peer1_to_peer2,omitempty"`
+	Peer2ToPeer1 *LinkParams `yaml:"peer2_to_peer1,omitempty"`
+	// profile started when the topology runs
+	Profile *LinkProfileConfig `yaml:",omitempty"`
+	// flapping started when the topology runs
+	Flap *LinkFlapConfig `yaml:",omitempty"`
+	// options of both interfaces
+	Mtu        int `yaml:",omitempty"`
+	TxQueueLen int `yaml:"txqueuelen,omitempty"`
+}
+
+// ifOptions returns the MTU and the txqueuelen of an interface,
+// with the default values if they are not set
+func ifOptions(mtu, txQueueLen int) (int, int) {
+	if mtu == 0 {
+		mtu = link.DEFAULT_MTU
+	}
+	if txQueueLen == 0 {
+		txQueueLen = link.DEFAULT_TXQLEN
+	}
+	return mtu, txQueueLen
+}
+
+// Peer1Params returns the parameters applied to the traffic sent by peer1
+func (c LinkConfig) Peer1Params() LinkParams {
+	if c.Peer1ToPeer2 != nil {
+		return *c.Peer1ToPeer2
+	}
+	return c.LinkParams
+}
+
+// Peer2Params returns the parameters applied to the traffic sent by peer2
+func (c LinkConfig) Peer2Params() LinkParams {
+	if c.Peer2ToPeer1 != nil {
+		return *c.Peer2ToPeer1
+	}
+	return c.LinkParams
 }
 
 type BridgeConfig struct {
 	Host       string
 	Interfaces []string
+	// options of the interfaces connected to the bridge
+	Mtu        int `yaml:",omitempty"`
+	TxQueueLen int `yaml:"txqueuelen,omitempty"`
+}
+
+const (
+	HOSTIF_MOVE    = "move"
+	HOSTIF_MACVLAN = "macvlan"
+	HOSTIF_IPVLAN  = "ipvlan"
+)
+
+// HostIfConfig gives the host interface Host to the node interface
+// Interface. With the mode move, the default, the host interface itself
+// is moved in the node while it runs. With the modes macvlan and ipvlan,
+// a child of the host interface is created in the node
+type HostIfConfig struct {
+	Host      string
+	Interface string
+	Mode      string `yaml:",omitempty"`
+}
+
+func (c HostIfConfig) GetMode() string {
+	if c.Mode == "" {
+		return HOSTIF_MOVE
+	}
+	return c.Mode
 }
 
 type NetemTopology struct {
-	Nodes   map[string]NodeConfig
-	Links   []LinkConfig
-	Bridges map[string]BridgeConfig
+	Kinds    map[string]options.NodeKind `yaml:",omitempty"`
+	Nodes    map[string]NodeConfig
+	Links    []LinkConfig
+	Bridges  map[string]BridgeConfig
+	HostIfs  map[string]HostIfConfig `yaml:"hostifs,omitempty"`
+	Segments map[string]SegmentConfig
+	// location of each element in the network files
+	origins map[string]Location
 }
 
 type NetemLinkPeer struct {
@@ -68,18 +277,38 @@ type NetemLinkPeer struct {
 }
 
 type NetemLink struct {
-	Peer1  NetemLinkPeer
-	Peer2  NetemLinkPeer
-	Loss   float64
-	Delay  int
-	Jitter int
-	Rate   int
+	Peer1   NetemLinkPeer
+	Peer2   NetemLinkPeer
+	Config  LinkConfig
+	applied LinkConfig // configuration of the qdiscs, it differs from Config during a profile
+	// lock protects Config and the qdiscs, modified by SetLinkParams and the profiles
+	lock sync.Mutex
+}
+
+// getConfig returns the configuration of the link,
+// it may be modified at any time by SetLinkParams
+func (l *NetemLink) getConfig() LinkConfig {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.Config
 }
 
 type NetemBridge struct {
 	Name          string
+	ConfigName    string // name in the network file
+	ShortName     string
 	HostInterface string
 	Peers         []NetemLinkPeer
+	Mtu           int
+	TxQueueLen    int
+}
+
+type NetemHostIf struct {
+	ConfigName string // name in the network file
+	Host       string
+	Mode       string
+	Peer       NetemLinkPeer
+	attached   bool
 }
 
 type NetemTopologyManager struct {
@@ -87,10 +316,21 @@ type NetemTopologyManager struct {
 	path  string
 
 	IdGenerator *NodeIdentifierGenerator
+	topology    *NetemTopology // loaded network file
 	nodes       []INetemNode
 	ovsInstance *ovs.OvsProjectInstance
 	links       []*NetemLink
 	bridges     []*NetemBridge
+	hostIfs     []*NetemHostIf
+	segments    []*NetemSegment
+	profiles    map[*NetemLink]*linkProfileRunner
+	profileLock *sync.Mutex
+	flaps       map[*NetemLink]*linkFlapRunner
+	flapLock    *sync.Mutex
+	positions   map[string]Position
+	mobility    map[string]MobilityConfig
+	moves       map[string]*nodeMoveRunner
+	moveLock    *sync.Mutex
 	running     bool
 	logger      *logrus.Entry
 }
@@ -109,6 +349,11 @@ func (t *NetemTopologyManager) Check() error {
 	return nil
 }
 
+// Lint checks the topology with the configuration files of the nodes
+func (t *NetemTopologyManager) Lint() []error {
+	return LintProject(t.path)
+}
+
 func (t *NetemTopologyManager) Load() error {
 	filepath := path.Join(t.path, networkFilename)
 	topology, errors := CheckTopology(filepath)
@@ -142,7 +387,7 @@ func (t *NetemTopologyManager) Load() error {
 			if err != nil {
 				return err
 			}
-			node, err := CreateNode(t.prjID, name, shortName, nConfig)
+			node, err := CreateNode(t.prjID, name, shortName, nConfig, topology.Kinds)
 
 			mutex.Lock()
 			t.nodes = append(t.nodes, node)
@@ -162,76 +407,114 @@ func (t *NetemTopologyManager) Load() error {
 	// Create links
 	t.links = make([]*NetemLink, len(topology.Links))
 	for idx, lConfig := range topology.Links {
-		peer1 := strings.Split(lConfig.Peer1, ".")
-		peer2 := strings.Split(lConfig.Peer2, ".")
-
-		peer1Idx, _ := strconv.Atoi(peer1[1])
-		peer2Idx, _ := strconv.Atoi(peer2[1])
-
-		t.links[idx] = &NetemLink{
-			Peer1: NetemLinkPeer{
-				Node:    t.GetNode(peer1[0]),
-				IfIndex: peer1Idx,
-			},
-			Peer2: NetemLinkPeer{
-				Node:    t.GetNode(peer2[0]),
-				IfIndex: peer2Idx,
-			},
-			Delay:  lConfig.Delay,
-			Jitter: lConfig.Jitter,
-			Loss:   lConfig.Loss,
-			Rate:   lConfig.Rate,
-		}
+		t.links[idx] = t.loadLink(lConfig)
 	}
 
 	// Create bridges
-	bIdx := 0
-	t.bridges = make([]*NetemBridge, len(topology.Bridges))
+	t.bridges = make([]*NetemBridge, 0, len(topology.Bridges))
 	for bName, bConfig := range topology.Bridges {
-		shortName, err := t.IdGenerator.GetId(bName)
+		br, err := t.loadBridge(bName, bConfig)
 		if err != nil {
 			return err
 		}
+		t.bridges = append(t.bridges, br)
+	}
 
-		t.bridges[bIdx] = &NetemBridge{
-			Name:          options.NETEM_ID + t.prjID + "." + shortName,
-			HostInterface: bConfig.Host,
-			Peers:         make([]NetemLinkPeer, len(bConfig.Interfaces)),
-		}
-
-		for pIdx, ifName := range bConfig.Interfaces {
-			peer := strings.Split(ifName, ".")
-			peerIdx, _ := strconv.Atoi(peer[1])
+	// Create host interfaces
+	t.hostIfs = make([]*NetemHostIf, 0, len(topology.HostIfs))
+	for hName, hConfig := range topology.HostIfs {
+		t.hostIfs = append(t.hostIfs, t.loadHostIf(hName, hConfig))
+	}
 
-			t.bridges[bIdx].Peers[pIdx] = NetemLinkPeer{
-				Node:    t.GetNode(peer[0]),
-				IfIndex: peerIdx,
-			}
+	// Create segments
+	t.segments = make([]*NetemSegment, 0, len(topology.Segments))
+	for sName, sConfig := range topology.Segments {
+		segment, err := t.loadSegment(sName, sConfig, topology.Nodes)
+		if err != nil {
+			return err
 		}
+		t.segments = append(t.segments, segment)
+	}
 
-		bIdx++
+	// Positions of nodes in wireless segments
+	t.moveLock.Lock()
+	t.positions = make(map[string]Position)
+	t.mobility = make(map[string]MobilityConfig)
+	for name, nConfig := range topology.Nodes {
+		if nConfig.Position != nil {
+			t.positions[name] = *nConfig.Position
+		}
+		if nConfig.Mobility != nil {
+			t.mobility[name] = *nConfig.Mobility
+		}
 	}
+	t.moveLock.Unlock()
 
+	t.topology = topology
 	return nil
 }
 
-func (t *NetemTopologyManager) Reload() ([]*proto.RunResponse_NodeMessages, error) {
-	var err error
-	var nodeMessages []*proto.RunResponse_NodeMessages
+func (t *NetemTopologyManager) loadLink(lConfig LinkConfig) *NetemLink {
+	peer1 := strings.Split(lConfig.Peer1, ".")
+	peer2 := strings.Split(lConfig.Peer2, ".")
 
-	if err = t.Close(); err != nil {
-		return nodeMessages, err
+	peer1Idx, _ := strconv.Atoi(peer1[1])
+	peer2Idx, _ := strconv.Atoi(peer2[1])
+
+	return &NetemLink{
+		Peer1: NetemLinkPeer{
+			Node:    t.GetNode(peer1[0]),
+			IfIndex: peer1Idx,
+		},
+		Peer2: NetemLinkPeer{
+			Node:    t.GetNode(peer2[0]),
+			IfIndex: peer2Idx,
+		},
+		Config: lConfig,
 	}
+}
 
-	if err = t.Load(); err != nil {
-		return nodeMessages, err
+func (t *NetemTopologyManager) loadBridge(name string, bConfig BridgeConfig) (*NetemBridge, error) {
+	shortName, err := t.IdGenerator.GetId(name)
+	if err != nil {
+		return nil, err
 	}
-	if t.running {
-		t.running = false
-		return t.Run()
+
+	br := &NetemBridge{
+		Name:          options.NETEM_ID + t.prjID + "." + shortName,
+		ConfigName:    name,
+		ShortName:     shortName,
+		HostInterface: bConfig.Host,
+		Peers:         make([]NetemLinkPeer, len(bConfig.Interfaces)),
 	}
+	br.Mtu, br.TxQueueLen = ifOptions(bConfig.Mtu, bConfig.TxQueueLen)
 
-	return nodeMessages, nil
+	for pIdx, ifName := range bConfig.Interfaces {
+		peer := strings.Split(ifName, ".")
+		peerIdx, _ := strconv.Atoi(peer[1])
+
+		br.Peers[pIdx] = NetemLinkPeer{
+			Node:    t.GetNode(peer[0]),
+			IfIndex: peerIdx,
+		}
+	}
+
+	return br, nil
+}
+
+func (t *NetemTopologyManager) loadHostIf(name string, hConfig HostIfConfig) *NetemHostIf {
+	peer := strings.Split(hConfig.Interface, ".")
+	peerIdx, _ := strconv.Atoi(peer[1])
+
+	return &NetemHostIf{
+		ConfigName: name,
+		Host:       hConfig.Host,
+		Mode:       hConfig.GetMode(),
+		Peer: NetemLinkPeer{
+			Node:    t.GetNode(peer[0]),
+			IfIndex: peerIdx,
+		},
+	}
 }
 
 func (t *NetemTopologyManager) Run() ([]*proto.RunResponse_NodeMessages, error) {
@@ -283,7 +566,27 @@ func (t *NetemTopologyManager) Run() ([]*proto.RunResponse_NodeMessages, error)
 		return nodeMessages, err
 	}
 
-	// 5 - load configs
+	// 5 - attach host interfaces
+	t.logger.Debug("Topo/Run: attach host interfaces")
+	for _, hostIf := range t.hostIfs {
+		if err := t.attachHostIf(hostIf); err != nil {
+			return nodeMessages, err
+		}
+	}
+
+	// 6 - create segments
+	t.logger.Debug("Topo/Run: setup segments")
+	for _, segment := range t.segments {
+		segment := segment
+		g.Go(func() error {
+			return t.setupSegment(segment)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nodeMessages, err
+	}
+
+	// 7 - load configs
 	t.logger.Debug("Topo/Run: load configuration")
 	configPath := path.Join(t.path, configDir)
 	for _, node := range t.nodes {
@@ -302,6 +605,28 @@ func (t *NetemTopologyManager) Run() ([]*proto.RunResponse_NodeMessages, error)
 	}
 
 	t.running = true
+
+	// 8 - start link profiles, flapping and node mobility
+	t.logger.Debug("Topo/Run: start link profiles, flapping and mobility")
+	for _, l := range t.links {
+		if l.Config.Profile != nil {
+			err := t.StartLinkProfile(
+				l.Peer1.Node.GetName(), l.Peer1.IfIndex,
+				l.Config.Profile.Name, l.Config.Profile.Loop)
+			if err != nil {
+				return nodeMessages, err
+			}
+		}
+		if l.Config.Flap != nil {
+			if err := t.StartLinkFlap(l.Config.Peer1, l.Config.Peer2, *l.Config.Flap); err != nil {
+				return nodeMessages, err
+			}
+		}
+	}
+	if err := t.startAllNodeMobility(); err != nil {
+		return nodeMessages, err
+	}
+
 	return nodeMessages, nil
 }
 
@@ -330,6 +655,7 @@ func (t *NetemTopologyManager) setupBridge(br *NetemBridge) error {
 		veth, err := link.CreateVethLink(
 			ifName, rootNs,
 			peerIfName, peerNetns,
+			br.Mtu, br.TxQueueLen,
 		)
 		if err != nil {
 			return fmt.Errorf(
@@ -367,7 +693,8 @@ func (t *NetemTopologyManager) setupLink(l *NetemLink) error {
 
 	peer1IfName := fmt.Sprintf("%s%s.%d", t.prjID, l.Peer1.Node.GetShortName(), l.Peer1.IfIndex)
 	peer2IfName := fmt.Sprintf("%s%s.%d", t.prjID, l.Peer2.Node.GetShortName(), l.Peer2.IfIndex)
-	_, err = link.CreateVethLink(peer1IfName, peer1Netns, peer2IfName, peer2Netns)
+	mtu, txQueueLen := ifOptions(l.Config.Mtu, l.Config.TxQueueLen)
+	_, err = link.CreateVethLink(peer1IfName, peer1Netns, peer2IfName, peer2Netns, mtu, txQueueLen)
 	if err != nil {
 		return fmt.Errorf(
 			"Unable to create link %s.%d-%s.%d: %v",
@@ -377,35 +704,370 @@ func (t *NetemTopologyManager) setupLink(l *NetemLink) error {
 		)
 	}
 
+	// create qdiscs if necessary
+	if err := t.createLinkQdiscs(peer1IfName, peer1Netns, l.Config.Peer1Params(), mtu); err != nil {
+		return err
+	}
+	if err := t.createLinkQdiscs(peer2IfName, peer2Netns, l.Config.Peer2Params(), mtu); err != nil {
+		return err
+	}
+	l.applied = l.Config
+
+	if err := l.Peer1.Node.AddInterface(peer1IfName, l.Peer1.IfIndex, peer1Netns); err != nil {
+		return err
+	}
+	if err := l.Peer2.Node.AddInterface(peer2IfName, l.Peer2.IfIndex, peer2Netns); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// loadDistTable returns the distribution table name, first searched
+// in the project then in the iproute2 tables installed on the server
+func loadDistTable(prjPath, name string) ([]int16, error) {
+	filepath := path.Join(prjPath, distDir, name+".dist")
+	if _, err := os.Stat(filepath); os.IsNotExist(err) {
+		filepath, err = link.FindSystemDistTable(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return link.ReadDistTable(filepath)
+}
+
+func (t *NetemTopologyManager) getNetemParams(params LinkParams) (link.NetemParams, error) {
+	netemParams := params.netemParams()
+	if params.Distribution != "" {
+		table, err := loadDistTable(t.path, params.Distribution)
+		if err != nil {
+			return netemParams, err
+		}
+		netemParams.DelayDist = table
+	}
+
+	return netemParams, nil
+}
+
+func (t *NetemTopologyManager) createLinkQdiscs(ifName string, ns netns.NsHandle, params LinkParams, mtu int) error {
 	// create netem qdisc if necessary
-	if l.Delay > 0 || l.Loss > 0 {
-		if err := link.CreateNetem(peer1IfName, peer1Netns, l.Delay, l.Jitter, l.Loss); err != nil {
+	if params.hasNetem() {
+		netemParams, err := t.getNetemParams(params)
+		if err != nil {
 			return err
 		}
-		if err := link.CreateNetem(peer2IfName, peer2Netns, l.Delay, l.Jitter, l.Loss); err != nil {
+		if err := link.CreateNetem(ifName, ns, netemParams); err != nil {
 			return err
 		}
 	}
-	// create tbf qdisc if necessary
-	if l.Rate > 0 {
-		if err := link.CreateTbf(peer1IfName, peer1Netns, l.Delay+l.Jitter, l.Rate); err != nil {
+	// create tbf, or htb, qdisc and its queue if necessary
+	if params.Rate > 0 {
+		if params.Queue != nil && params.Queue.Type == link.QUEUE_HTB {
+			return link.CreateHtb(ifName, ns, uint64(params.Rate), mtu, params.Queue.queueParams())
+		}
+		if err := link.CreateTbf(ifName, ns, time.Duration(params.Delay+params.Jitter), uint64(params.Rate), mtu); err != nil {
 			return err
 		}
-		if err := link.CreateTbf(peer2IfName, peer2Netns, l.Delay+l.Jitter, l.Rate); err != nil {
+		if params.Queue != nil {
+			return link.CreateQueue(ifName, ns, uint64(params.Rate), params.Queue.queueParams())
+		}
+	}
+
+	return nil
+}
+
+// netemNeedsReset returns true if the netem qdisc configured with previous
+// must be recreated to apply params. The kernel keeps the distribution
+// table of a netem qdisc when a replace request does not give one
+func netemNeedsReset(previous, params LinkParams) bool {
+	return previous.Distribution != "" && params.Distribution == ""
+}
+
+func (t *NetemTopologyManager) updateLinkQdiscs(peer NetemLinkPeer, previous, params LinkParams, mtu int) error {
+	ns, err := peer.Node.GetNetns()
+	if err != nil {
+		return err
+	}
+	defer ns.Close()
+
+	ifName := peer.Node.GetInterfaceName(peer.IfIndex)
+	if !params.hasNetem() {
+		return link.DeleteNetem(ifName, ns)
+	} else if netemNeedsReset(previous, params) {
+		// the tbf qdisc, deleted with the netem qdisc, is created again below
+		if err := link.DeleteNetem(ifName, ns); err != nil {
 			return err
 		}
 	}
 
-	if err := l.Peer1.Node.AddInterface(peer1IfName, l.Peer1.IfIndex, peer1Netns); err != nil {
+	netemParams, err := t.getNetemParams(params)
+	if err != nil {
 		return err
 	}
-	if err := l.Peer2.Node.AddInterface(peer2IfName, l.Peer2.IfIndex, peer2Netns); err != nil {
+	if err := link.ReplaceNetem(ifName, ns, netemParams); err != nil {
+		return err
+	}
+	if params.Rate == 0 {
+		return link.DeleteTbf(ifName, ns)
+	}
+
+	if params.Queue != nil && params.Queue.Type == link.QUEUE_HTB {
+		return link.ReplaceHtb(ifName, ns, uint64(params.Rate), mtu, params.Queue.queueParams())
+	}
+	if err := link.ReplaceTbf(ifName, ns, time.Duration(params.Delay+params.Jitter), uint64(params.Rate), mtu); err != nil {
+		return err
+	}
+	if params.Queue != nil {
+		return link.ReplaceQueue(ifName, ns, uint64(params.Rate), params.Queue.queueParams())
+	}
+	return link.DeleteQueue(ifName, ns)
+}
+
+// applyLinkConfig modifies the qdiscs of both link peers
+// according to lConfig, the lock of the link must be held
+func (t *NetemTopologyManager) applyLinkConfig(l *NetemLink, lConfig LinkConfig) error {
+	// interface options are not modified, they come from the link
+	mtu, _ := ifOptions(l.Config.Mtu, l.Config.TxQueueLen)
+	if err := t.updateLinkQdiscs(l.Peer1, l.applied.Peer1Params(), lConfig.Peer1Params(), mtu); err != nil {
+		return fmt.Errorf("Unable to update link %s-%s: %w", lConfig.Peer1, lConfig.Peer2, err)
+	}
+	if err := t.updateLinkQdiscs(l.Peer2, l.applied.Peer2Params(), lConfig.Peer2Params(), mtu); err != nil {
+		return fmt.Errorf("Unable to update link %s-%s: %w", lConfig.Peer1, lConfig.Peer2, err)
+	}
+	l.applied = lConfig
+
+	return nil
+}
+
+func (t *NetemTopologyManager) GetLink(nodeName string, ifIndex int) *NetemLink {
+	for _, l := range t.links {
+		for _, peer := range []NetemLinkPeer{l.Peer1, l.Peer2} {
+			if peer.Node.GetName() == nodeName && peer.IfIndex == ifIndex {
+				return l
+			}
+		}
+	}
+	return nil
+}
+
+// SetLinkParams modifies, without reloading the topology, the
+// parameters of the link connected to the interface <nodeName>.<ifIndex>.
+// params are given with the same keys as in the network file, keys
+// prefixed with "peer1_to_peer2." or "peer2_to_peer1." modify only
+// one direction of the link.
+// New values are also written in the network file.
+func (t *NetemTopologyManager) SetLinkParams(nodeName string, ifIndex int, params map[string]string) error {
+	l := t.GetLink(nodeName, ifIndex)
+	if l == nil {
+		return fmt.Errorf("No link found for interface %s.%d", nodeName, ifIndex)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	lConfig, err := mergeLinkParams(l.Config, params)
+	if err != nil {
+		return err
+	}
+	if errors := checkLinkConfig(lConfig, t.path); len(errors) > 0 {
+		msg := ""
+		for _, err := range errors {
+			msg += "\n\t" + err.Error()
+		}
+		return fmt.Errorf("Link parameters are not valid:%s\n", msg)
+	}
+
+	// the network file is updated first, the link can not be
+	// modified if its new parameters can not be saved
+	data, err := t.ReadNetworkFile()
+	if err != nil {
+		return err
+	}
+	newData, err := updateLinkConfig(data, lConfig, params)
+	if err != nil {
+		return err
+	}
+	if err := t.WriteNetworkFile(newData); err != nil {
 		return err
 	}
 
+	if t.running {
+		if err := t.applyLinkConfig(l, lConfig); err != nil {
+			if wErr := t.WriteNetworkFile(data); wErr != nil {
+				t.logger.Errorf("Unable to restore network file: %v", wErr)
+			}
+			return err
+		}
+	}
+	l.Config = lConfig
+	// the loaded topology is compared with the network file on reload
+	for idx, other := range t.topology.Links {
+		if other.Peer1 == lConfig.Peer1 && other.Peer2 == lConfig.Peer2 {
+			t.topology.Links[idx] = lConfig
+			break
+		}
+	}
+
 	return nil
 }
 
+// mergeLinkParams returns a copy of lConfig where
+// fields present in params have been overwritten
+func mergeLinkParams(lConfig LinkConfig, params map[string]string) (LinkConfig, error) {
+	// parameters are copied to not modify lConfig, per-direction
+	// parameters are initialized with the common parameters if necessary
+	lConfig.LinkParams = lConfig.LinkParams.copy()
+	directions := map[string]**LinkParams{
+		peer1ToPeer2Key: &lConfig.Peer1ToPeer2,
+		peer2ToPeer1Key: &lConfig.Peer2ToPeer1,
+	}
+	for _, dirParams := range directions {
+		if *dirParams != nil {
+			dirCopy := (**dirParams).copy()
+			*dirParams = &dirCopy
+		}
+	}
+
+	data := ""
+	dirData := make(map[string]string)
+	for key, value := range params {
+		direction := ""
+		if split := strings.SplitN(key, ".", 2); len(split) == 2 {
+			direction, key = split[0], split[1]
+			if _, ok := directions[direction]; !ok {
+				return lConfig, fmt.Errorf("Link direction '%s' is not valid", direction)
+			}
+		}
+
+		if !paramKeyRE.MatchString(key) {
+			return lConfig, fmt.Errorf("Link parameter '%s' is not valid", key)
+		}
+		if strings.Contains(value, "\n") {
+			return lConfig, fmt.Errorf("Value '%s' of link parameter '%s' is not valid", value, key)
+		}
+
+		if direction != "" {
+			dirData[direction] += fmt.Sprintf("%s: %s\n", key, value)
+		} else {
+			data += fmt.Sprintf("%s: %s\n", key, value)
+		}
+	}
+
+	// only the parameters can be modified, other fields of the link are
+	// unknown keys for LinkParams
+	if err := yaml.UnmarshalStrict([]byte(data), &lConfig.LinkParams); err != nil {
+		return lConfig, fmt.Errorf("Unable to parse link parameters:\n\t%w", err)
+	}
+	for direction, dData := range dirData {
+		dirParams := directions[direction]
+		if *dirParams == nil {
+			common := lConfig.LinkParams.copy()
+			*dirParams = &common
+		}
+		if err := yaml.UnmarshalStrict([]byte(dData), *dirParams); err != nil {
+			return lConfig, fmt.Errorf("Unable to parse %s link parameters:\n\t%w", direction, err)
+		}
+	}
+
+	return lConfig, nil
+}
+
+// updateLinkConfig returns the content of the network file data where
+// parameters of the link set by params have been replaced by their
+// values in lConfig. The rest of the file, comments included, is kept
+func updateLinkConfig(data []byte, lConfig LinkConfig, params map[string]string) ([]byte, error) {
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("Unable to parse network file: %w", err)
+	}
+
+	var linkNode *yamlv3.Node
+	if len(doc.Content) > 0 {
+		if links := yamlMapValue(doc.Content[0], "links"); links != nil && links.Kind == yamlv3.SequenceNode {
+			for _, lNode := range links.Content {
+				peer1, peer2 := yamlMapValue(lNode, "peer1"), yamlMapValue(lNode, "peer2")
+				if peer1 == nil || peer2 == nil {
+					continue
+				}
+				if (peer1.Value == lConfig.Peer1 && peer2.Value == lConfig.Peer2) ||
+					(peer1.Value == lConfig.Peer2 && peer2.Value == lConfig.Peer1) {
+					linkNode = lNode
+					break
+				}
+			}
+		}
+	}
+	if linkNode == nil {
+		return nil, fmt.Errorf(
+			"Link %s-%s is not written as is in the network file (pattern, generator or include), unable to save its parameters",
+			lConfig.Peer1, lConfig.Peer2)
+	}
+
+	var newNode yamlv3.Node
+	if err := newNode.Encode(lConfig); err != nil {
+		return nil, err
+	}
+	for key := range params {
+		if split := strings.SplitN(key, ".", 2); len(split) == 2 {
+			// a direction missing in the file is written with
+			// all its parameters
+			if dirNode := yamlMapValue(linkNode, split[0]); dirNode != nil && dirNode.Kind == yamlv3.MappingNode {
+				yamlMapSet(dirNode, split[1], yamlMapValue(yamlMapValue(&newNode, split[0]), split[1]))
+				continue
+			}
+			key = split[0]
+		}
+		yamlMapSet(linkNode, key, yamlMapValue(&newNode, key))
+	}
+
+	buf := new(bytes.Buffer)
+	encoder := yamlv3.NewEncoder(buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// yamlMapValue returns the value of key in the mapping node,
+// nil if the key is not found
+func yamlMapValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// yamlMapSet replaces the value of key in the mapping node, the key
+// is added if necessary and removed if value is nil
+func yamlMapSet(node *yamlv3.Node, key string, value *yamlv3.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+		if value == nil {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+		} else {
+			value.LineComment = node.Content[i+1].LineComment
+			node.Content[i+1] = value
+		}
+		return
+	}
+
+	if value != nil {
+		node.Content = append(node.Content, &yamlv3.Node{Kind: yamlv3.ScalarNode, Value: key}, value)
+	}
+}
+
 func (t *NetemTopologyManager) IsRunning() bool {
 	return t.running
 }
@@ -418,6 +1080,21 @@ func (t *NetemTopologyManager) ReadNetworkFile() ([]byte, error) {
 	return ioutil.ReadFile(t.GetNetFilePath())
 }
 
+// ReadExpandedNetworkFile returns the network file where variables,
+// brace patterns and generators are expanded
+func (t *NetemTopologyManager) ReadExpandedNetworkFile() ([]byte, error) {
+	data, err := t.ReadNetworkFile()
+	if err != nil {
+		return nil, err
+	}
+
+	topology, err := ExpandTopology(data, t.path)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to parse network file: %w", err)
+	}
+	return yaml.Marshal(topology)
+}
+
 func (t *NetemTopologyManager) WriteNetworkFile(data []byte) error {
 	return ioutil.WriteFile(t.GetNetFilePath(), data, 0644)
 }
@@ -439,6 +1116,13 @@ func (t *NetemTopologyManager) startNode(node INetemNode) ([]string, error) {
 	if err := node.Start(); err != nil {
 		return []string{}, fmt.Errorf("Unable to start node %s: %w", node.GetName(), err)
 	}
+	for _, hostIf := range t.hostIfs {
+		if hostIf.Peer.Node == node {
+			if err := t.attachHostIf(hostIf); err != nil {
+				return []string{}, err
+			}
+		}
+	}
 
 	configPath := path.Join(t.path, configDir)
 	messages, err := node.LoadConfig(configPath)
@@ -449,7 +1133,16 @@ func (t *NetemTopologyManager) startNode(node INetemNode) ([]string, error) {
 	return messages, nil
 }
 
+// stopNode gives back the host interfaces of the node before stopping it,
+// a stopped node may lose its namespace
 func (t *NetemTopologyManager) stopNode(node INetemNode) error {
+	for _, hostIf := range t.hostIfs {
+		if hostIf.Peer.Node == node {
+			if err := t.detachHostIf(hostIf); err != nil {
+				return err
+			}
+		}
+	}
 	if err := node.Stop(); err != nil {
 		return fmt.Errorf("Unable to stop node %s: %w", node.GetName(), err)
 	}
@@ -502,6 +1195,17 @@ func (t *NetemTopologyManager) Save() error {
 }
 
 func (t *NetemTopologyManager) Close() error {
+	t.stopAllLinkProfiles()
+	t.stopAllLinkFlaps()
+	t.stopAllNodeMobility()
+
+	// host interfaces are returned before their node namespace is deleted
+	for _, hostIf := range t.hostIfs {
+		if err := t.detachHostIf(hostIf); err != nil {
+			t.logger.Errorf("Error when detaching host interface %s: %v", hostIf.ConfigName, err)
+		}
+	}
+
 	g := new(errgroup.Group)
 	// close all nodes
 	for _, node := range t.nodes {
@@ -529,9 +1233,18 @@ func (t *NetemTopologyManager) Close() error {
 		}
 	}
 
+	for _, segment := range t.segments {
+		if err := t.closeSegment(segment); err != nil {
+			t.logger.Warnf("Error when deleting segment %s: %v", segment.Name, err)
+		}
+	}
+
 	t.nodes = make([]INetemNode, 0)
 	t.links = make([]*NetemLink, 0)
 	t.bridges = make([]*NetemBridge, 0)
+	t.hostIfs = make([]*NetemHostIf, 0)
+	t.segments = make([]*NetemSegment, 0)
+	t.topology = nil
 	t.IdGenerator.Close()
 
 	if err := ovs.CloseOvsInstance(t.prjID); err != nil {
@@ -544,10 +1257,18 @@ func (t *NetemTopologyManager) Close() error {
 
 func LoadTopology(prjID, prjPath string) (*NetemTopologyManager, error) {
 	topo := &NetemTopologyManager{
-		prjID:  prjID,
-		path:   prjPath,
-		nodes:  make([]INetemNode, 0),
-		logger: logrus.WithField("project", prjID),
+		prjID:       prjID,
+		path:        prjPath,
+		nodes:       make([]INetemNode, 0),
+		profiles:    make(map[*NetemLink]*linkProfileRunner),
+		profileLock: &sync.Mutex{},
+		flaps:       make(map[*NetemLink]*linkFlapRunner),
+		flapLock:    &sync.Mutex{},
+		positions:   make(map[string]Position),
+		mobility:    make(map[string]MobilityConfig),
+		moves:       make(map[string]*nodeMoveRunner),
+		moveLock:    &sync.Mutex{},
+		logger:      logrus.WithField("project", prjID),
 		IdGenerator: &NodeIdentifierGenerator{
 			lock: &sync.Mutex{},
 		},
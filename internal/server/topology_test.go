@@ -4,7 +4,10 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/mroy31/gonetem/internal/options"
 	"github.com/mroy31/gonetem/internal/utils"
@@ -176,3 +179,323 @@ func TestTopology_Save(t *testing.T) {
 		}
 	}
 }
+
+func TestTopology_MergeLinkParams(t *testing.T) {
+	base := LinkConfig{Peer1: "R1.0", Peer2: "R2.0", LinkParams: LinkParams{Delay: Duration(10 * time.Millisecond), Loss: 1}}
+	tests := []struct {
+		desc          string
+		params        map[string]string
+		expected      LinkConfig
+		expectedError bool
+	}{
+		{
+			desc:     "MergeLinkParams: modify delay and rate",
+			params:   map[string]string{"delay": "100", "rate": "1000"},
+			expected: LinkConfig{Peer1: "R1.0", Peer2: "R2.0", LinkParams: LinkParams{Delay: Duration(100 * time.Millisecond), Loss: 1, Rate: 1000000}},
+		},
+		{
+			desc:     "MergeLinkParams: values with units",
+			params:   map[string]string{"delay": "250us", "rate": "10gbit"},
+			expected: LinkConfig{Peer1: "R1.0", Peer2: "R2.0", LinkParams: LinkParams{Delay: Duration(250 * time.Microsecond), Loss: 1, Rate: 10000000000}},
+		},
+		{
+			desc:   "MergeLinkParams: modify one direction",
+			params: map[string]string{"peer2_to_peer1.loss": "5"},
+			expected: LinkConfig{
+				Peer1:        "R1.0",
+				Peer2:        "R2.0",
+				LinkParams:   LinkParams{Delay: Duration(10 * time.Millisecond), Loss: 1},
+				Peer2ToPeer1: &LinkParams{Delay: Duration(10 * time.Millisecond), Loss: 5},
+			},
+		},
+		{
+			desc:   "MergeLinkParams: set a loss model",
+			params: map[string]string{"loss": "0", "loss_gemodel": "{p: 1, r: 20}"},
+			expected: LinkConfig{
+				Peer1:      "R1.0",
+				Peer2:      "R2.0",
+				LinkParams: LinkParams{Delay: Duration(10 * time.Millisecond), LossGEModel: &LossGEModel{P: 1, R: 20}},
+			},
+		},
+		{
+			desc:          "MergeLinkParams: unknown direction",
+			params:        map[string]string{"peer1_to_peer3.loss": "5"},
+			expectedError: true,
+		},
+		{
+			desc:          "MergeLinkParams: unknown parameter",
+			params:        map[string]string{"jiter": "10"},
+			expectedError: true,
+		},
+		{
+			desc:          "MergeLinkParams: peers can not be modified",
+			params:        map[string]string{"peer1": "R3.0"},
+			expectedError: true,
+		},
+		{
+			desc:          "MergeLinkParams: interface options can not be modified",
+			params:        map[string]string{"mtu": "9000", "peer1_to_peer2.txqueuelen": "10"},
+			expectedError: true,
+		},
+		{
+			desc:          "MergeLinkParams: nested direction",
+			params:        map[string]string{"peer1_to_peer2.peer2_to_peer1": "{loss: 5}"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			lConfig, err := mergeLinkParams(base, tt.params)
+			if err != nil {
+				if !tt.expectedError {
+					t.Errorf("mergeLinkParams returns an unexpected error: %v", err)
+				}
+				return
+			} else if tt.expectedError {
+				t.Errorf("mergeLinkParams does not return an error")
+				return
+			}
+
+			if !reflect.DeepEqual(lConfig, tt.expected) {
+				t.Errorf("Wrong link config %v != %v", lConfig, tt.expected)
+			}
+		})
+	}
+}
+
+const updateLinkNetwork = `# test network
+nodes:
+  R1:
+    type: docker.router
+  R2:
+    type: docker.router
+links:
+- peer1: R1.0
+  peer2: R2.0
+  delay: 10 # one way delay
+  loss: 1
+- peer1: R{1..2}.1
+  peer2: R{2..3}.1
+`
+
+func TestTopology_UpdateLinkConfig(t *testing.T) {
+	tests := []struct {
+		desc          string
+		lConfig       LinkConfig
+		params        map[string]string
+		expected      []string
+		notExpected   []string
+		expectedError bool
+	}{
+		{
+			desc:        "UpdateLinkConfig: modify and remove parameters",
+			lConfig:     LinkConfig{Peer1: "R2.0", Peer2: "R1.0", LinkParams: LinkParams{Delay: Duration(100 * time.Millisecond)}},
+			params:      map[string]string{"delay": "100", "loss": "0"},
+			expected:    []string{"# test network", "delay: 100 # one way delay"},
+			notExpected: []string{"loss"},
+		},
+		{
+			desc: "UpdateLinkConfig: add a direction",
+			lConfig: LinkConfig{
+				Peer1:        "R1.0",
+				Peer2:        "R2.0",
+				LinkParams:   LinkParams{Delay: Duration(10 * time.Millisecond), Loss: 1},
+				Peer1ToPeer2: &LinkParams{Delay: Duration(10 * time.Millisecond), Loss: 5},
+			},
+			params:   map[string]string{"peer1_to_peer2.loss": "5"},
+			expected: []string{"peer1_to_peer2:", "loss: 5", "delay: 10 # one way delay"},
+		},
+		{
+			desc:          "UpdateLinkConfig: link from a pattern",
+			lConfig:       LinkConfig{Peer1: "R1.1", Peer2: "R2.1", LinkParams: LinkParams{Loss: 5}},
+			params:        map[string]string{"loss": "5"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			data, err := updateLinkConfig([]byte(updateLinkNetwork), tt.lConfig, tt.params)
+			if err != nil {
+				if !tt.expectedError {
+					t.Errorf("updateLinkConfig returns an unexpected error: %v", err)
+				}
+				return
+			} else if tt.expectedError {
+				t.Errorf("updateLinkConfig does not return an error")
+				return
+			}
+
+			for _, e := range tt.expected {
+				if !strings.Contains(string(data), e) {
+					t.Errorf("'%s' not found in network file:\n%s", e, data)
+				}
+			}
+			for _, e := range tt.notExpected {
+				if strings.Contains(string(data), e) {
+					t.Errorf("'%s' found in network file:\n%s", e, data)
+				}
+			}
+		})
+	}
+}
+
+func TestTopology_NetemNeedsReset(t *testing.T) {
+	tests := []struct {
+		desc     string
+		previous LinkParams
+		params   LinkParams
+		expected bool
+	}{
+		{
+			desc:     "NetemNeedsReset: distribution removed",
+			previous: LinkParams{Delay: 10, Jitter: 2, Distribution: "normal"},
+			params:   LinkParams{Delay: 10, Jitter: 2},
+			expected: true,
+		},
+		{
+			desc:     "NetemNeedsReset: distribution changed",
+			previous: LinkParams{Delay: 10, Jitter: 2, Distribution: "normal"},
+			params:   LinkParams{Delay: 10, Jitter: 2, Distribution: "pareto"},
+		},
+		{
+			desc:     "NetemNeedsReset: distribution added",
+			previous: LinkParams{Delay: 10, Jitter: 2},
+			params:   LinkParams{Delay: 10, Jitter: 2, Distribution: "normal"},
+		},
+		{
+			desc:     "NetemNeedsReset: no distribution",
+			previous: LinkParams{Delay: 10},
+			params:   LinkParams{Delay: 20},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			if result := netemNeedsReset(tt.previous, tt.params); result != tt.expected {
+				t.Errorf("Wrong result %v != %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+// linkTestNode is a node only identified by its name
+type linkTestNode struct {
+	INetemNode
+	name string
+}
+
+func (n *linkTestNode) GetName() string {
+	return n.name
+}
+
+func TestTopology_SetLinkParams(t *testing.T) {
+	prjPath, err := ioutil.TempDir("", "gonetem-setlink")
+	if err != nil {
+		t.Fatalf("Unable to create temp folder: %v", err)
+	}
+	defer os.RemoveAll(prjPath)
+	if err := ioutil.WriteFile(path.Join(prjPath, networkFilename), []byte(updateLinkNetwork), 0644); err != nil {
+		t.Fatalf("Unable to write network file: %v", err)
+	}
+
+	lConfig := LinkConfig{Peer1: "R1.0", Peer2: "R2.0", LinkParams: LinkParams{Delay: Duration(10 * time.Millisecond), Loss: 1}}
+	l := &NetemLink{
+		Peer1:  NetemLinkPeer{Node: &linkTestNode{name: "R1"}, IfIndex: 0},
+		Peer2:  NetemLinkPeer{Node: &linkTestNode{name: "R2"}, IfIndex: 0},
+		Config: lConfig,
+	}
+	topo := &NetemTopologyManager{
+		path:     prjPath,
+		topology: &NetemTopology{Links: []LinkConfig{lConfig}},
+		links:    []*NetemLink{l},
+	}
+
+	if err := topo.SetLinkParams("R1", 0, map[string]string{"loss": "5"}); err != nil {
+		t.Fatalf("SetLinkParams returns an error: %v", err)
+	}
+	if l.Config.Loss != 5 {
+		t.Errorf("Link config has not been modified: %v", l.Config.Loss)
+	}
+	if topo.topology.Links[0].Loss != 5 {
+		t.Errorf("Loaded topology has not been modified: %v", topo.topology.Links[0].Loss)
+	}
+
+	data, err := topo.ReadNetworkFile()
+	if err != nil {
+		t.Fatalf("Unable to read network file: %v", err)
+	} else if !strings.Contains(string(data), "loss: 5") {
+		t.Errorf("Network file has not been modified:\n%s", data)
+	}
+}
+
+func TestTopology_CheckNodeOptions(t *testing.T) {
+	tests := []struct {
+		desc          string
+		config        NodeConfig
+		expectedError bool
+	}{
+		{
+			desc: "CheckNodeOptions: valid options",
+			config: NodeConfig{
+				Type:       "docker.host",
+				Sysctls:    map[string]string{"net.ipv4.ip_forward": "0", "net.ipv6.conf.all.seg6_enabled": "1", "kernel.shmmax": "65536"},
+				Env:        map[string]string{"APP_MODE": "lab"},
+				Entrypoint: []string{"/bin/sh", "-c"},
+				Command:    []string{"sleep infinity"},
+			},
+		},
+		{
+			desc:          "CheckNodeOptions: sysctl not namespaced",
+			config:        NodeConfig{Type: "docker.host", Sysctls: map[string]string{"vm.swappiness": "10"}},
+			expectedError: true,
+		},
+		{
+			desc:          "CheckNodeOptions: invalid environment variable",
+			config:        NodeConfig{Type: "docker.host", Env: map[string]string{"APP-MODE": "lab"}},
+			expectedError: true,
+		},
+		{
+			desc:          "CheckNodeOptions: options on a switch",
+			config:        NodeConfig{Type: "ovs", Env: map[string]string{"APP_MODE": "lab"}},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := checkNodeConfig("N1", tt.config, []string{}, nil)
+			if err != nil && !tt.expectedError {
+				t.Fatalf("Unexpected error: %v", err)
+			} else if err == nil && tt.expectedError {
+				t.Fatalf("An error is expected")
+			}
+		})
+	}
+}
+
+func TestTopology_CheckIfOptions(t *testing.T) {
+	tests := []struct {
+		desc          string
+		mtu           int
+		txQueueLen    int
+		expectedError bool
+	}{
+		{desc: "CheckIfOptions: default values"},
+		{desc: "CheckIfOptions: valid values", mtu: 9000, txQueueLen: 10},
+		{desc: "CheckIfOptions: mtu too small", mtu: 10, expectedError: true},
+		{desc: "CheckIfOptions: negative txqueuelen", txQueueLen: -1, expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := checkIfOptions(tt.mtu, tt.txQueueLen)
+			if err != nil && !tt.expectedError {
+				t.Fatalf("Unexpected error: %v", err)
+			} else if err == nil && tt.expectedError {
+				t.Fatalf("An error is expected")
+			}
+		})
+	}
+}
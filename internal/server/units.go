@@ -0,0 +1,148 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	unitValueRE = regexp.MustCompile(`^(-?[0-9]*\.?[0-9]+)\s*([a-zA-Zµ]*)$`)
+
+	durationUnits = map[string]time.Duration{
+		"":     time.Millisecond, // compatibility, a number is in ms
+		"ns":   time.Nanosecond,
+		"us":   time.Microsecond,
+		"µs":   time.Microsecond,
+		"usec": time.Microsecond,
+		"ms":   time.Millisecond,
+		"msec": time.Millisecond,
+		"s":    time.Second,
+		"sec":  time.Second,
+	}
+	bitrateUnits = map[string]float64{
+		"":     1e3, // compatibility, a number is in kbps
+		"bit":  1,
+		"kbit": 1e3,
+		"mbit": 1e6,
+		"gbit": 1e9,
+		"tbit": 1e12,
+	}
+	bitrateNames = []string{"tbit", "gbit", "mbit", "kbit"}
+)
+
+// Duration is a duration in the network file. It is a number of ms
+// or a value with a unit, like 250us, 1.5ms or 1s
+type Duration time.Duration
+
+// Bitrate is a rate, in bits/s, in the network file. It is a number
+// of kbps or a value with a unit, like 56kbit, 100mbit or 10gbit
+type Bitrate int64
+
+func parseUnitValue(value string) (float64, string, error) {
+	match := unitValueRE.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, "", fmt.Errorf("'%s' is not a valid value", value)
+	}
+
+	number, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("'%s' is not a valid value", value)
+	}
+	return number, strings.ToLower(match[2]), nil
+}
+
+// ParseDuration converts value, with an optional unit, in Duration
+func ParseDuration(value string) (Duration, error) {
+	number, unit, err := parseUnitValue(value)
+	if err != nil {
+		return 0, err
+	}
+
+	factor, found := durationUnits[unit]
+	if !found {
+		return 0, fmt.Errorf("'%s': unit '%s' is not valid (us, ms or s expected)", value, unit)
+	}
+	return Duration(math.Round(number * float64(factor))), nil
+}
+
+// ParseBitrate converts value, with an optional unit, in Bitrate
+func ParseBitrate(value string) (Bitrate, error) {
+	number, unit, err := parseUnitValue(value)
+	if err != nil {
+		return 0, err
+	}
+
+	factor, found := bitrateUnits[unit]
+	if !found {
+		return 0, fmt.Errorf("'%s': unit '%s' is not valid (bit, kbit, mbit, gbit or tbit expected)", value, unit)
+	}
+	return Bitrate(math.Round(number * factor)), nil
+}
+
+func (d *Duration) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+
+	duration, err := ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = duration
+	return nil
+}
+
+// MarshalYAML keeps the number of ms when possible
+func (d Duration) MarshalYAML() (interface{}, error) {
+	if time.Duration(d)%time.Millisecond == 0 {
+		return int64(time.Duration(d) / time.Millisecond), nil
+	}
+	return d.String(), nil
+}
+
+func (d Duration) String() string {
+	switch {
+	case time.Duration(d)%time.Millisecond == 0:
+		return fmt.Sprintf("%dms", time.Duration(d)/time.Millisecond)
+	case time.Duration(d)%time.Microsecond == 0:
+		return fmt.Sprintf("%dus", time.Duration(d)/time.Microsecond)
+	}
+	return fmt.Sprintf("%dns", time.Duration(d))
+}
+
+func (r *Bitrate) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var value string
+	if err := unmarshal(&value); err != nil {
+		return err
+	}
+
+	rate, err := ParseBitrate(value)
+	if err != nil {
+		return err
+	}
+	*r = rate
+	return nil
+}
+
+// MarshalYAML keeps the number of kbps when possible
+func (r Bitrate) MarshalYAML() (interface{}, error) {
+	if r%1000 == 0 {
+		return int64(r / 1000), nil
+	}
+	return r.String(), nil
+}
+
+func (r Bitrate) String() string {
+	for _, name := range bitrateNames {
+		factor := Bitrate(bitrateUnits[name])
+		if r != 0 && r%factor == 0 {
+			return fmt.Sprintf("%d%s", r/factor, name)
+		}
+	}
+	return fmt.Sprintf("%dbit", r)
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestUnits_Parse(t *testing.T) {
+	tests := []struct {
+		desc          string
+		content       string
+		expected      LinkParams
+		expectedError bool
+	}{
+		{
+			desc:     "Units: values without unit",
+			content:  "delay: 10\njitter: 2\nrate: 1000",
+			expected: LinkParams{Delay: Duration(10 * time.Millisecond), Jitter: Duration(2 * time.Millisecond), Rate: 1000000},
+		},
+		{
+			desc:     "Units: values with units",
+			content:  "delay: 1.5ms\njitter: 250us\nrate: 56kbit",
+			expected: LinkParams{Delay: Duration(1500 * time.Microsecond), Jitter: Duration(250 * time.Microsecond), Rate: 56000},
+		},
+		{
+			desc:     "Units: rate above 32 bits",
+			content:  "delay: 1s\nrate: 40gbit",
+			expected: LinkParams{Delay: Duration(time.Second), Rate: 40000000000},
+		},
+		{
+			desc:          "Units: unknown time unit",
+			content:       "delay: 10min",
+			expectedError: true,
+		},
+		{
+			desc:          "Units: bytes are not supported for rate",
+			content:       "rate: 10mbps",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			var params LinkParams
+			err := yaml.UnmarshalStrict([]byte(tt.content), &params)
+			if tt.expectedError {
+				if err == nil {
+					t.Errorf("Parsing succeeds but an error is expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unable to parse parameters: %v", err)
+			}
+			if params != tt.expected {
+				t.Errorf("Wrong parameters: %+v != %+v", params, tt.expected)
+			}
+
+			// values are the same after a write/read cycle
+			data, err := yaml.Marshal(&params)
+			if err != nil {
+				t.Fatalf("Unable to write parameters: %v", err)
+			}
+			var written LinkParams
+			if err := yaml.UnmarshalStrict(data, &written); err != nil {
+				t.Fatalf("Unable to parse written parameters: %v", err)
+			}
+			if written != params {
+				t.Errorf("Wrong written parameters: %+v != %+v", written, params)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"time"
+
+	"github.com/mroy31/gonetem/internal/link"
+	"github.com/vishvananda/netns"
+)
+
+const (
+	// period of the position updates when a node moves
+	moveStep = 100 * time.Millisecond
+	// default path loss exponent of the loss model
+	defaultLossExponent = 2.0
+)
+
+// Position of a node, in meters, on a 2D plane
+type Position struct {
+	X float64
+	Y float64
+}
+
+func (p Position) distance(other Position) float64 {
+	return math.Hypot(p.X-other.X, p.Y-other.Y)
+}
+
+// Waypoint is a position reached by a node after Duration
+type Waypoint struct {
+	X        float64
+	Y        float64
+	Duration Duration `yaml:",omitempty"` // travel time from the previous position
+}
+
+// MobilityConfig is the path followed by a node when the topology runs
+type MobilityConfig struct {
+	Waypoints []Waypoint
+	Loop      bool `yaml:",omitempty"`
+}
+
+// WirelessConfig defines how loss and delay between two members of a
+// wireless segment grow with their distance. Loss goes from Loss, for
+// a distance of 0, to MaxLoss at the range of the sender. Beyond this
+// range, all frames are lost
+type WirelessConfig struct {
+	Range    float64  // default range of the nodes, in meters
+	Delay    Duration `yaml:",omitempty"`
+	MaxDelay Duration `yaml:"max_delay,omitempty"`
+	Loss     float64  `yaml:",omitempty"`         // percent
+	MaxLoss  float64  `yaml:"max_loss,omitempty"` // percent
+	Exponent float64  `yaml:"exponent,omitempty"` // default 2
+}
+
+func checkWirelessConfig(w WirelessConfig) []error {
+	errors := make([]error, 0)
+
+	if w.Range <= 0 {
+		errors = append(errors, fmt.Errorf("Wireless range must be > 0"))
+	}
+	if w.Delay < 0 || w.MaxDelay < 0 {
+		errors = append(errors, fmt.Errorf("Wireless delays must be >= 0"))
+	} else if w.MaxDelay > 0 && w.MaxDelay < w.Delay {
+		errors = append(errors, fmt.Errorf("Wireless max_delay must be >= delay"))
+	}
+	if w.Loss < 0 || w.Loss > 100 || w.MaxLoss < 0 || w.MaxLoss > 100 {
+		errors = append(errors, fmt.Errorf("Wireless losses must be between 0 and 100"))
+	} else if w.MaxLoss > 0 && w.MaxLoss < w.Loss {
+		errors = append(errors, fmt.Errorf("Wireless max_loss must be >= loss"))
+	}
+	if w.Exponent < 0 {
+		errors = append(errors, fmt.Errorf("Wireless exponent must be >= 0"))
+	}
+
+	return errors
+}
+
+func checkMobilityConfig(m MobilityConfig) error {
+	if len(m.Waypoints) == 0 {
+		return fmt.Errorf("Mobility: at least one waypoint is required")
+	}
+	total := Duration(0)
+	for _, wp := range m.Waypoints {
+		if wp.Duration < 0 {
+			return fmt.Errorf("Mobility: duration of waypoints must be >= 0")
+		}
+		total += wp.Duration
+	}
+	if m.Loop && total == 0 {
+		return fmt.Errorf("Mobility: duration of a loop must be > 0")
+	}
+	return nil
+}
+
+// pairParams returns the impairments of frames sent by a node located
+// at from, with a radio range rng, to a node located at to
+func (w WirelessConfig) pairParams(from, to Position, rng float64) link.NetemParams {
+	if rng == 0 {
+		rng = w.Range
+	}
+	d := from.distance(to)
+	if d > rng {
+		return link.NetemParams{Loss: 100}
+	}
+
+	maxDelay, maxLoss, exponent := w.MaxDelay, w.MaxLoss, w.Exponent
+	if maxDelay < w.Delay {
+		maxDelay = w.Delay
+	}
+	if maxLoss < w.Loss {
+		maxLoss = w.Loss
+	}
+	if exponent == 0 {
+		exponent = defaultLossExponent
+	}
+
+	ratio := d / rng
+	delay := float64(w.Delay) + float64(maxDelay-w.Delay)*ratio
+	return link.NetemParams{
+		Delay: time.Duration(math.Round(delay)),
+		Loss:  w.Loss + (maxLoss-w.Loss)*math.Pow(ratio, exponent),
+	}
+}
+
+// wirelessSegment contains the state of a wireless segment
+// required to update the impairments between its members
+type wirelessSegment struct {
+	Config     WirelessConfig
+	Ranges     []float64 // radio range of each member
+	Macs       []net.HardwareAddr
+	HubIfNames []string
+}
+
+func (t *NetemTopologyManager) getPosition(name string) Position {
+	t.moveLock.Lock()
+	defer t.moveLock.Unlock()
+	return t.positions[name]
+}
+
+func (t *NetemTopologyManager) wirelessPeer(segment *NetemSegment, from, to int) link.WirelessPeer {
+	w := segment.Wireless
+	return link.WirelessPeer{
+		Index: from,
+		Mac:   w.Macs[from],
+		Params: w.Config.pairParams(
+			t.getPosition(segment.Members[from].Node.GetName()),
+			t.getPosition(segment.Members[to].Node.GetName()),
+			w.Ranges[from]),
+	}
+}
+
+// setupWirelessPorts creates the qdiscs of the hub ports once all the
+// members of the segment are connected
+func (t *NetemTopologyManager) setupWirelessPorts(segment *NetemSegment, segmentNs netns.NsHandle) error {
+	for to := range segment.Members {
+		peers := make([]link.WirelessPeer, 0, len(segment.Members)-1)
+		for from := range segment.Members {
+			if from != to {
+				peers = append(peers, t.wirelessPeer(segment, from, to))
+			}
+		}
+
+		if err := link.CreateWirelessPort(segment.Wireless.HubIfNames[to], segmentNs, peers); err != nil {
+			return fmt.Errorf("Unable to setup wireless segment %s: %w", segment.Name, err)
+		}
+	}
+	return nil
+}
+
+// updateWirelessNode updates the impairments between the node name
+// and the other members of the wireless segments
+func (t *NetemTopologyManager) updateWirelessNode(name string) error {
+	for _, segment := range t.segments {
+		if segment.Wireless == nil {
+			continue
+		}
+
+		segmentNs, err := netns.GetFromName(segment.Netns)
+		if err != nil {
+			return fmt.Errorf("Unable to get netns of segment %s: %v", segment.Name, err)
+		}
+
+		for idx, member := range segment.Members {
+			if member.Node.GetName() != name {
+				continue
+			}
+			for other := range segment.Members {
+				if other == idx {
+					continue
+				}
+				// frames sent by the node and received by the node
+				for _, pair := range [][2]int{{idx, other}, {other, idx}} {
+					peer := t.wirelessPeer(segment, pair[0], pair[1])
+					if err := link.UpdateWirelessPeer(segment.Wireless.HubIfNames[pair[1]], segmentNs, peer); err != nil {
+						segmentNs.Close()
+						return err
+					}
+				}
+			}
+		}
+		segmentNs.Close()
+	}
+
+	return nil
+}
+
+func (t *NetemTopologyManager) setPosition(name string, pos Position) error {
+	t.moveLock.Lock()
+	t.positions[name] = pos
+	t.moveLock.Unlock()
+
+	return t.updateWirelessNode(name)
+}
+
+// nodeMoveRunner moves a node along waypoints in its own goroutine
+type nodeMoveRunner struct {
+	name     string
+	mobility MobilityConfig
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// moveTo moves the node linearly to wp and returns false if the
+// runner has been stopped
+func (r *nodeMoveRunner) moveTo(t *NetemTopologyManager, wp Waypoint) bool {
+	start := t.getPosition(r.name)
+	target := Position{X: wp.X, Y: wp.Y}
+	steps := int((time.Duration(wp.Duration) + moveStep - 1) / moveStep)
+
+	ticker := time.NewTicker(moveStep)
+	defer ticker.Stop()
+	for step := 1; step <= steps; step++ {
+		select {
+		case <-ticker.C:
+		case <-r.stop:
+			return false
+		}
+
+		ratio := float64(step) / float64(steps)
+		pos := Position{
+			X: start.X + (target.X-start.X)*ratio,
+			Y: start.Y + (target.Y-start.Y)*ratio,
+		}
+		if err := t.setPosition(r.name, pos); err != nil {
+			t.logger.Errorf("Mobility: unable to move node %s: %v", r.name, err)
+		}
+	}
+
+	if steps == 0 {
+		if err := t.setPosition(r.name, target); err != nil {
+			t.logger.Errorf("Mobility: unable to move node %s: %v", r.name, err)
+		}
+	}
+	return true
+}
+
+func (r *nodeMoveRunner) run(t *NetemTopologyManager) {
+	defer close(r.done)
+
+	for {
+		for _, wp := range r.mobility.Waypoints {
+			if !r.moveTo(t, wp) {
+				return
+			}
+		}
+		if !r.mobility.Loop {
+			return
+		}
+	}
+}
+
+func (t *NetemTopologyManager) isWirelessNode(name string) bool {
+	t.moveLock.Lock()
+	defer t.moveLock.Unlock()
+	_, found := t.positions[name]
+	return found
+}
+
+// StartNodeMobility moves the node name along the waypoints
+// of mobility. A previous movement of this node is stopped
+func (t *NetemTopologyManager) StartNodeMobility(name string, mobility MobilityConfig) error {
+	if !t.running {
+		return fmt.Errorf("Topology is not running")
+	}
+	if t.GetNode(name) == nil {
+		return fmt.Errorf("Node %s not found in the topology", name)
+	}
+	if !t.isWirelessNode(name) {
+		return fmt.Errorf("Node %s has no position", name)
+	}
+	if err := checkMobilityConfig(mobility); err != nil {
+		return err
+	}
+
+	t.stopNodeMobility(name)
+	runner := &nodeMoveRunner{
+		name:     name,
+		mobility: mobility,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	t.moveLock.Lock()
+	t.moves[name] = runner
+	t.moveLock.Unlock()
+
+	go runner.run(t)
+	return nil
+}
+
+// MoveNode moves the node name to (x, y) in duration
+func (t *NetemTopologyManager) MoveNode(name string, x, y float64, duration time.Duration) error {
+	return t.StartNodeMobility(name, MobilityConfig{
+		Waypoints: []Waypoint{{X: x, Y: y, Duration: Duration(duration)}},
+	})
+}
+
+// stopNodeMobility stops the movement of the node and
+// returns true if the node was moving
+func (t *NetemTopologyManager) stopNodeMobility(name string) bool {
+	t.moveLock.Lock()
+	runner, found := t.moves[name]
+	delete(t.moves, name)
+	t.moveLock.Unlock()
+
+	if !found {
+		return false
+	}
+
+	close(runner.stop)
+	<-runner.done
+	return true
+}
+
+// startAllNodeMobility starts the mobility defined in the network
+// file for each node
+func (t *NetemTopologyManager) startAllNodeMobility() error {
+	t.moveLock.Lock()
+	mobility := make(map[string]MobilityConfig, len(t.mobility))
+	for name, m := range t.mobility {
+		mobility[name] = m
+	}
+	t.moveLock.Unlock()
+
+	for name, m := range mobility {
+		if err := t.StartNodeMobility(name, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *NetemTopologyManager) stopAllNodeMobility() {
+	t.moveLock.Lock()
+	names := make([]string, 0, len(t.moves))
+	for name := range t.moves {
+		names = append(names, name)
+	}
+	t.moveLock.Unlock()
+
+	for _, name := range names {
+		t.stopNodeMobility(name)
+	}
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestWireless_PairParams(t *testing.T) {
+	config := WirelessConfig{
+		Range:    100,
+		Delay:    Duration(time.Millisecond),
+		MaxDelay: Duration(5 * time.Millisecond),
+		Loss:     1,
+		MaxLoss:  21,
+	}
+
+	tests := []struct {
+		desc          string
+		to            Position
+		rng           float64
+		expectedDelay time.Duration
+		expectedLoss  float64
+	}{
+		{
+			desc:          "Wireless: same position",
+			to:            Position{0, 0},
+			expectedDelay: time.Millisecond,
+			expectedLoss:  1,
+		},
+		{
+			desc:          "Wireless: half of the range",
+			to:            Position{30, 40},
+			expectedDelay: 3 * time.Millisecond,
+			expectedLoss:  6,
+		},
+		{
+			desc:          "Wireless: at the range",
+			to:            Position{0, 100},
+			expectedDelay: 5 * time.Millisecond,
+			expectedLoss:  21,
+		},
+		{
+			desc:         "Wireless: out of range",
+			to:           Position{0, 101},
+			expectedLoss: 100,
+		},
+		{
+			desc:          "Wireless: range of the node",
+			to:            Position{0, 101},
+			rng:           202,
+			expectedDelay: 3 * time.Millisecond,
+			expectedLoss:  6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			params := config.pairParams(Position{0, 0}, tt.to, tt.rng)
+			if params.Delay != tt.expectedDelay {
+				t.Errorf("Wrong delay: %v != %v", params.Delay, tt.expectedDelay)
+			}
+			if math.Abs(params.Loss-tt.expectedLoss) > 1e-9 {
+				t.Errorf("Wrong loss: %f != %f", params.Loss, tt.expectedLoss)
+			}
+		})
+	}
+}
+
+func TestWireless_CheckMobility(t *testing.T) {
+	tests := []struct {
+		desc          string
+		mobility      MobilityConfig
+		expectedError bool
+	}{
+		{
+			desc: "Mobility: valid loop",
+			mobility: MobilityConfig{
+				Waypoints: []Waypoint{{X: 10, Duration: Duration(time.Second)}, {X: 0}},
+				Loop:      true,
+			},
+		},
+		{
+			desc:          "Mobility: no waypoint",
+			mobility:      MobilityConfig{},
+			expectedError: true,
+		},
+		{
+			desc: "Mobility: loop without duration",
+			mobility: MobilityConfig{
+				Waypoints: []Waypoint{{X: 10}, {X: 0}},
+				Loop:      true,
+			},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := checkMobilityConfig(tt.mobility)
+			if tt.expectedError && err == nil {
+				t.Errorf("Error expected but not found")
+			} else if !tt.expectedError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}